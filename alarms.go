@@ -0,0 +1,117 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// alarmZone is a range of the angle domain watched for the servo entering or exiting it
+	alarmZone struct {
+		lowMilliDegrees        uint32
+		highMilliDegrees       uint32
+		hysteresisMilliDegrees uint32
+		onEnter                func(zone int)
+		onExit                 func(zone int)
+		isInside               bool
+	}
+)
+
+// AddAlarmZone watches a range of angles, calling a function when the commanded angle enters it and another one when
+// it exits it, e.g. when a throttle servo exceeds 80% or a rudder approaches its limit. The servo must move past the
+// hysteresis beyond the range to exit it, so a servo hovering at the edge doesn't fire the callbacks repeatedly.
+// The callbacks run within the command that moved the servo
+//
+// Parameters:
+//
+// lowAngle: The lowest absolute angle of the zone
+// highAngle: The highest absolute angle of the zone
+// hysteresis: The degrees beyond the zone the servo must move to exit it
+// onEnter: The function called with the zone index when the servo enters the zone, or nil
+// onExit: The function called with the zone index when the servo exits the zone, or nil
+//
+// Returns:
+//
+// The index of the zone, and an error if the range is inverted or no more zones can be watched
+func (h *DefaultHandler) AddAlarmZone(
+	lowAngle uint16,
+	highAngle uint16,
+	hysteresis uint16,
+	onEnter func(zone int),
+	onExit func(zone int),
+) (int, tinygoerrors.ErrorCode) {
+	if lowAngle > highAngle {
+		return 0, ErrorCodeServoInvalidAlarmZone
+	}
+	if h.alarmZonesCount == MaxAlarmZones {
+		return 0, ErrorCodeServoTooManyAlarmZones
+	}
+
+	// Start inside the zone if the servo already is, without calling the enter function
+	zone := alarmZone{
+		lowMilliDegrees:        uint32(lowAngle) * 1000,
+		highMilliDegrees:       uint32(highAngle) * 1000,
+		hysteresisMilliDegrees: uint32(hysteresis) * 1000,
+		onEnter:                onEnter,
+		onExit:                 onExit,
+	}
+	angle := h.GetAngleMilliDegrees()
+	zone.isInside = angle >= zone.lowMilliDegrees && angle <= zone.highMilliDegrees
+
+	index := h.alarmZonesCount
+	h.alarmZones[index] = zone
+	h.alarmZonesCount++
+	return index, tinygoerrors.ErrorCodeNil
+}
+
+// ClearAlarmZones stops watching every alarm zone
+func (h *DefaultHandler) ClearAlarmZones() {
+	h.alarmZonesCount = 0
+}
+
+// IsInAlarmZone returns whether the servo is inside an alarm zone
+//
+// Parameters:
+//
+// zone: The index of the zone
+//
+// Returns:
+//
+// True if the servo is inside the zone, false otherwise or if there is no zone with the index
+func (h *DefaultHandler) IsInAlarmZone(zone int) bool {
+	if zone < 0 || zone >= h.alarmZonesCount {
+		return false
+	}
+	return h.alarmZones[zone].isInside
+}
+
+// updateAlarmZones checks the alarm zones against a new angle, calling the functions of the zones entered or exited
+//
+// Parameters:
+//
+// milliDegrees: The new angle in millidegrees
+func (h *DefaultHandler) updateAlarmZones(milliDegrees uint32) {
+	for index := 0; index < h.alarmZonesCount; index++ {
+		zone := &h.alarmZones[index]
+		if !zone.isInside {
+			// Enter the zone once the angle is within its range
+			if milliDegrees >= zone.lowMilliDegrees && milliDegrees <= zone.highMilliDegrees {
+				zone.isInside = true
+				if zone.onEnter != nil {
+					zone.onEnter(index)
+				}
+			}
+			continue
+		}
+
+		// Exit the zone once the angle is beyond its range and the hysteresis, without underflowing
+		isBelow := zone.lowMilliDegrees >= zone.hysteresisMilliDegrees &&
+			milliDegrees < zone.lowMilliDegrees-zone.hysteresisMilliDegrees
+		isAbove := milliDegrees > zone.highMilliDegrees+zone.hysteresisMilliDegrees
+		if isBelow || isAbove {
+			zone.isInside = false
+			if zone.onExit != nil {
+				zone.onExit(index)
+			}
+		}
+	}
+}
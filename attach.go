@@ -0,0 +1,77 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// detachMessage is the message logged when the servo is detached
+	detachMessage = []byte("Servo detached")
+
+	// attachMessage is the message logged when the servo is attached again
+	attachMessage = []byte("Servo attached")
+)
+
+// Detach stops driving the signal line, so the servo goes limp and stops buzzing or hunting around its angle. Unlike
+// Sleep, new angles don't resume the pulses: they are stored and applied by Attach
+func (h *DefaultHandler) Detach() {
+	// Check if the servo is already detached
+	if h.isDetached {
+		return
+	}
+	h.stopPulses()
+	h.isDetached = true
+	h.isRefreshing = false
+
+	// Log the detach if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(detachMessage)
+	}
+}
+
+// Attach resumes driving the signal line at the last commanded angle, ending the low-power mode. The servo is warmed
+// up first if warm-up frames are set, since it may have lost power while detached
+//
+// Returns:
+//
+// An error if the PWM peripheral could not be configured
+func (h *DefaultHandler) Attach() tinygoerrors.ErrorCode {
+	// Check if the servo is detached
+	if !h.isDetached {
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.isDetached = false
+
+	// Resume the pulses, warming up the servo if needed
+	if h.warmUpFrames != 0 {
+		if err := h.StartWarmUp(); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+	} else {
+		if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		h.isSleeping = false
+		h.isRefreshing = false
+		if h.canWritePulse() {
+			h.writePulse(h.pulse)
+		}
+	}
+
+	// Log the attach if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(attachMessage)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// IsDetached checks if the servo has stopped driving the signal line
+//
+// Returns:
+//
+// True if the servo is detached, false otherwise
+func (h *DefaultHandler) IsDetached() bool {
+	return h.isDetached
+}
@@ -0,0 +1,184 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// CalibrationPoint is a measured point of the response of a servo: the pulse width that moves it to an angle
+	CalibrationPoint struct {
+		AngleMilliDegrees uint32
+		PulseWidth        Nanoseconds
+	}
+
+	// CalibrationTable is a list of measured points of the response of a servo, interpolated linearly between them
+	// instead of the single linear mapping between the min and max pulse widths, for the cheap servos that are
+	// visibly non-linear near the ends of their travel. The same table can be shared by the handlers of identical
+	// servos
+	CalibrationTable struct {
+		points [MaxCalibrationPoints]CalibrationPoint
+		count  int
+	}
+)
+
+// NewCalibrationTable creates a new instance of CalibrationTable
+//
+// Parameters:
+//
+// points: The measured points, ordered by strictly increasing angles and pulse widths. The angles before the first
+// point and after the last one are interpolated up to the min and max pulse widths of the handler, unless the points
+// are at 0 degrees and at the actuation range
+//
+// Returns:
+//
+// An instance of CalibrationTable and an error if there are no points, too many of them or they are not ordered
+func NewCalibrationTable(points []CalibrationPoint) (*CalibrationTable, tinygoerrors.ErrorCode) {
+	if len(points) == 0 || len(points) > MaxCalibrationPoints {
+		return nil, ErrorCodeServoInvalidCalibration
+	}
+	table := &CalibrationTable{count: len(points)}
+	for index, point := range points {
+		if index > 0 {
+			previous := points[index-1]
+			if point.AngleMilliDegrees <= previous.AngleMilliDegrees || point.PulseWidth <= previous.PulseWidth {
+				return nil, ErrorCodeServoInvalidCalibration
+			}
+		}
+		table.points[index] = point
+	}
+	return table, tinygoerrors.ErrorCodeNil
+}
+
+// Points returns the measured points of the table
+//
+// Returns:
+//
+// The points, ordered by increasing angles
+func (t *CalibrationTable) Points() []CalibrationPoint {
+	return t.points[:t.count]
+}
+
+// SetCalibrationTable sets the calibration table the angles are mapped to pulse widths with. It takes precedence over
+// the neutral pulse width, and the pulse of the current angle is recalculated
+//
+// Parameters:
+//
+// table: The calibration table, or nil to map the angles between the min and max pulse widths again
+//
+// Returns:
+//
+// An error if a point is beyond the actuation range or its pulse width is outside the min and max pulse widths, or
+// the table isn't strictly increasing up to them
+func (h *DefaultHandler) SetCalibrationTable(table *CalibrationTable) tinygoerrors.ErrorCode {
+	if table != nil {
+		// The first and last points may replace the ends of the pulse range, the other ones must lie within it
+		first := table.points[0]
+		last := table.points[table.count-1]
+		if last.AngleMilliDegrees > uint32(h.actuationRange)*1000 {
+			return h.reportError(ErrorCodeServoInvalidCalibration)
+		}
+		if first.PulseWidth < h.minPulseWidth || (first.AngleMilliDegrees != 0 && first.PulseWidth == h.minPulseWidth) {
+			return h.reportError(ErrorCodeServoInvalidCalibration)
+		}
+		if last.PulseWidth > h.maxPulseWidth ||
+			(last.AngleMilliDegrees != uint32(h.actuationRange)*1000 && last.PulseWidth == h.maxPulseWidth) {
+			return h.reportError(ErrorCodeServoInvalidCalibration)
+		}
+	}
+	h.calibration = table
+	h.recalculatePulse()
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetCalibrationTable returns the calibration table set by SetCalibrationTable
+//
+// Returns:
+//
+// The calibration table, or nil if the angles are mapped between the min and max pulse widths
+func (h *DefaultHandler) GetCalibrationTable() *CalibrationTable {
+	return h.calibration
+}
+
+// calibrationPoint returns a point of the calibration table, framed by the ends of the pulse range
+//
+// Parameters:
+//
+// index: The index of the point, zero for the min pulse width and the number of points plus one for the max pulse
+// width
+//
+// Returns:
+//
+// The point
+func (h *DefaultHandler) calibrationPoint(index int) CalibrationPoint {
+	if index == 0 {
+		return CalibrationPoint{PulseWidth: h.minPulseWidth}
+	}
+	if index > h.calibration.count {
+		return CalibrationPoint{AngleMilliDegrees: uint32(h.actuationRange) * 1000, PulseWidth: h.maxPulseWidth}
+	}
+	return h.calibration.points[index-1]
+}
+
+// calculateCalibratedPulseMilliDegrees calculates the pulse width for the given angle in millidegrees, interpolating
+// between the points of the calibration table that frame it
+//
+// Parameters:
+//
+// milliDegrees: The angle to convert in millidegrees, must be between 0 and the actuation range
+//
+// Returns:
+//
+// The pulse width for the given angle
+func (h *DefaultHandler) calculateCalibratedPulseMilliDegrees(milliDegrees uint32) Nanoseconds {
+	from := h.calibrationPoint(0)
+	for index := 1; index <= h.calibration.count+1; index++ {
+		to := h.calibrationPoint(index)
+		if milliDegrees <= to.AngleMilliDegrees {
+			// Avoid division by zero when a point replaces an end of the pulse range
+			if to.AngleMilliDegrees == from.AngleMilliDegrees {
+				return to.PulseWidth
+			}
+			return from.PulseWidth + Nanoseconds(
+				divideRounded(
+					uint64(to.PulseWidth-from.PulseWidth)*uint64(milliDegrees-from.AngleMilliDegrees),
+					uint64(to.AngleMilliDegrees-from.AngleMilliDegrees),
+					h.rounding,
+				),
+			)
+		}
+		from = to
+	}
+	return h.maxPulseWidth
+}
+
+// calculateCalibratedMilliDegrees calculates the angle in millidegrees closest to the given pulse width, the inverse
+// of calculateCalibratedPulseMilliDegrees
+//
+// Parameters:
+//
+// pulse: The pulse width to convert, between the min and max pulse widths
+//
+// Returns:
+//
+// The angle in millidegrees
+func (h *DefaultHandler) calculateCalibratedMilliDegrees(pulse Nanoseconds) uint32 {
+	from := h.calibrationPoint(0)
+	for index := 1; index <= h.calibration.count+1; index++ {
+		to := h.calibrationPoint(index)
+		if pulse <= to.PulseWidth {
+			// Avoid division by zero when a point replaces an end of the pulse range
+			if to.PulseWidth == from.PulseWidth {
+				return to.AngleMilliDegrees
+			}
+			return from.AngleMilliDegrees + uint32(
+				divideRounded(
+					uint64(pulse-from.PulseWidth)*uint64(to.AngleMilliDegrees-from.AngleMilliDegrees),
+					uint64(to.PulseWidth-from.PulseWidth),
+					RoundingNearest,
+				),
+			)
+		}
+		from = to
+	}
+	return uint32(h.actuationRange) * 1000
+}
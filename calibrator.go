@@ -0,0 +1,320 @@
+package tinygo_servo
+
+import (
+	tinygobuffers "github.com/ralvarezdev/tinygo-buffers"
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// calibratorPhaseLabels are the prompts of the phases, followed by the current pulse width
+	calibratorPhaseLabels = [...][]byte{
+		CalibratorPhaseMin:    []byte("MIN "),
+		CalibratorPhaseCenter: []byte("CENTER "),
+		CalibratorPhaseMax:    []byte("MAX "),
+		CalibratorPhaseDone:   []byte("DONE "),
+	}
+
+	// calibratorErrorResponse precedes the prompt when a command fails
+	calibratorErrorResponse = []byte("ERR\r\n")
+
+	// calibratorSaveCommand marks the current pulse width
+	calibratorSaveCommand = []byte("save")
+
+	// calibratorMarkMinCommand marks the current pulse width as the min pulse width
+	calibratorMarkMinCommand = []byte("[")
+
+	// calibratorMarkMaxCommand marks the current pulse width as the max pulse width
+	calibratorMarkMaxCommand = []byte("]")
+
+	// calibratorFinishCommand finishes the calibration with the marked pulse widths
+	calibratorFinishCommand = []byte("w")
+
+	// calibratorSeparator separates the values of a response
+	calibratorSeparator = []byte(" ")
+
+	// calibratorNewline terminates a response
+	calibratorNewline = []byte("\r\n")
+)
+
+type (
+	// Calibrator walks the user through finding the min, center and max pulse widths of a servo from a serial
+	// terminal, with no buttons or display on the device. Every command line is answered with a prompt naming the
+	// pulse width being looked for and the current one in microseconds:
+	//
+	//	+     steps the pulse width up, "+++" steps it up three times
+	//	-     steps the pulse width down, "---" steps it down three times
+	//	save  marks the current pulse width as the one being looked for
+	//	[     marks the current pulse width as the min pulse width
+	//	]     marks the current pulse width as the max pulse width
+	//	w     finishes the calibration with the marked pulse widths, the center being optional
+	//	?     prints the prompt again
+	//
+	// The min pulse width is the lowest one the servo still follows, the center is its mechanical center and the max
+	// pulse width the highest one it still follows. The pulse width being looked for is the first one not marked yet,
+	// so save walks from the min pulse width to the center and then the max pulse width, while [ and ] mark the ends
+	// in any order. Once the three are marked, or the ends are and w is sent, the prompt is DONE followed by the min,
+	// center and max pulse widths in nanoseconds, the center being 0 if not marked, and Config returns them so the
+	// servo can be created again, or the configuration stored, right away.
+	//
+	// The commands are transport agnostic, the lines are read and the responses written by the caller, e.g. over the
+	// USB serial port. The handler under calibration must accept the pulse widths being explored, so it should be
+	// created with a wide pulse range and no limits
+	Calibrator struct {
+		servo         Handler
+		base          Config
+		step          Microseconds
+		phase         CalibratorPhase
+		minPulseWidth Microseconds
+		centerPulse   Microseconds
+		maxPulseWidth Microseconds
+	}
+)
+
+// NewCalibrator creates a new instance of Calibrator
+//
+// Parameters:
+//
+// servo: The servo to calibrate
+// base: The configuration the calibrated pulse widths are written into, its pulse range is where the calibration
+// starts
+// step: The pulse width step of the + and - commands in microseconds, or zero for DefaultCalibratorStep
+//
+// Returns:
+//
+// An instance of Calibrator and an error if the servo is nil
+func NewCalibrator(servo Handler, base Config, step Microseconds) (*Calibrator, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeServoNilHandler
+	}
+	if step == 0 {
+		step = DefaultCalibratorStep
+	}
+	return &Calibrator{servo: servo, base: base, step: step}, tinygoerrors.ErrorCodeNil
+}
+
+// Start starts the calibration over, moving the servo to the middle of the base pulse range, or to its neutral pulse
+// width if set, to look for the min pulse width
+//
+// Parameters:
+//
+// response: The buffer the prompt is appended to
+//
+// Returns:
+//
+// The response and an error if the servo could not be moved
+func (c *Calibrator) Start(response []byte) ([]byte, tinygoerrors.ErrorCode) {
+	c.phase = CalibratorPhaseMin
+	c.minPulseWidth = 0
+	c.centerPulse = 0
+	c.maxPulseWidth = 0
+	err := c.servo.SetPulseMicroseconds(uint32(c.startPulse()))
+	return c.respond(response, err), err
+}
+
+// HandleCommand executes a command line
+//
+// Parameters:
+//
+// line: The command line without the line terminator
+// response: The buffer the response is appended to
+//
+// Returns:
+//
+// The response and an error if the command is unknown, the servo could not be moved, the calibration is finished
+// before both ends are marked or the marked pulse widths are not in ascending order
+func (c *Calibrator) HandleCommand(line []byte, response []byte) ([]byte, tinygoerrors.ErrorCode) {
+	err := tinygoerrors.ErrorCodeNil
+	switch {
+	case len(line) == 0 || (len(line) == 1 && line[0] == '?'):
+	case c.phase == CalibratorPhaseDone:
+		err = ErrorCodeServoInvalidCalibratorCommand
+	case isRepeatedCommand(line, '+'):
+		err = c.servo.SetPulseMicroseconds(c.servo.GetPulseMicroseconds() + uint32(c.step)*uint32(len(line)))
+	case isRepeatedCommand(line, '-'):
+		decrement := uint32(c.step) * uint32(len(line))
+		pulse := c.servo.GetPulseMicroseconds()
+		if pulse <= decrement {
+			err = ErrorCodeServoInvalidPulseWidth
+			break
+		}
+		err = c.servo.SetPulseMicroseconds(pulse - decrement)
+	case string(line) == string(calibratorSaveCommand):
+		err = c.save()
+	case string(line) == string(calibratorMarkMinCommand):
+		c.minPulseWidth = Microseconds(c.servo.GetPulseMicroseconds())
+		err = c.advance()
+	case string(line) == string(calibratorMarkMaxCommand):
+		c.maxPulseWidth = Microseconds(c.servo.GetPulseMicroseconds())
+		err = c.advance()
+	case string(line) == string(calibratorFinishCommand):
+		err = c.finish()
+	default:
+		err = ErrorCodeServoInvalidCalibratorCommand
+	}
+	return c.respond(response, err), err
+}
+
+// Phase returns the pulse width being looked for
+//
+// Returns:
+//
+// The phase of the calibration, CalibratorPhaseDone once the three pulse widths are saved
+func (c *Calibrator) Phase() CalibratorPhase {
+	return c.phase
+}
+
+// Config returns the base configuration with the calibrated pulse widths, the center one, if marked, being the
+// neutral pulse width
+//
+// Returns:
+//
+// The configuration and an error if the calibration is not done or the configuration is invalid, e.g. the max pulse
+// width doesn't fit in the period
+func (c *Calibrator) Config() (Config, tinygoerrors.ErrorCode) {
+	if c.phase != CalibratorPhaseDone {
+		return Config{}, ErrorCodeServoCalibrationIncomplete
+	}
+	var pulseWidths [3]Nanoseconds
+	for index, pulse := range [...]Microseconds{c.minPulseWidth, c.centerPulse, c.maxPulseWidth} {
+		pulseWidth, ok := microsecondsToNanoseconds(pulse)
+		if !ok {
+			return Config{}, ErrorCodeServoInvalidPulseWidth
+		}
+		pulseWidths[index] = pulseWidth
+	}
+	config := c.base
+	config.MinPulseWidth = pulseWidths[0]
+	config.NeutralPulseWidth = pulseWidths[1]
+	config.MaxPulseWidth = pulseWidths[2]
+	if err := config.Validate(); err != tinygoerrors.ErrorCodeNil {
+		return Config{}, err
+	}
+	return config, tinygoerrors.ErrorCodeNil
+}
+
+// save marks the current pulse width as the one being looked for and moves on to the next one. The center is then
+// looked for from the start pulse width again, and the max pulse width from the center
+//
+// Returns:
+//
+// An error if the pulse width is not above the previously marked one, the marked pulse widths are not in ascending
+// order or the servo could not be moved
+func (c *Calibrator) save() tinygoerrors.ErrorCode {
+	pulse := Microseconds(c.servo.GetPulseMicroseconds())
+	switch c.phase {
+	case CalibratorPhaseMin:
+		c.minPulseWidth = pulse
+		if err := c.advance(); err != tinygoerrors.ErrorCodeNil || c.phase != CalibratorPhaseCenter {
+			return err
+		}
+		return c.servo.SetPulseMicroseconds(uint32(max(c.startPulse(), pulse+c.step)))
+	case CalibratorPhaseCenter:
+		if pulse <= c.minPulseWidth {
+			return ErrorCodeServoInvalidCalibration
+		}
+		c.centerPulse = pulse
+	case CalibratorPhaseMax:
+		if pulse <= c.centerPulse {
+			return ErrorCodeServoInvalidCalibration
+		}
+		c.maxPulseWidth = pulse
+	}
+	return c.advance()
+}
+
+// advance moves on to the first pulse width not marked yet, finishing the calibration once the three are marked
+//
+// Returns:
+//
+// An error if the three pulse widths are marked but not in ascending order
+func (c *Calibrator) advance() tinygoerrors.ErrorCode {
+	switch {
+	case c.minPulseWidth == 0:
+		c.phase = CalibratorPhaseMin
+	case c.centerPulse == 0:
+		c.phase = CalibratorPhaseCenter
+	case c.maxPulseWidth == 0:
+		c.phase = CalibratorPhaseMax
+	default:
+		return c.finish()
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// finish finishes the calibration with the marked pulse widths, the center being optional
+//
+// Returns:
+//
+// An error if the min or max pulse width is not marked or the marked pulse widths are not in ascending order, in
+// which case the calibration goes on
+func (c *Calibrator) finish() tinygoerrors.ErrorCode {
+	if c.minPulseWidth == 0 || c.maxPulseWidth == 0 {
+		return ErrorCodeServoCalibrationIncomplete
+	}
+	if c.maxPulseWidth <= c.minPulseWidth ||
+		(c.centerPulse != 0 && (c.centerPulse <= c.minPulseWidth || c.centerPulse >= c.maxPulseWidth)) {
+		return ErrorCodeServoInvalidCalibration
+	}
+	c.phase = CalibratorPhaseDone
+	return tinygoerrors.ErrorCodeNil
+}
+
+// startPulse returns the pulse width the calibration starts at
+//
+// Returns:
+//
+// The neutral pulse width of the base configuration if set, otherwise the middle of its pulse range, in
+// microseconds
+func (c *Calibrator) startPulse() Microseconds {
+	if c.base.NeutralPulseWidth != 0 {
+		return nanosecondsToMicroseconds(c.base.NeutralPulseWidth)
+	}
+	return nanosecondsToMicroseconds(c.base.MinPulseWidth/2 + c.base.MaxPulseWidth/2)
+}
+
+// respond appends the prompt of the current phase to the response, preceded by the error line if the command failed
+//
+// Parameters:
+//
+// response: The buffer the prompt is appended to
+// err: The error of the command
+//
+// Returns:
+//
+// The response
+func (c *Calibrator) respond(response []byte, err tinygoerrors.ErrorCode) []byte {
+	if err != tinygoerrors.ErrorCodeNil {
+		response = append(response, calibratorErrorResponse...)
+	}
+	response = append(response, calibratorPhaseLabels[c.phase]...)
+	if c.phase != CalibratorPhaseDone {
+		response = append(response, tinygobuffers.UintToDecimal(uint64(c.servo.GetPulseMicroseconds()))...)
+		return append(response, calibratorNewline...)
+	}
+	for index, pulse := range [...]Microseconds{c.minPulseWidth, c.centerPulse, c.maxPulseWidth} {
+		if index > 0 {
+			response = append(response, calibratorSeparator...)
+		}
+		response = append(response, tinygobuffers.UintToDecimal(uint64(pulse)*uint64(nanosecondsPerMicrosecond))...)
+	}
+	return append(response, calibratorNewline...)
+}
+
+// isRepeatedCommand checks if a command line only repeats a command character
+//
+// Parameters:
+//
+// line: The command line
+// command: The command character
+//
+// Returns:
+//
+// True if every character of the line is the command character, false otherwise
+func isRepeatedCommand(line []byte, command byte) bool {
+	for _, character := range line {
+		if character != command {
+			return false
+		}
+	}
+	return len(line) > 0
+}
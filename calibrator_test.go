@@ -0,0 +1,128 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// newTestCalibrator creates a calibrator of a handler writing to a fake output over the default pulse range, moved to
+// its start pulse width
+func newTestCalibrator(t *testing.T) *Calibrator {
+	t.Helper()
+	handler, err := NewOutputHandler(servotest.NewOutput())
+	if err != 0 {
+		t.Fatalf("NewOutputHandler: %d", err)
+	}
+	releaseAll(t, handler)
+	calibrator, err := NewCalibrator(handler, Config{
+		Frequency:      DefaultFrequency,
+		MinPulseWidth:  DefaultMinPulseWidth,
+		MaxPulseWidth:  DefaultMaxPulseWidth,
+		ActuationRange: StandardActuationRange,
+		CenterAngle:    90,
+	}, 100)
+	if err != 0 {
+		t.Fatalf("NewCalibrator: %d", err)
+	}
+	if _, err = calibrator.Start(nil); err != 0 {
+		t.Fatalf("Start: %d", err)
+	}
+	return calibrator
+}
+
+// runCalibratorCommands passes the command lines to a calibrator, failing on the first error
+func runCalibratorCommands(t *testing.T, calibrator *Calibrator, lines ...string) string {
+	t.Helper()
+	var response []byte
+	for _, line := range lines {
+		var err tinygoerrors.ErrorCode
+		response, err = calibrator.HandleCommand([]byte(line), response[:0])
+		if err != 0 {
+			t.Fatalf("command %q: %d, response %q", line, err, response)
+		}
+	}
+	return string(response)
+}
+
+// TestCalibratorSavesInSequence checks save walks from the min pulse width to the center and the max pulse width
+func TestCalibratorSavesInSequence(t *testing.T) {
+	calibrator := newTestCalibrator(t)
+	response := runCalibratorCommands(t, calibrator, "-----", "save", "+", "save", "++++++", "save")
+	if response != "DONE 1000000 1600000 2200000\r\n" {
+		t.Errorf("response = %q", response)
+	}
+	config, err := calibrator.Config()
+	if err != 0 {
+		t.Fatalf("Config: %d", err)
+	}
+	if config.MinPulseWidth != 1000000 || config.NeutralPulseWidth != 1600000 || config.MaxPulseWidth != 2200000 {
+		t.Errorf(
+			"pulse widths = %d %d %d, want 1000000 1600000 2200000",
+			config.MinPulseWidth,
+			config.NeutralPulseWidth,
+			config.MaxPulseWidth,
+		)
+	}
+}
+
+// TestCalibratorMarksEnds checks [ and ] mark the ends in any order and w finishes the calibration without a center
+func TestCalibratorMarksEnds(t *testing.T) {
+	calibrator := newTestCalibrator(t)
+	response := runCalibratorCommands(t, calibrator, "++++++++", "]")
+	if response != "MIN 2300\r\n" || calibrator.Phase() != CalibratorPhaseMin {
+		t.Fatalf("after marking the max pulse width: response %q, phase %d", response, calibrator.Phase())
+	}
+	if _, err := calibrator.Config(); err != ErrorCodeServoCalibrationIncomplete {
+		t.Fatalf("Config before finishing = %d, want %d", err, ErrorCodeServoCalibrationIncomplete)
+	}
+
+	// Finishing needs both ends
+	errorResponse, err := calibrator.HandleCommand([]byte("w"), nil)
+	if err != ErrorCodeServoCalibrationIncomplete || string(errorResponse) != "ERR\r\nMIN 2300\r\n" {
+		t.Fatalf("w without the min pulse width = %d, response %q", err, errorResponse)
+	}
+
+	response = runCalibratorCommands(t, calibrator, "-------------", "[")
+	if response != "CENTER 1000\r\n" {
+		t.Fatalf("after marking the min pulse width: response %q", response)
+	}
+	response = runCalibratorCommands(t, calibrator, "w")
+	if response != "DONE 1000000 0 2300000\r\n" {
+		t.Errorf("response = %q", response)
+	}
+	config, err := calibrator.Config()
+	if err != 0 {
+		t.Fatalf("Config: %d", err)
+	}
+	if config.MinPulseWidth != 1000000 || config.NeutralPulseWidth != 0 || config.MaxPulseWidth != 2300000 {
+		t.Errorf(
+			"pulse widths = %d %d %d, want 1000000 0 2300000",
+			config.MinPulseWidth,
+			config.NeutralPulseWidth,
+			config.MaxPulseWidth,
+		)
+	}
+}
+
+// TestCalibratorRejectsUnorderedMarks checks the marks are checked once the calibration is finished, and it goes on
+// until they are corrected
+func TestCalibratorRejectsUnorderedMarks(t *testing.T) {
+	calibrator := newTestCalibrator(t)
+	runCalibratorCommands(t, calibrator, "[", "---", "]")
+	if _, err := calibrator.HandleCommand([]byte("w"), nil); err != ErrorCodeServoInvalidCalibration {
+		t.Fatalf("w with the max pulse width below the min one = %d, want %d", err, ErrorCodeServoInvalidCalibration)
+	}
+	if calibrator.Phase() != CalibratorPhaseCenter {
+		t.Fatalf("phase = %d, want %d", calibrator.Phase(), CalibratorPhaseCenter)
+	}
+
+	// Marking the max pulse width again fixes it
+	response := runCalibratorCommands(t, calibrator, "+++++++", "]", "w")
+	if response != "DONE 1500000 0 1900000\r\n" {
+		t.Errorf("response = %q", response)
+	}
+}
@@ -0,0 +1,11 @@
+package camera
+
+type (
+	// timelapseState is an enum to represent the stage of a Timelapse sequence.
+	timelapseState uint8
+)
+
+const (
+	timelapseStateIdle timelapseState = iota
+	timelapseStateSettling
+)
@@ -0,0 +1,19 @@
+package camera
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeCameraStartNumber is the starting number for camera rig related error codes.
+	ErrorCodeCameraStartNumber uint16 = 5460
+)
+
+const (
+	ErrorCodeCameraNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeCameraStartNumber)
+	ErrorCodeCameraZeroStepInterval
+	ErrorCodeCameraZeroDuration
+	ErrorCodeCameraNilShootFunc
+	ErrorCodeCameraZeroShots
+	ErrorCodeCameraStepOutOfRange
+)
@@ -0,0 +1,14 @@
+package camera
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the camera rigs, letting the rigs drive
+	// any handler or the fakes of the tests
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		GetAngleMilliDegrees() uint32
+	}
+)
@@ -0,0 +1,161 @@
+package camera
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Panorama sweeps a servo extremely slowly for panoramic timelapse heads, e.g. 90 degrees over 30 minutes. The
+	// position is commanded in millidegrees at a fixed step cadence and derived from the elapsed time instead of
+	// accumulated increments, so the fractional part of each step carries over to the next ones and the sweep neither
+	// stutters nor drifts
+	Panorama struct {
+		servo               Servo
+		stepIntervalMs      uint32
+		driftCorrectionFunc func(nowMs uint32, targetMilliDegrees uint32) int32
+		startMilliDegrees   uint32
+		endMilliDegrees     uint32
+		durationMs          uint32
+		isRunning           bool
+		hasStarted          bool
+		startMs             uint32
+		nextStepMs          uint32
+	}
+)
+
+// NewPanorama creates a new instance of Panorama
+//
+// Parameters:
+//
+// servo: The servo that pans the camera
+// stepIntervalMs: The time between two steps in milliseconds
+//
+// Returns:
+//
+// An instance of Panorama and an error if the servo is nil or the step interval is zero
+func NewPanorama(servo Servo, stepIntervalMs uint32) (*Panorama, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeCameraNilServo
+	}
+	if stepIntervalMs == 0 {
+		return nil, ErrorCodeCameraZeroStepInterval
+	}
+	return &Panorama{
+		servo:          servo,
+		stepIntervalMs: stepIntervalMs,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetDriftCorrectionHook sets the function that corrects the commanded position, e.g. from a compass or the sky
+// position of a tracked object
+//
+// Parameters:
+//
+// driftCorrectionFunc: The function that receives the current time and the target position in millidegrees, and
+// returns the correction in millidegrees added to the target. Nil disables the correction
+func (p *Panorama) SetDriftCorrectionHook(driftCorrectionFunc func(nowMs uint32, targetMilliDegrees uint32) int32) {
+	p.driftCorrectionFunc = driftCorrectionFunc
+}
+
+// Start starts a sweep from the current position of the servo on the next update
+//
+// Parameters:
+//
+// endMilliDegrees: The position in millidegrees the sweep ends at
+// durationMs: The duration of the sweep in milliseconds
+//
+// Returns:
+//
+// An error if the duration is zero
+func (p *Panorama) Start(endMilliDegrees uint32, durationMs uint32) tinygoerrors.ErrorCode {
+	if durationMs == 0 {
+		return ErrorCodeCameraZeroDuration
+	}
+	p.startMilliDegrees = p.servo.GetAngleMilliDegrees()
+	p.endMilliDegrees = endMilliDegrees
+	p.durationMs = durationMs
+	p.hasStarted = false
+	p.isRunning = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Stop stops the sweep, the servo keeps its current position
+func (p *Panorama) Stop() {
+	p.isRunning = false
+}
+
+// IsRunning returns whether a sweep is running
+//
+// Returns:
+//
+// True if a sweep is running, false otherwise
+func (p *Panorama) IsRunning() bool {
+	return p.isRunning
+}
+
+// targetMilliDegrees returns the position of the sweep after some time
+//
+// Parameters:
+//
+// elapsedMs: The time since the sweep started, at most its duration
+//
+// Returns:
+//
+// The position in millidegrees, rounded to the nearest one
+func (p *Panorama) targetMilliDegrees(elapsedMs uint32) uint32 {
+	if p.endMilliDegrees >= p.startMilliDegrees {
+		span := uint64(p.endMilliDegrees - p.startMilliDegrees)
+		return p.startMilliDegrees + uint32((span*uint64(elapsedMs)+uint64(p.durationMs)/2)/uint64(p.durationMs))
+	}
+	span := uint64(p.startMilliDegrees - p.endMilliDegrees)
+	return p.startMilliDegrees - uint32((span*uint64(elapsedMs)+uint64(p.durationMs)/2)/uint64(p.durationMs))
+}
+
+// Update commands the next step when it is due, it must be called periodically from the main loop. Steps are
+// scheduled from the start of the sweep, so a late update doesn't delay the following ones
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (p *Panorama) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if !p.isRunning {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Start the sweep on the first update
+	if !p.hasStarted {
+		p.hasStarted = true
+		p.startMs = nowMs
+		p.nextStepMs = nowMs
+	}
+
+	// Check if the next step is due
+	if int32(nowMs-p.nextStepMs) < 0 {
+		return tinygoerrors.ErrorCodeNil
+	}
+	for int32(nowMs-p.nextStepMs) >= 0 {
+		p.nextStepMs += p.stepIntervalMs
+	}
+
+	// Calculate the target position, finishing the sweep once its duration has elapsed
+	elapsedMs := nowMs - p.startMs
+	if elapsedMs >= p.durationMs {
+		elapsedMs = p.durationMs
+		p.isRunning = false
+	}
+	target := p.targetMilliDegrees(elapsedMs)
+
+	// Apply the drift correction without underflowing
+	if p.driftCorrectionFunc != nil {
+		corrected := int64(target) + int64(p.driftCorrectionFunc(nowMs, target))
+		if corrected < 0 {
+			corrected = 0
+		}
+		target = uint32(corrected)
+	}
+	return p.servo.SetAngleMilliDegrees(target)
+}
@@ -0,0 +1,139 @@
+package camera
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Timelapse coordinates motorized slider and panning rigs, alternating small servo moves, settle delays and shots
+	Timelapse struct {
+		servo            Servo
+		shootFunc        func(shot uint16)
+		state            timelapseState
+		stepMilliDegrees int32
+		settleMs         uint32
+		shots            uint16
+		takenShots       uint16
+		hasSettleStart   bool
+		settleStartMs    uint32
+	}
+)
+
+// NewTimelapse creates a new instance of Timelapse
+//
+// Parameters:
+//
+// servo: The servo that moves the rig
+// shootFunc: The function that triggers the camera, it receives the index of the shot starting at zero
+//
+// Returns:
+//
+// An instance of Timelapse and an error if the servo or the shoot function is nil
+func NewTimelapse(servo Servo, shootFunc func(shot uint16)) (*Timelapse, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeCameraNilServo
+	}
+	if shootFunc == nil {
+		return nil, ErrorCodeCameraNilShootFunc
+	}
+	return &Timelapse{
+		servo:     servo,
+		shootFunc: shootFunc,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// StepShootMove starts a sequence of shots. The first shot is taken at the current position once the rig has settled,
+// and every following one after moving a step and settling again
+//
+// Parameters:
+//
+// stepDeg: The degrees to move between two shots, negative values move towards 0
+// settleMs: The time in milliseconds to wait after every move before shooting, so vibrations don't blur the shot
+// shots: The number of shots
+//
+// Returns:
+//
+// An error if the number of shots is zero or the last step would exceed the servo range
+func (t *Timelapse) StepShootMove(stepDeg int16, settleMs uint32, shots uint16) tinygoerrors.ErrorCode {
+	if shots == 0 {
+		return ErrorCodeCameraZeroShots
+	}
+
+	// Check the last step doesn't go below zero, the servo checks its own limits on every move
+	stepMilliDegrees := int32(stepDeg) * 1000
+	if int64(t.servo.GetAngleMilliDegrees())+int64(stepMilliDegrees)*int64(shots-1) < 0 {
+		return ErrorCodeCameraStepOutOfRange
+	}
+
+	t.stepMilliDegrees = stepMilliDegrees
+	t.settleMs = settleMs
+	t.shots = shots
+	t.takenShots = 0
+	t.hasSettleStart = false
+	t.state = timelapseStateSettling
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Stop stops the sequence, the rig keeps its current position
+func (t *Timelapse) Stop() {
+	t.state = timelapseStateIdle
+}
+
+// IsRunning returns whether a sequence is running
+//
+// Returns:
+//
+// True if a sequence is running, false otherwise
+func (t *Timelapse) IsRunning() bool {
+	return t.state != timelapseStateIdle
+}
+
+// TakenShots returns the number of shots taken in the current or last sequence
+//
+// Returns:
+//
+// The number of shots taken
+func (t *Timelapse) TakenShots() uint16 {
+	return t.takenShots
+}
+
+// Update runs the sequence, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any. The sequence is stopped if a move fails
+func (t *Timelapse) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if t.state != timelapseStateSettling {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Wait for the rig to settle
+	if !t.hasSettleStart {
+		t.hasSettleStart = true
+		t.settleStartMs = nowMs
+	}
+	if nowMs-t.settleStartMs < t.settleMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Take the shot
+	t.shootFunc(t.takenShots)
+	t.takenShots++
+	if t.takenShots == t.shots {
+		t.state = timelapseStateIdle
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Move to the next position and settle again
+	angle := uint32(int64(t.servo.GetAngleMilliDegrees()) + int64(t.stepMilliDegrees))
+	if err := t.servo.SetAngleMilliDegrees(angle); err != tinygoerrors.ErrorCodeNil {
+		t.state = timelapseStateIdle
+		return err
+	}
+	t.hasSettleStart = false
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,31 @@
+package can
+
+import (
+	"github.com/ralvarezdev/tinygo-servo/v2/group"
+)
+
+const (
+	// FrameDataSize is the payload size of a classic CAN frame
+	FrameDataSize = 8
+
+	// MaxStandardID is the highest 11-bit standard CAN identifier
+	MaxStandardID uint32 = 0x7FF
+
+	// DefaultBaseID is the CAN identifier of the pose frames, the sync frames use the next one
+	DefaultBaseID uint32 = 0x300
+
+	// MaxPoseEntries is the number of servo angles a node can stage until the next sync frame
+	MaxPoseEntries = group.MaxServos
+
+	// poseEntrySize is the size of a servo angle in a pose frame: the servo ID and a 24-bit angle in millidegrees
+	poseEntrySize = 4
+
+	// poseEntriesPerFrame is the number of servo angles carried by a pose frame
+	poseEntriesPerFrame = FrameDataSize / poseEntrySize
+
+	// syncFrameSize is the size of a sync frame: the 32-bit frame number
+	syncFrameSize = 4
+
+	// maxAngleMilliDegrees is the highest angle a pose entry can carry
+	maxAngleMilliDegrees uint32 = 1<<24 - 1
+)
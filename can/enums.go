@@ -0,0 +1,14 @@
+package can
+
+type (
+	// FrameType is an enum to represent the frames exchanged by the nodes, as an offset from their base CAN identifier.
+	FrameType uint8
+)
+
+const (
+	// FrameTypePose carries up to two servo angles staged until the next sync frame
+	FrameTypePose FrameType = iota
+
+	// FrameTypeSync applies the staged angles on every node at the same time
+	FrameTypeSync
+)
@@ -0,0 +1,20 @@
+package can
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeCANStartNumber is the starting number for CAN-related error codes.
+	ErrorCodeCANStartNumber uint16 = 5540
+)
+
+const (
+	ErrorCodeCANNilBus tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeCANStartNumber)
+	ErrorCodeCANNilGroup
+	ErrorCodeCANInvalidBaseID
+	ErrorCodeCANInvalidFrameLength
+	ErrorCodeCANAngleOutOfRange
+	ErrorCodeCANPoseTooLarge
+	ErrorCodeCANTransmitFailed
+)
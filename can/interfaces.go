@@ -0,0 +1,9 @@
+package can
+
+type (
+	// Bus is the CAN controller the frames are transmitted with. It is satisfied by the MCP2515 driver of the TinyGo
+	// drivers module, and BusFunc adapts any other controller
+	Bus interface {
+		Tx(id uint32, dlc uint8, data []byte) error
+	}
+)
@@ -0,0 +1,274 @@
+package can
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/group"
+)
+
+type (
+	// BusFunc adapts a function to the Bus interface
+	BusFunc func(id uint32, dlc uint8, data []byte) error
+
+	// PoseEntry is the angle of a servo within a pose
+	PoseEntry struct {
+		ID                uint8
+		AngleMilliDegrees uint32
+	}
+
+	// Node keeps the servo group of a controller board frame-synchronized with the other boards of an installation.
+	// The leader sends the angles of every pose as pose frames, which the nodes stage without moving, and then a sync
+	// frame, which makes every node apply its staged angles at the same time. The servo IDs are shared by the whole
+	// installation, every node only stages the angles of the servos of its group
+	//
+	// The pose frames carry up to two entries of the servo ID followed by its 24-bit angle in millidegrees, and the
+	// sync frames the 32-bit frame number, all values in little-endian order
+	Node struct {
+		bus          Bus
+		group        *group.Group
+		baseID       uint32
+		staged       [MaxPoseEntries]PoseEntry
+		stagedCount  int
+		frameNumber  uint32
+		hasSynced    bool
+		missedFrames uint32
+		syncFunc     func(frameNumber uint32)
+		buffer       [FrameDataSize]byte
+	}
+)
+
+// Tx calls the function
+//
+// Parameters:
+//
+// id: The CAN identifier of the frame
+// dlc: The length of the frame data
+// data: The frame data
+//
+// Returns:
+//
+// An error if the frame couldn't be transmitted
+func (f BusFunc) Tx(id uint32, dlc uint8, data []byte) error {
+	return f(id, dlc, data)
+}
+
+// NewNode creates a new instance of Node
+//
+// Parameters:
+//
+// bus: The CAN controller
+// group: The servos driven by this node
+// baseID: The CAN identifier of the pose frames, the sync frames use the next one. Both must be standard identifiers
+//
+// Returns:
+//
+// An instance of Node and an error if any parameter is invalid
+func NewNode(bus Bus, group *group.Group, baseID uint32) (*Node, tinygoerrors.ErrorCode) {
+	if bus == nil {
+		return nil, ErrorCodeCANNilBus
+	}
+	if group == nil {
+		return nil, ErrorCodeCANNilGroup
+	}
+	if baseID+uint32(FrameTypeSync) > MaxStandardID {
+		return nil, ErrorCodeCANInvalidBaseID
+	}
+	return &Node{
+		bus:    bus,
+		group:  group,
+		baseID: baseID,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetSyncFunc sets a function called after every sync frame applied, e.g. to fire a SyncStart of the node
+//
+// Parameters:
+//
+// syncFunc: The function called with the frame number, or nil
+func (n *Node) SetSyncFunc(syncFunc func(frameNumber uint32)) {
+	n.syncFunc = syncFunc
+}
+
+// SendPose stages the angles of a pose on every node, including this one, until the next call to SendSync
+//
+// Parameters:
+//
+// pose: The angles of the servos
+//
+// Returns:
+//
+// An error if any angle can't be carried by a pose frame, the pose doesn't fit the staging buffer or a frame couldn't
+// be transmitted
+func (n *Node) SendPose(pose []PoseEntry) tinygoerrors.ErrorCode {
+	if len(pose) > MaxPoseEntries {
+		return ErrorCodeCANPoseTooLarge
+	}
+	for _, entry := range pose {
+		if entry.AngleMilliDegrees > maxAngleMilliDegrees {
+			return ErrorCodeCANAngleOutOfRange
+		}
+	}
+
+	// Send the entries in frames of up to two of them
+	for start := 0; start < len(pose); start += poseEntriesPerFrame {
+		end := min(start+poseEntriesPerFrame, len(pose))
+		size := 0
+		for _, entry := range pose[start:end] {
+			n.buffer[size] = entry.ID
+			encodeUint24(n.buffer[size+1:], entry.AngleMilliDegrees)
+			size += poseEntrySize
+		}
+		if err := n.transmit(FrameTypePose, size); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+	}
+
+	// Stage the entries of the servos driven by this node
+	for _, entry := range pose {
+		n.stage(entry)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SendSync makes every node, including this one, apply its staged angles
+//
+// Returns:
+//
+// An error if the sync frame couldn't be transmitted, the local angles are not applied then
+func (n *Node) SendSync() tinygoerrors.ErrorCode {
+	frameNumber := n.frameNumber + 1
+	if !n.hasSynced {
+		frameNumber = 0
+	}
+	encodeUint32(n.buffer[:], frameNumber)
+	if err := n.transmit(FrameTypeSync, syncFrameSize); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	n.sync(frameNumber)
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Handle processes a frame received by the CAN controller. Frames with other CAN identifiers are ignored, so every
+// received frame can be passed
+//
+// Parameters:
+//
+// id: The CAN identifier of the frame
+// data: The frame data
+//
+// Returns:
+//
+// An error if the frame is a pose or sync frame with an invalid length
+func (n *Node) Handle(id uint32, data []byte) tinygoerrors.ErrorCode {
+	switch id {
+	case n.baseID + uint32(FrameTypePose):
+		if len(data) == 0 || len(data)%poseEntrySize != 0 || len(data) > FrameDataSize {
+			return ErrorCodeCANInvalidFrameLength
+		}
+		for offset := 0; offset < len(data); offset += poseEntrySize {
+			n.stage(
+				PoseEntry{
+					ID:                data[offset],
+					AngleMilliDegrees: decodeUint24(data[offset+1:]),
+				},
+			)
+		}
+	case n.baseID + uint32(FrameTypeSync):
+		if len(data) != syncFrameSize {
+			return ErrorCodeCANInvalidFrameLength
+		}
+		frameNumber := decodeUint32(data)
+
+		// Count the sync frames lost since the previous one, a leader restarting from zero is not a gap
+		if gap := int32(frameNumber - n.frameNumber); n.hasSynced && gap > 1 {
+			n.missedFrames += uint32(gap - 1)
+		}
+		n.sync(frameNumber)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// FrameNumber returns the number of the last sync frame applied
+//
+// Returns:
+//
+// The frame number, and false if no sync frame has been applied yet
+func (n *Node) FrameNumber() (uint32, bool) {
+	return n.frameNumber, n.hasSynced
+}
+
+// MissedFrames returns the number of sync frames lost on the bus, detected by gaps in the frame numbers
+//
+// Returns:
+//
+// The number of missed frames
+func (n *Node) MissedFrames() uint32 {
+	return n.missedFrames
+}
+
+// StagedCount returns the number of angles waiting for the next sync frame
+//
+// Returns:
+//
+// The number of staged angles
+func (n *Node) StagedCount() int {
+	return n.stagedCount
+}
+
+// stage keeps the angle of a servo driven by this node until the next sync frame, replacing its previous one
+//
+// Parameters:
+//
+// entry: The angle of the servo
+func (n *Node) stage(entry PoseEntry) {
+	if _, err := n.group.Servo(entry.ID); err != tinygoerrors.ErrorCodeNil {
+		return
+	}
+	for index := 0; index < n.stagedCount; index++ {
+		if n.staged[index].ID == entry.ID {
+			n.staged[index] = entry
+			return
+		}
+	}
+
+	// The buffer holds as many entries as servos a group can hold, so every servo of the group fits
+	n.staged[n.stagedCount] = entry
+	n.stagedCount++
+}
+
+// sync applies the staged angles
+//
+// Parameters:
+//
+// frameNumber: The number of the sync frame
+func (n *Node) sync(frameNumber uint32) {
+	for index := 0; index < n.stagedCount; index++ {
+		entry := n.staged[index]
+		if servo, err := n.group.Servo(entry.ID); err == tinygoerrors.ErrorCodeNil {
+			_ = servo.SetAngleMilliDegrees(entry.AngleMilliDegrees)
+		}
+	}
+	n.stagedCount = 0
+	n.frameNumber = frameNumber
+	n.hasSynced = true
+
+	if n.syncFunc != nil {
+		n.syncFunc(frameNumber)
+	}
+}
+
+// transmit sends the frame in the buffer
+//
+// Parameters:
+//
+// frameType: The type of the frame
+// size: The length of the frame data
+//
+// Returns:
+//
+// An error if the frame couldn't be transmitted
+func (n *Node) transmit(frameType FrameType, size int) tinygoerrors.ErrorCode {
+	if err := n.bus.Tx(n.baseID+uint32(frameType), uint8(size), n.buffer[:size]); err != nil {
+		return ErrorCodeCANTransmitFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,50 @@
+package can
+
+// encodeUint24 writes the lowest 24 bits of a value in little-endian order
+//
+// Parameters:
+//
+// buffer: The buffer to write to, at least 3 bytes long
+// value: The value to write
+func encodeUint24(buffer []byte, value uint32) {
+	buffer[0] = byte(value)
+	buffer[1] = byte(value >> 8)
+	buffer[2] = byte(value >> 16)
+}
+
+// decodeUint24 reads a 24-bit value in little-endian order
+//
+// Parameters:
+//
+// buffer: The buffer to read from, at least 3 bytes long
+//
+// Returns:
+//
+// The value read
+func decodeUint24(buffer []byte) uint32 {
+	return uint32(buffer[0]) | uint32(buffer[1])<<8 | uint32(buffer[2])<<16
+}
+
+// encodeUint32 writes a value in little-endian order
+//
+// Parameters:
+//
+// buffer: The buffer to write to, at least 4 bytes long
+// value: The value to write
+func encodeUint32(buffer []byte, value uint32) {
+	encodeUint24(buffer, value)
+	buffer[3] = byte(value >> 24)
+}
+
+// decodeUint32 reads a value in little-endian order
+//
+// Parameters:
+//
+// buffer: The buffer to read from, at least 4 bytes long
+//
+// Returns:
+//
+// The value read
+func decodeUint32(buffer []byte) uint32 {
+	return decodeUint24(buffer) | uint32(buffer[3])<<24
+}
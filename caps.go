@@ -0,0 +1,68 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// globalMaxSpeed is the maximum speed of every servo in degrees per second, zero means uncapped
+	globalMaxSpeed uint16
+
+	// globalMaxRangePercent is the percentage of its travel, on each side of the center, every servo can use
+	globalMaxRangePercent uint8 = 100
+)
+
+// SetGlobalCaps sets a safety override applied on top of the settings of every servo handler, so educational kits can
+// guarantee gentle motion regardless of what the application commands. The range cap shrinks the limits of every
+// handler towards its center, it doesn't move servos already outside the capped range until they are commanded again
+//
+// Parameters:
+//
+// maxSpeed: The maximum speed of every servo in degrees per second, or zero to leave the speed uncapped. It is
+// enforced by the speed-limited moves, and SetAngleImmediate is refused while it is set
+// maxRangePercent: The percentage of its travel on each side of the center every servo can use, between 1 and 100
+//
+// Returns:
+//
+// An error if the range percentage is not between 1 and 100
+func SetGlobalCaps(maxSpeed uint16, maxRangePercent uint8) tinygoerrors.ErrorCode {
+	// Check if the range percentage is valid
+	if maxRangePercent == 0 || maxRangePercent > 100 {
+		return ErrorCodeServoInvalidGlobalRangeCap
+	}
+	globalMaxSpeed = maxSpeed
+	globalMaxRangePercent = maxRangePercent
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ClearGlobalCaps removes the global safety override
+func ClearGlobalCaps() {
+	globalMaxSpeed = 0
+	globalMaxRangePercent = 100
+}
+
+// GetGlobalCaps returns the global safety override
+//
+// Returns:
+//
+// The maximum speed in degrees per second, zero if uncapped, and the percentage of the travel on each side of the
+// center every servo can use
+func GetGlobalCaps() (uint16, uint8) {
+	return globalMaxSpeed, globalMaxRangePercent
+}
+
+// capRangeFromCenter applies the global range cap to the travel on one side of the center
+//
+// Parameters:
+//
+// travel: The degrees between the center and a limit
+//
+// Returns:
+//
+// The capped degrees between the center and the limit
+func capRangeFromCenter(travel uint16) uint16 {
+	if globalMaxRangePercent >= 100 {
+		return travel
+	}
+	return uint16(uint32(travel) * uint32(globalMaxRangePercent) / 100)
+}
@@ -0,0 +1,157 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// GetCenterAngle returns the center angle of the servo motor
+//
+// Returns:
+//
+// The absolute angle the relative commands are measured from
+func (h *DefaultHandler) GetCenterAngle() uint16 {
+	return h.centerAngle
+}
+
+// setCenterAngle replaces the center angle of the servo motor and logs it
+//
+// Parameters:
+//
+// angle: The new center angle
+func (h *DefaultHandler) setCenterAngle(angle uint16) {
+	h.centerAngle = angle
+
+	// The neutral pulse width follows the center angle, so the mapping of the current angle changes
+	if h.neutralPulseWidth != 0 {
+		h.recalculatePulse()
+	}
+
+	// Log the new center angle if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.AddMessageWithUint16(setCenterAnglePrefix, angle, true, true, false)
+		h.logger.Debug()
+	}
+}
+
+// SetCenterAngle redefines the center angle of the servo motor at runtime, e.g. after installing a steering linkage
+// slightly off, keeping its current limits. The relative commands are measured from the new center, the servo motor
+// is not moved unless a neutral pulse width is set, as it follows the center angle
+//
+// Parameters:
+//
+// angle: The new absolute center angle, it must lie between the left and right limits
+//
+// Returns:
+//
+// An error if the angle is outside the limits
+func (h *DefaultHandler) SetCenterAngle(angle uint16) tinygoerrors.ErrorCode {
+	if angle < h.leftLimitAngle || angle > h.rightLimitAngle {
+		return ErrorCodeServoInvalidCenterAngle
+	}
+	h.setCenterAngle(angle)
+	h.trim = 0
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetCenterAngleWithLimits redefines the center angle of the servo motor at runtime and derives its limits again from
+// the new center, as the constructor does, updating the limits warning. If the servo motor is outside the new limits
+// it is moved to the closest one
+//
+// Parameters:
+//
+// angle: The new absolute center angle, between 0 and the actuation range
+// maxLeftAngle: The maximum angle the servo motor can move to the left from the new center
+// maxRightAngle: The maximum angle the servo motor can move to the right from the new center
+//
+// Returns:
+//
+// An error if the angle exceeds the actuation range or the servo motor could not be moved within the new limits
+func (h *DefaultHandler) SetCenterAngleWithLimits(
+	angle uint16,
+	maxLeftAngle uint16,
+	maxRightAngle uint16,
+) tinygoerrors.ErrorCode {
+	if angle > h.actuationRange {
+		return ErrorCodeServoInvalidCenterAngle
+	}
+
+	// The left side is towards the higher absolute angles when the direction is inverted
+	maxLowerAngle, maxUpperAngle := maxLeftAngle, maxRightAngle
+	if h.isDirectionInverted {
+		maxLowerAngle, maxUpperAngle = maxRightAngle, maxLeftAngle
+	}
+	leftLimitAngle, rightLimitAngle, areLimitsClamped := deriveLimitAngles(
+		angle,
+		maxLowerAngle,
+		maxUpperAngle,
+		h.actuationRange,
+	)
+
+	// Replace the center and the limits
+	h.setCenterAngle(angle)
+	h.trim = 0
+	if err := h.setLimits(leftLimitAngle, rightLimitAngle); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if areLimitsClamped {
+		h.limitsWarning = ErrorCodeServoLimitsClamped
+	}
+
+	// Move the servo within the new limits, without initializing a deferred handler
+	if !h.isInitialized {
+		if h.angle < leftLimitAngle {
+			h.angle = leftLimitAngle
+			h.angleFraction = 0
+		} else if h.angle >= rightLimitAngle {
+			h.angle = rightLimitAngle
+			h.angleFraction = 0
+		}
+		h.pulse = h.calculatePulseMilliDegrees(h.GetAngleMilliDegrees())
+		return tinygoerrors.ErrorCodeNil
+	}
+	return h.Jog(0)
+}
+
+// SetTrim shifts the center angle of the servo motor by an offset from its untrimmed center, e.g. to trim a steering
+// servo live while the vehicle runs. The servo motor follows the shift, so it keeps its angle relative to the center,
+// and the limits are kept. Setting a new center angle resets the trim
+//
+// Parameters:
+//
+// offsetDegrees: The offset from the untrimmed center in degrees, negative to the left and positive to the right
+//
+// Returns:
+//
+// An error if the trimmed center is outside the limits or the servo motor could not follow it
+func (h *DefaultHandler) SetTrim(offsetDegrees int16) tinygoerrors.ErrorCode {
+	// The right side is towards the lower absolute angles when the direction is inverted
+	offset := int32(offsetDegrees)
+	previousOffset := int32(h.trim)
+	if h.isDirectionInverted {
+		offset, previousOffset = -offset, -previousOffset
+	}
+	untrimmedCenter := int32(h.centerAngle) - previousOffset
+	center := untrimmedCenter + offset
+	if center < int32(h.leftLimitAngle) || center > int32(h.rightLimitAngle) {
+		return h.reportError(ErrorCodeServoInvalidTrim)
+	}
+
+	// Shift the center, moving the servo motor with it
+	delta := center - int32(h.centerAngle)
+	h.setCenterAngle(uint16(center))
+	h.trim = offsetDegrees
+	if delta == 0 || !h.isInitialized {
+		return tinygoerrors.ErrorCodeNil
+	}
+	return h.Jog(int16(delta))
+}
+
+// GetTrim returns the offset of the center angle set by SetTrim
+//
+// Returns:
+//
+// The offset from the untrimmed center in degrees, negative to the left and positive to the right
+func (h *DefaultHandler) GetTrim() int16 {
+	return h.trim
+}
@@ -0,0 +1,433 @@
+// Servoconfig validates the servo table of a Group before flashing, so configuration errors are caught at the desk
+// instead of in the field:
+//
+//	go run github.com/ralvarezdev/tinygo-servo/v2/cmd/servoconfig [-i2c 0x68,0x3c] [-allcall=false] group.csv
+//
+// The CSV file must start with the header
+//
+//	id,backend,device,channel,frequency,min_pulse_width_ns,max_pulse_width_ns,actuation_range,center_angle,max_left_angle,max_right_angle
+//
+// and every following row describes a servo of the group. The backend is pwm, pca9685, softpwm or piopwm. The device
+// is the name of the PWM peripheral for pwm, the I2C address of the board for pca9685, the name of the generator for
+// softpwm and the PIO block, 0 or 1, for piopwm. The channel is the pin for pwm, softpwm and piopwm, and the output of
+// the board for pca9685.
+//
+// Every row is checked like NewHandler does, and the table as a whole is checked for duplicated IDs,
+// overlapping channels, devices shared with different frequencies and I2C address conflicts. Every problem is
+// reported, and the exit status is 1 if any was found.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/group"
+	"github.com/ralvarezdev/tinygo-servo/v2/pca9685"
+	"github.com/ralvarezdev/tinygo-servo/v2/piopwm"
+	"github.com/ralvarezdev/tinygo-servo/v2/softpwm"
+)
+
+const (
+	// pca9685AllCallAddress is the address every board answers to unless the all call is disabled in MODE1
+	pca9685AllCallAddress = 0x70
+
+	// pca9685MinFrequency and pca9685MaxFrequency are the frequencies of the highest and lowest prescale values with
+	// the nominal 25MHz oscillator
+	pca9685MinFrequency = 24
+	pca9685MaxFrequency = 1526
+)
+
+const (
+	// Backends
+	backendPWM     = "pwm"
+	backendPCA9685 = "pca9685"
+	backendSoftPWM = "softpwm"
+	backendPIOPWM  = "piopwm"
+)
+
+type (
+	// servo is a row of the table
+	servo struct {
+		Line    int
+		ID      uint8
+		Backend string
+		Device  string
+		Address uint16
+		Channel uint8
+		Config  tinygoservo.Config
+	}
+
+	// output identifies a channel of a device
+	output struct {
+		Backend string
+		Device  string
+		Channel uint8
+	}
+
+	// device identifies a PWM peripheral, a board or a software generator
+	device struct {
+		Backend string
+		Device  string
+	}
+)
+
+var (
+	// header is the expected first row of the CSV file
+	header = []string{
+		"id",
+		"backend",
+		"device",
+		"channel",
+		"frequency",
+		"min_pulse_width_ns",
+		"max_pulse_width_ns",
+		"actuation_range",
+		"center_angle",
+		"max_left_angle",
+		"max_right_angle",
+	}
+)
+
+// parseUint parses an unsigned field, in decimal or with a 0x prefix in hexadecimal
+//
+// Parameters:
+//
+// field: The field to parse
+// bitSize: The size of the integer type the value must fit in
+//
+// Returns:
+//
+// The value and an error if the field is not a number or it doesn't fit
+func parseUint(field string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(strings.TrimSpace(field), 0, bitSize)
+}
+
+// parseServo parses and validates a CSV row
+//
+// Parameters:
+//
+// record: The fields of the row
+//
+// Returns:
+//
+// The servo and an error if any field is invalid
+func parseServo(record []string) (servo, error) {
+	var parsed servo
+	if len(record) != len(header) {
+		return parsed, fmt.Errorf("expected %d fields, got %d", len(header), len(record))
+	}
+
+	id, err := parseUint(record[0], 8)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid id %q", record[0])
+	}
+	parsed.ID = uint8(id)
+
+	// Check the backend and its device
+	parsed.Backend = strings.TrimSpace(record[1])
+	parsed.Device = strings.TrimSpace(record[2])
+	switch parsed.Backend {
+	case backendPWM, backendSoftPWM:
+		if parsed.Device == "" {
+			return parsed, fmt.Errorf("empty device")
+		}
+	case backendPCA9685:
+		parsedAddress, err := parseUint(parsed.Device, 16)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid I2C address %q", record[2])
+		}
+		address := uint16(parsedAddress)
+		if address < pca9685.DefaultAddress || address > pca9685.DefaultAddress+pca9685.MaxAddressOffset {
+			return parsed, fmt.Errorf(
+				"I2C address 0x%02x out of the PCA9685 range 0x%02x-0x%02x",
+				address,
+				pca9685.DefaultAddress,
+				pca9685.DefaultAddress+pca9685.MaxAddressOffset,
+			)
+		}
+		parsed.Address = address
+		parsed.Device = fmt.Sprintf("0x%02x", address)
+	case backendPIOPWM:
+		block, err := parseUint(parsed.Device, 8)
+		if err != nil || block >= piopwm.BlocksCount {
+			return parsed, fmt.Errorf("invalid PIO block %q, expected 0-%d", record[2], piopwm.BlocksCount-1)
+		}
+		parsed.Device = strconv.FormatUint(block, 10)
+	default:
+		return parsed, fmt.Errorf("unknown backend %q", record[1])
+	}
+
+	channel, err := parseUint(record[3], 8)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid channel %q", record[3])
+	}
+	if parsed.Backend == backendPCA9685 && channel >= pca9685.ChannelsCount {
+		return parsed, fmt.Errorf("channel %d out of the PCA9685 range 0-%d", channel, pca9685.ChannelsCount-1)
+	}
+	parsed.Channel = uint8(channel)
+
+	// Parse the configuration
+	var fields [7]uint64
+	for index, name := range header[4:] {
+		bitSize := 16
+		if strings.HasSuffix(name, "_ns") {
+			bitSize = 32
+		}
+		fields[index], err = parseUint(record[4+index], bitSize)
+		if err != nil {
+			label := strings.ReplaceAll(strings.TrimSuffix(name, "_ns"), "_", " ")
+			return parsed, fmt.Errorf("invalid %s %q", label, record[4+index])
+		}
+	}
+	parsed.Config = tinygoservo.Config{
+		Frequency:      uint16(fields[0]),
+		MinPulseWidth:  tinygoservo.Nanoseconds(fields[1]),
+		MaxPulseWidth:  tinygoservo.Nanoseconds(fields[2]),
+		ActuationRange: uint16(fields[3]),
+		CenterAngle:    uint16(fields[4]),
+		MaxLeftAngle:   uint16(fields[5]),
+		MaxRightAngle:  uint16(fields[6]),
+	}
+	config := parsed.Config
+	if parsed.Backend == backendPCA9685 &&
+		(config.Frequency < pca9685MinFrequency || config.Frequency > pca9685MaxFrequency) {
+		return parsed, fmt.Errorf(
+			"frequency %dHz out of the PCA9685 range %d-%dHz",
+			config.Frequency,
+			pca9685MinFrequency,
+			pca9685MaxFrequency,
+		)
+	}
+
+	// Check the configuration like the handlers do
+	switch config.Validate() {
+	case tinygoerrors.ErrorCodeNil:
+	case tinygoservo.ErrorCodeServoZeroFrequency:
+		return parsed, fmt.Errorf("invalid frequency %q", record[4])
+	case tinygoservo.ErrorCodeServoPulseWidthNotInNanoseconds:
+		return parsed, fmt.Errorf("max pulse width %q is shorter than 10us, it must be in nanoseconds", record[6])
+	case tinygoservo.ErrorCodeServoInvalidMinPulseWidth:
+		return parsed, fmt.Errorf(
+			"invalid min pulse width %q, it must be shorter than the %dns period",
+			record[5],
+			1000000000/uint32(config.Frequency),
+		)
+	case tinygoservo.ErrorCodeServoInvalidMaxPulseWidth:
+		return parsed, fmt.Errorf(
+			"invalid max pulse width %q, it must be longer than the min and shorter than the %dns period",
+			record[6],
+			1000000000/uint32(config.Frequency),
+		)
+	case tinygoservo.ErrorCodeServoInvalidActuationRange:
+		return parsed, fmt.Errorf(
+			"invalid actuation range %q, expected 1-%d",
+			record[7],
+			tinygoservo.MaxActuationRange,
+		)
+	case tinygoservo.ErrorCodeServoInvalidCenterAngle:
+		return parsed, fmt.Errorf("invalid center angle %q, it must be within the actuation range", record[8])
+	default:
+		return parsed, fmt.Errorf("invalid configuration")
+	}
+
+	// Check the limits, rejecting the ones NewHandler would clamp
+	if config.MaxLeftAngle > config.CenterAngle {
+		return parsed, fmt.Errorf(
+			"max left angle %d goes below 0 from the center angle %d",
+			config.MaxLeftAngle,
+			config.CenterAngle,
+		)
+	}
+	if config.CenterAngle+config.MaxRightAngle > config.ActuationRange {
+		return parsed, fmt.Errorf(
+			"max right angle %d goes beyond the actuation range %d from the center angle %d",
+			config.MaxRightAngle,
+			config.ActuationRange,
+			config.CenterAngle,
+		)
+	}
+	return parsed, nil
+}
+
+// readServos reads the servos of a CSV file
+//
+// Parameters:
+//
+// path: The path of the CSV file
+//
+// Returns:
+//
+// The servos, the problems of the rows that could not be parsed, and an error if the file is malformed
+func readServos(path string) ([]servo, []string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check the header
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(header, ",") {
+		return nil, nil, fmt.Errorf("%s: the header must be %s", path, strings.Join(header, ","))
+	}
+
+	// Parse the rows, collecting the problems of every one
+	servos := make([]servo, 0, len(records)-1)
+	var problems []string
+	for index, record := range records[1:] {
+		parsed, err := parseServo(record)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s:%d: %v", path, index+2, err))
+			continue
+		}
+		parsed.Line = index + 2
+		servos = append(servos, parsed)
+	}
+	return servos, problems, nil
+}
+
+// checkTable checks the servos as a whole
+//
+// Parameters:
+//
+// path: The path of the CSV file, used in the problems
+// servos: The servos of the table
+// busAddresses: The I2C addresses of the other devices on the bus of the boards
+// isAllCallEnabled: Whether the boards answer to the all call address
+//
+// Returns:
+//
+// The problems found
+func checkTable(path string, servos []servo, busAddresses map[uint16]bool, isAllCallEnabled bool) []string {
+	var problems []string
+	report := func(line int, format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("%s:%d: %s", path, line, fmt.Sprintf(format, args...)))
+	}
+
+	// Check the size of the group
+	if len(servos) > group.MaxServos {
+		problems = append(problems, fmt.Sprintf("%s: %d servos, a Group holds up to %d", path, len(servos),
+			group.MaxServos))
+	}
+
+	ids := make(map[uint8]int)
+	outputs := make(map[output]int)
+	frequencies := make(map[device]servo)
+	softPWMChannels := make(map[string]int)
+	pioPWMChannels := make(map[string]int)
+	for _, entry := range servos {
+		// Check the IDs and the channels are not used twice
+		if line, ok := ids[entry.ID]; ok {
+			report(entry.Line, "id %d already used on line %d", entry.ID, line)
+		} else {
+			ids[entry.ID] = entry.Line
+		}
+		key := output{Backend: entry.Backend, Device: entry.Device, Channel: entry.Channel}
+		if line, ok := outputs[key]; ok {
+			report(entry.Line, "%s %s channel %d already used on line %d", entry.Backend, entry.Device,
+				entry.Channel, line)
+			continue
+		}
+		outputs[key] = entry.Line
+
+		// Check the servos sharing a device share its period, it is set for the whole peripheral or board
+		shared := device{Backend: entry.Backend, Device: entry.Device}
+		if owner, ok := frequencies[shared]; !ok {
+			frequencies[shared] = entry
+		} else if owner.Config.Frequency != entry.Config.Frequency {
+			report(entry.Line, "%s %s runs at %dHz since line %d, not %dHz", entry.Backend, entry.Device,
+				owner.Config.Frequency, owner.Line, entry.Config.Frequency)
+		}
+
+		switch entry.Backend {
+		case backendSoftPWM:
+			softPWMChannels[entry.Device]++
+			if softPWMChannels[entry.Device] == softpwm.MaxChannels+1 {
+				report(entry.Line, "softpwm %s has more than %d channels", entry.Device, softpwm.MaxChannels)
+			}
+		case backendPIOPWM:
+			// Every channel takes one of the state machines of the block
+			pioPWMChannels[entry.Device]++
+			if pioPWMChannels[entry.Device] == piopwm.MaxChannels+1 {
+				report(entry.Line, "piopwm block %s has more than %d channels", entry.Device, piopwm.MaxChannels)
+			}
+		case backendPCA9685:
+			// Check the board address doesn't collide with the other devices on the bus
+			if busAddresses[entry.Address] {
+				report(entry.Line, "I2C address %s is used by another device on the bus", entry.Device)
+			}
+			if isAllCallEnabled && entry.Address == pca9685AllCallAddress {
+				report(entry.Line, "I2C address %s is the all call address of every PCA9685", entry.Device)
+			}
+		}
+	}
+	return problems
+}
+
+// parseAddresses parses a comma-separated list of I2C addresses
+//
+// Parameters:
+//
+// list: The list to parse
+//
+// Returns:
+//
+// The set of addresses and an error if any is invalid
+func parseAddresses(list string) (map[uint16]bool, error) {
+	addresses := make(map[uint16]bool)
+	if strings.TrimSpace(list) == "" {
+		return addresses, nil
+	}
+	for _, field := range strings.Split(list, ",") {
+		address, err := parseUint(field, 7)
+		if err != nil {
+			return nil, fmt.Errorf("invalid I2C address %q", field)
+		}
+		addresses[uint16(address)] = true
+	}
+	return addresses, nil
+}
+
+func main() {
+	busList := flag.String("i2c", "", "comma-separated I2C addresses of the other devices on the bus of the boards")
+	isAllCallEnabled := flag.Bool("allcall", true, "whether the PCA9685 boards answer to the all call address")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: servoconfig [-i2c 0x68,0x3c] [-allcall=false] group.csv")
+		os.Exit(2)
+	}
+	busAddresses, err := parseAddresses(*busList)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Read and check the table
+	servos, problems, err := readServos(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	problems = append(problems, checkTable(flag.Arg(0), servos, busAddresses, *isAllCallEnabled)...)
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(problems) != 0 {
+		os.Exit(1)
+	}
+	fmt.Printf("%s: %d servos OK\n", flag.Arg(0), len(servos))
+}
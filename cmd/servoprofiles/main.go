@@ -0,0 +1,204 @@
+// Servoprofiles generates Go profile tables from a CSV file of servo models, so fleets with custom servo models can
+// manage their parameters as data. It is meant to be run with go:generate:
+//
+//	//go:generate go run github.com/ralvarezdev/tinygo-servo/v2/cmd/servoprofiles -o profiles_gen.go -package main servos.csv
+//
+// The CSV file must start with the header
+//
+//	name,frequency,min_pulse_width_ns,max_pulse_width_ns,actuation_range
+//
+// and every following row describes a servo model. The generated file declares an array of tinygoservo.Profile values.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+)
+
+var (
+	// header is the expected first row of the CSV file
+	header = []string{"name", "frequency", "min_pulse_width_ns", "max_pulse_width_ns", "actuation_range"}
+)
+
+// parseProfile parses a CSV row and validates it like tinygoservo.Config.Validate
+//
+// Parameters:
+//
+// record: The fields of the row
+//
+// Returns:
+//
+// The profile and an error if any field is invalid
+func parseProfile(record []string) (tinygoservo.Profile, error) {
+	var parsed tinygoservo.Profile
+	if len(record) != len(header) {
+		return parsed, fmt.Errorf("expected %d fields, got %d", len(header), len(record))
+	}
+	parsed.Name = strings.TrimSpace(record[0])
+	if parsed.Name == "" {
+		return parsed, fmt.Errorf("empty name")
+	}
+
+	frequency, err := strconv.ParseUint(strings.TrimSpace(record[1]), 10, 16)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid frequency %q", record[1])
+	}
+	minPulseWidth, err := strconv.ParseUint(strings.TrimSpace(record[2]), 10, 32)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid min pulse width %q", record[2])
+	}
+	maxPulseWidth, err := strconv.ParseUint(strings.TrimSpace(record[3]), 10, 32)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid max pulse width %q", record[3])
+	}
+	actuationRange, err := strconv.ParseUint(strings.TrimSpace(record[4]), 10, 16)
+	if err != nil {
+		return parsed, fmt.Errorf("invalid actuation range %q", record[4])
+	}
+	parsed.Frequency = uint16(frequency)
+	parsed.MinPulseWidth = tinygoservo.Nanoseconds(minPulseWidth)
+	parsed.MaxPulseWidth = tinygoservo.Nanoseconds(maxPulseWidth)
+	parsed.ActuationRange = uint16(actuationRange)
+
+	// Check the profile as the handlers created from it would
+	config := tinygoservo.Config{
+		Frequency:      parsed.Frequency,
+		MinPulseWidth:  parsed.MinPulseWidth,
+		MaxPulseWidth:  parsed.MaxPulseWidth,
+		ActuationRange: parsed.ActuationRange,
+	}
+	switch config.Validate() {
+	case tinygoservo.ErrorCodeServoZeroFrequency:
+		return parsed, fmt.Errorf("invalid frequency %q", record[1])
+	case tinygoservo.ErrorCodeServoPulseWidthNotInNanoseconds:
+		return parsed, fmt.Errorf("max pulse width %q is shorter than 10us, it must be in nanoseconds", record[3])
+	case tinygoservo.ErrorCodeServoInvalidMinPulseWidth:
+		return parsed, fmt.Errorf("invalid min pulse width %q, it must be shorter than the period", record[2])
+	case tinygoservo.ErrorCodeServoInvalidMaxPulseWidth:
+		return parsed, fmt.Errorf(
+			"invalid max pulse width %q, it must be longer than the min and shorter than the period",
+			record[3],
+		)
+	case tinygoservo.ErrorCodeServoInvalidActuationRange:
+		return parsed, fmt.Errorf(
+			"invalid actuation range %q, expected 1-%d",
+			record[4],
+			tinygoservo.MaxActuationRange,
+		)
+	}
+	return parsed, nil
+}
+
+// readProfiles reads the profiles of a CSV file
+//
+// Parameters:
+//
+// path: The path of the CSV file
+//
+// Returns:
+//
+// The profiles and an error if the file is malformed
+func readProfiles(path string) ([]tinygoservo.Profile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the header
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(header, ",") {
+		return nil, fmt.Errorf("%s: the header must be %s", path, strings.Join(header, ","))
+	}
+
+	// Parse the rows, rejecting duplicated names
+	profiles := make([]tinygoservo.Profile, 0, len(records)-1)
+	names := make(map[string]bool)
+	for index, record := range records[1:] {
+		parsed, err := parseProfile(record)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, index+2, err)
+		}
+		if names[parsed.Name] {
+			return nil, fmt.Errorf("%s:%d: duplicated name %q", path, index+2, parsed.Name)
+		}
+		names[parsed.Name] = true
+		profiles = append(profiles, parsed)
+	}
+	return profiles, nil
+}
+
+// writeGoSource formats the profiles as Go source
+//
+// Parameters:
+//
+// packageName: The package of the generated file
+// variableName: The name of the profile table variable
+// profiles: The profiles
+//
+// Returns:
+//
+// The Go source
+func writeGoSource(packageName string, variableName string, profiles []tinygoservo.Profile) string {
+	var builder strings.Builder
+	builder.WriteString("// Code generated by servoprofiles. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&builder, "package %s\n\n", packageName)
+	builder.WriteString("import (\n\ttinygoservo \"github.com/ralvarezdev/tinygo-servo/v2\"\n)\n\n")
+	fmt.Fprintf(&builder, "var %s = [...]tinygoservo.Profile{\n", variableName)
+	for _, entry := range profiles {
+		fmt.Fprintf(
+			&builder,
+			"\t{Name: %q, Frequency: %d, MinPulseWidth: %d, MaxPulseWidth: %d, ActuationRange: %d},\n",
+			entry.Name,
+			entry.Frequency,
+			entry.MinPulseWidth,
+			entry.MaxPulseWidth,
+			entry.ActuationRange,
+		)
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+func main() {
+	output := flag.String("o", "", "output file, defaults to the standard output")
+	variableName := flag.String("var", "Profiles", "name of the profile table variable")
+	packageName := flag.String("package", "main", "package of the generated Go source")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: servoprofiles [-o output] [-var Profiles] [-package main] servos.csv")
+		os.Exit(2)
+	}
+
+	// Read the profiles
+	profiles, err := readProfiles(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	data := []byte(writeGoSource(*packageName, *variableName, profiles))
+
+	// Write the profile table
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*output, data, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,94 @@
+// Servoscript compiles servo scripts into the bytecode run by the script interpreter on the device.
+//
+// Usage:
+//
+//	servoscript [-o program.bin] [-go name -package main] script.txt
+//
+// With -go, the bytecode is written as Go source declaring a byte slice variable, so it can be embedded in firmware.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/script"
+)
+
+const (
+	// maxProgramSize is the largest bytecode the tool produces
+	maxProgramSize = 64 * 1024
+)
+
+// writeGoSource formats the bytecode as Go source
+//
+// Parameters:
+//
+// packageName: The package of the generated file
+// variableName: The name of the byte slice variable
+// program: The bytecode
+//
+// Returns:
+//
+// The Go source
+func writeGoSource(packageName string, variableName string, program []byte) string {
+	var builder strings.Builder
+	builder.WriteString("// Code generated by servoscript. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&builder, "package %s\n\n", packageName)
+	fmt.Fprintf(&builder, "var %s = []byte{", variableName)
+	for index, value := range program {
+		if index%12 == 0 {
+			builder.WriteString("\n\t")
+		} else {
+			builder.WriteString(" ")
+		}
+		fmt.Fprintf(&builder, "0x%02x,", value)
+	}
+	builder.WriteString("\n}\n")
+	return builder.String()
+}
+
+func main() {
+	output := flag.String("o", "", "output file, defaults to the standard output")
+	variableName := flag.String("go", "", "write Go source declaring a byte slice variable with this name")
+	packageName := flag.String("package", "main", "package of the generated Go source")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: servoscript [-o output] [-go name -package main] script.txt")
+		os.Exit(2)
+	}
+
+	// Read and compile the script
+	source, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	program := make([]byte, maxProgramSize)
+	size, line, errCode := script.Compile(source, program)
+	if errCode != tinygoerrors.ErrorCodeNil {
+		fmt.Fprintf(os.Stderr, "%s:%d: compilation failed with error code %d\n", flag.Arg(0), line, errCode)
+		os.Exit(1)
+	}
+	program = program[:size]
+
+	// Format the bytecode
+	data := program
+	if *variableName != "" {
+		data = []byte(writeGoSource(*packageName, *variableName, program))
+	}
+
+	// Write the bytecode
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*output, data, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,165 @@
+// Package compat keeps the positional constructors of the v1 module on top of the options of the v2 one, so a project
+// can move to the v2 import path first and then replace the calls one at a time. Every function is deprecated and is
+// removed in the next major version.
+package compat
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+)
+
+// NewDefaultHandler creates a new instance of DefaultHandler with the parameters of the v1 constructor
+//
+// Deprecated: Use tinygoservo.NewHandler with the options instead, e.g. WithFrequency, WithPulseRange,
+// WithActuationRange, WithCenterAngle, WithLimits and WithInvertedDirection.
+//
+// Parameters:
+//
+// pwm: The PWM interface to control the servo
+// pin: The pin connected to the servo
+// afterSetAngleFunc: A callback function to be called after setting the angle
+// isMovementEnabled: An optional function to check if movement is enabled, checked in addition to EnableMovement and
+// DisableMovement
+// frequency: The frequency of the PWM signal
+// minPulseWidth: The minimum pulse width for the servo motor in nanoseconds
+// maxPulseWidth: The maximum pulse width for the servo motor in nanoseconds
+// actuationRange: The actuation range of the servo motor in degrees, up to MaxActuationRange
+// centerAngle: The center angle of the servo motor, between 0 and the actuation range
+// maxLeftAngle: The maximum left angle from the center, clamped so the left limit is not lower than 0
+// maxRightAngle: The maximum right angle from the center, clamped so the right limit is not higher than the actuation
+// range
+// isDirectionInverted: Whether the direction of the servo motor is inverted
+// logger: The logger instance for logging messages
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if any occurred during initialization
+func NewDefaultHandler(
+	pwm tinygoservo.PWM,
+	pin tinygoservo.Pin,
+	afterSetAngleFunc func(angle uint16),
+	isMovementEnabled func() bool,
+	frequency uint16,
+	minPulseWidth uint32,
+	maxPulseWidth uint32,
+	actuationRange uint16,
+	centerAngle uint16,
+	maxLeftAngle uint16,
+	maxRightAngle uint16,
+	isDirectionInverted bool,
+	logger tinygologger.Logger,
+) (*tinygoservo.DefaultHandler, tinygoerrors.ErrorCode) {
+	var options [10]tinygoservo.Option
+	return tinygoservo.NewHandler(
+		pwm,
+		pin,
+		appendOptions(
+			options[:0],
+			afterSetAngleFunc,
+			isMovementEnabled,
+			frequency,
+			minPulseWidth,
+			maxPulseWidth,
+			actuationRange,
+			centerAngle,
+			maxLeftAngle,
+			maxRightAngle,
+			isDirectionInverted,
+			logger,
+		)...,
+	)
+}
+
+// NewDeferredDefaultHandler creates a new instance of DefaultHandler with the parameters of the v1 constructor that
+// defers configuring the PWM peripheral until the first command or an explicit call to Initialize
+//
+// Deprecated: Use tinygoservo.NewHandler with the tinygoservo.WithDeferredInitialization option instead.
+//
+// Parameters:
+//
+// # The same as NewDefaultHandler
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if any parameter is invalid
+func NewDeferredDefaultHandler(
+	pwm tinygoservo.PWM,
+	pin tinygoservo.Pin,
+	afterSetAngleFunc func(angle uint16),
+	isMovementEnabled func() bool,
+	frequency uint16,
+	minPulseWidth uint32,
+	maxPulseWidth uint32,
+	actuationRange uint16,
+	centerAngle uint16,
+	maxLeftAngle uint16,
+	maxRightAngle uint16,
+	isDirectionInverted bool,
+	logger tinygologger.Logger,
+) (*tinygoservo.DefaultHandler, tinygoerrors.ErrorCode) {
+	var options [11]tinygoservo.Option
+	return tinygoservo.NewHandler(
+		pwm,
+		pin,
+		append(
+			appendOptions(
+				options[:0],
+				afterSetAngleFunc,
+				isMovementEnabled,
+				frequency,
+				minPulseWidth,
+				maxPulseWidth,
+				actuationRange,
+				centerAngle,
+				maxLeftAngle,
+				maxRightAngle,
+				isDirectionInverted,
+				logger,
+			),
+			tinygoservo.WithDeferredInitialization(),
+		)...,
+	)
+}
+
+// appendOptions appends the options matching the parameters of the v1 constructors
+//
+// Parameters:
+//
+// # The same as NewDefaultHandler, plus:
+//
+// options: The slice the options are appended to
+//
+// Returns:
+//
+// The options
+func appendOptions(
+	options []tinygoservo.Option,
+	afterSetAngleFunc func(angle uint16),
+	isMovementEnabled func() bool,
+	frequency uint16,
+	minPulseWidth uint32,
+	maxPulseWidth uint32,
+	actuationRange uint16,
+	centerAngle uint16,
+	maxLeftAngle uint16,
+	maxRightAngle uint16,
+	isDirectionInverted bool,
+	logger tinygologger.Logger,
+) []tinygoservo.Option {
+	options = append(
+		options,
+		tinygoservo.WithAfterSetAngleFunc(afterSetAngleFunc),
+		tinygoservo.WithMovementEnabledFunc(isMovementEnabled),
+		tinygoservo.WithFrequency(frequency),
+		tinygoservo.WithPulseRange(tinygoservo.Nanoseconds(minPulseWidth), tinygoservo.Nanoseconds(maxPulseWidth)),
+		tinygoservo.WithActuationRange(actuationRange),
+		tinygoservo.WithCenterAngle(centerAngle),
+		tinygoservo.WithLimits(maxLeftAngle, maxRightAngle),
+		tinygoservo.WithLogger(logger),
+	)
+	if isDirectionInverted {
+		options = append(options, tinygoservo.WithInvertedDirection())
+	}
+	return options
+}
@@ -0,0 +1,139 @@
+//go:build !tinygo
+
+package compat
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+)
+
+type (
+	// fakePWM is a PWM peripheral counting its configurations, with one channel per pin
+	fakePWM struct {
+		configures int
+		duties     map[uint8]uint32
+	}
+)
+
+// newFakePWM creates a new instance of fakePWM
+func newFakePWM() *fakePWM {
+	return &fakePWM{duties: make(map[uint8]uint32)}
+}
+
+func (p *fakePWM) Configure(config tinygoservo.PWMConfig) error {
+	p.configures++
+	return nil
+}
+
+func (p *fakePWM) Channel(pin tinygoservo.Pin) (uint8, error) {
+	return uint8(pin), nil
+}
+
+func (p *fakePWM) Top() uint32 {
+	return 0xffff
+}
+
+func (p *fakePWM) Set(channel uint8, value uint32) {
+	p.duties[channel] = value
+}
+
+// TestNewDefaultHandlerMatchesOptions checks the v1 constructor creates the same handler as NewHandler with the
+// matching options
+func TestNewDefaultHandlerMatchesOptions(t *testing.T) {
+	pwm := newFakePWM()
+	var angles []uint16
+	legacy, err := NewDefaultHandler(
+		pwm,
+		0,
+		func(angle uint16) { angles = append(angles, angle) },
+		nil,
+		60,
+		600000,
+		2400000,
+		270,
+		120,
+		200,
+		90,
+		true,
+		nil,
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("NewDefaultHandler: %v", err)
+	}
+	t.Cleanup(legacy.Release)
+	handler, err := tinygoservo.NewHandler(
+		pwm,
+		1,
+		tinygoservo.WithFrequency(60),
+		tinygoservo.WithPulseRange(600000, 2400000),
+		tinygoservo.WithActuationRange(270),
+		tinygoservo.WithCenterAngle(120),
+		tinygoservo.WithLimits(200, 90),
+		tinygoservo.WithInvertedDirection(),
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	t.Cleanup(handler.Release)
+
+	if legacy.LeftLimit() != handler.LeftLimit() || legacy.RightLimit() != handler.RightLimit() {
+		t.Errorf(
+			"limits = %d-%d, want %d-%d",
+			legacy.LeftLimit(),
+			legacy.RightLimit(),
+			handler.LeftLimit(),
+			handler.RightLimit(),
+		)
+	}
+	if legacy.GetCenterAngle() != handler.GetCenterAngle() || !legacy.IsDirectionInverted() {
+		t.Errorf(
+			"center %d, inverted %v, want %d and inverted",
+			legacy.GetCenterAngle(),
+			legacy.IsDirectionInverted(),
+			handler.GetCenterAngle(),
+		)
+	}
+
+	// Both handlers output the same pulse for the same angle
+	for _, angle := range []uint16{legacy.LeftLimit(), 150, legacy.RightLimit()} {
+		if err = legacy.SetAngle(angle); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("legacy SetAngle(%d): %v", angle, err)
+		}
+		if err = handler.SetAngle(angle); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("SetAngle(%d): %v", angle, err)
+		}
+		if pwm.duties[0] != pwm.duties[1] {
+			t.Errorf("duty at %d degrees = %d, want %d", angle, pwm.duties[0], pwm.duties[1])
+		}
+	}
+	if len(angles) != 3 {
+		t.Errorf("after set angle func called %d times, want 3", len(angles))
+	}
+}
+
+// TestNewDeferredDefaultHandler checks the deferred v1 constructor validates its parameters at once and configures
+// the PWM peripheral on the first command
+func TestNewDeferredDefaultHandler(t *testing.T) {
+	pwm := newFakePWM()
+	_, err := NewDeferredDefaultHandler(pwm, 0, nil, nil, 50, 2500000, 500000, 180, 90, 90, 90, false, nil)
+	if err == tinygoerrors.ErrorCodeNil {
+		t.Fatal("NewDeferredDefaultHandler accepted a min pulse width above the max one")
+	}
+
+	handler, err := NewDeferredDefaultHandler(pwm, 0, nil, nil, 50, 500000, 2500000, 180, 90, 90, 90, false, nil)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("NewDeferredDefaultHandler: %v", err)
+	}
+	t.Cleanup(handler.Release)
+	if handler.IsInitialized() || pwm.configures != 0 {
+		t.Fatalf("initialized %v with %d configurations before the first command", handler.IsInitialized(), pwm.configures)
+	}
+	if err = handler.SetAngle(45); err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("SetAngle: %v", err)
+	}
+	if !handler.IsInitialized() || pwm.configures == 0 {
+		t.Errorf("initialized %v with %d configurations after the first command", handler.IsInitialized(), pwm.configures)
+	}
+}
@@ -0,0 +1,124 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Config is a servo configuration that can be stored and reused to create handlers. NeutralPulseWidth is the
+	// measured pulse width of the mechanical center, see SetNeutralPulseWidth, or zero to map the angles linearly
+	// between the min and max pulse widths
+	Config struct {
+		Frequency           uint16
+		MinPulseWidth       Nanoseconds
+		MaxPulseWidth       Nanoseconds
+		ActuationRange      uint16
+		CenterAngle         uint16
+		MaxLeftAngle        uint16
+		MaxRightAngle       uint16
+		IsDirectionInverted bool
+		NeutralPulseWidth   Nanoseconds
+	}
+)
+
+// DefaultConfig returns the configuration of a standard 50Hz hobby servo, with pulse widths from 500us to 2500us over
+// 180 degrees, centered at 90 degrees and free to move over its whole actuation range
+//
+// Returns:
+//
+// The default configuration
+func DefaultConfig() Config {
+	return Config{
+		Frequency:      DefaultFrequency,
+		MinPulseWidth:  DefaultMinPulseWidth,
+		MaxPulseWidth:  DefaultMaxPulseWidth,
+		ActuationRange: StandardActuationRange,
+		CenterAngle:    StandardActuationRange / 2,
+		MaxLeftAngle:   StandardActuationRange / 2,
+		MaxRightAngle:  StandardActuationRange / 2,
+	}
+}
+
+// Validate checks if the configuration can be used to create a handler. Limits exceeding the actuation range are
+// valid, they are clamped by the constructor
+//
+// Returns:
+//
+// An error if any field is invalid
+func (c Config) Validate() tinygoerrors.ErrorCode {
+	// Check if the frequency is zero
+	period, err := periodFromFrequency(c.Frequency)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the pulse widths were given in microseconds instead of nanoseconds
+	if c.MaxPulseWidth < minPlausiblePulseWidth {
+		return ErrorCodeServoPulseWidthNotInNanoseconds
+	}
+
+	// Check if the min pulse width is valid
+	if c.MinPulseWidth == 0 || c.MinPulseWidth >= period {
+		return ErrorCodeServoInvalidMinPulseWidth
+	}
+
+	// Check if the max pulse width is valid
+	if c.MaxPulseWidth <= c.MinPulseWidth || c.MaxPulseWidth >= period {
+		return ErrorCodeServoInvalidMaxPulseWidth
+	}
+
+	// Check if the actuation range is valid
+	if c.ActuationRange == 0 || c.ActuationRange > MaxActuationRange {
+		return ErrorCodeServoInvalidActuationRange
+	}
+
+	// Check if the center angle is valid
+	if c.CenterAngle > c.ActuationRange {
+		return ErrorCodeServoInvalidCenterAngle
+	}
+
+	// Check if the neutral pulse width is within the pulse range
+	if c.NeutralPulseWidth != 0 && (c.NeutralPulseWidth < c.MinPulseWidth || c.NeutralPulseWidth > c.MaxPulseWidth) {
+		return ErrorCodeServoInvalidNeutralPulseWidth
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// NewFromConfig creates a new instance of DefaultHandler from a configuration
+//
+// Parameters:
+//
+// pwm: The PWM interface to control the servo
+// pin: The pin connected to the servo
+// config: The configuration of the servo
+// options: The options for the parameters not covered by the configuration, like WithLogger. The options overriding
+// the fields of the configuration take precedence
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if the configuration is invalid or any occurred during initialization
+func NewFromConfig(
+	pwm PWM,
+	pin Pin,
+	config Config,
+	options ...Option,
+) (*DefaultHandler, tinygoerrors.ErrorCode) {
+	return newHandler(
+		pwm,
+		pin,
+		handlerOptions{
+			frequency:           config.Frequency,
+			minPulseWidth:       config.MinPulseWidth,
+			maxPulseWidth:       config.MaxPulseWidth,
+			actuationRange:      config.ActuationRange,
+			centerAngle:         config.CenterAngle,
+			hasCenterAngle:      true,
+			maxLeftAngle:        config.MaxLeftAngle,
+			maxRightAngle:       config.MaxRightAngle,
+			hasLimits:           true,
+			isDirectionInverted: config.IsDirectionInverted,
+			neutralPulseWidth:   config.NeutralPulseWidth,
+		},
+		options,
+	)
+}
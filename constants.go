@@ -0,0 +1,57 @@
+package tinygo_servo
+
+const (
+	// StandardActuationRange is the actuation range of standard hobby servos, in degrees
+	StandardActuationRange uint16 = 180
+
+	// WideActuationRange is the actuation range of wide-angle servos like the DS3225 or DS3218 270 degrees variants, in
+	// degrees
+	WideActuationRange uint16 = 270
+
+	// FullActuationRange is the actuation range of full-turn positional servos, in degrees. Continuous-rotation servos
+	// are not positional, so they have no actuation range
+	FullActuationRange uint16 = 360
+
+	// MaxActuationRange is the maximum actuation range supported by the handlers, in degrees
+	MaxActuationRange = FullActuationRange
+)
+
+const (
+	// MaxErrorGroupEntries is the number of different component and error code pairs an ErrorGroup can count
+	MaxErrorGroupEntries = 16
+)
+
+const (
+	// MaxAlarmZones is the number of alarm zones a handler can watch
+	MaxAlarmZones = 4
+
+	// MaxCalibrationPoints is the number of points a calibration table can hold
+	MaxCalibrationPoints = 16
+
+	// TuningVersion is the version of the layout of the encoded tunings, its first byte
+	TuningVersion = 1
+
+	// TuningHeaderSize is the size of an encoded tuning without calibration points: version, center angle, limits,
+	// trim, neutral pulse width and points count
+	TuningHeaderSize = 14
+
+	// TuningPointSize is the size of an encoded calibration point: angle in millidegrees and pulse width
+	TuningPointSize = 8
+
+	// MaxTuningSize is the size of an encoded tuning with a full calibration table
+	MaxTuningSize = TuningHeaderSize + MaxCalibrationPoints*TuningPointSize
+)
+
+const (
+	// DefaultFrequency is the PWM frequency used by NewHandler unless overridden, the one of standard hobby servos
+	DefaultFrequency uint16 = 50
+
+	// DefaultMinPulseWidth is the min pulse width used by NewHandler unless overridden, in nanoseconds
+	DefaultMinPulseWidth Nanoseconds = 500000
+
+	// DefaultMaxPulseWidth is the max pulse width used by NewHandler unless overridden, in nanoseconds
+	DefaultMaxPulseWidth Nanoseconds = 2500000
+
+	// DefaultCalibratorStep is the pulse width step of a Calibrator unless overridden, in microseconds
+	DefaultCalibratorStep Microseconds = 10
+)
@@ -0,0 +1,201 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+)
+
+const (
+	// MaxContinuousSpeed is the speed percentage of a continuous-rotation servo at full speed
+	MaxContinuousSpeed int8 = 100
+)
+
+type (
+	// ContinuousHandler drives continuous-rotation servos like the FS90R, whose pulse width sets the speed and
+	// direction of rotation instead of a position. The speeds from -100 to 100 percent are mapped linearly between the
+	// min pulse width, the neutral pulse width that stops the servo and the max pulse width
+	//
+	// It is built on a DefaultHandler whose angles are the speeds shifted by 100, so the sleep, polarity, movement and
+	// PWM ownership features are shared with the positional servos
+	ContinuousHandler struct {
+		handler             *DefaultHandler
+		isDirectionInverted bool
+		speed               int8
+	}
+)
+
+// NewContinuousHandler creates a new instance of ContinuousHandler, stopped
+//
+// Parameters:
+//
+// pwm: The PWM interface to control the servo
+// pin: The pin connected to the servo
+// frequency: The frequency of the PWM signal
+// minPulseWidth: The pulse width of the full speed in the negative direction
+// neutralPulseWidth: The pulse width that stops the servo, between the min and max pulse widths
+// maxPulseWidth: The pulse width of the full speed in the positive direction
+// isDirectionInverted: Whether the direction of rotation is inverted
+// logger: The logger instance for logging messages
+//
+// Returns:
+//
+// An instance of ContinuousHandler and an error if any occurred during initialization
+func NewContinuousHandler(
+	pwm PWM,
+	pin Pin,
+	frequency uint16,
+	minPulseWidth Nanoseconds,
+	neutralPulseWidth Nanoseconds,
+	maxPulseWidth Nanoseconds,
+	isDirectionInverted bool,
+	logger tinygologger.Logger,
+) (*ContinuousHandler, tinygoerrors.ErrorCode) {
+	// Check if the neutral pulse width stops the servo strictly between both full speeds
+	if neutralPulseWidth <= minPulseWidth || neutralPulseWidth >= maxPulseWidth {
+		return nil, ErrorCodeServoInvalidNeutralPulseWidth
+	}
+
+	// Defer the initialization until the neutral pulse width is set, so the servo doesn't twitch at start
+	stopAngle := uint16(MaxContinuousSpeed)
+	handler, err := newDefaultHandler(
+		pwm,
+		pin,
+		nil,
+		nil,
+		frequency,
+		minPulseWidth,
+		maxPulseWidth,
+		2*stopAngle,
+		stopAngle,
+		stopAngle,
+		stopAngle,
+		false,
+		logger,
+		nil,
+		true,
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	if err = handler.SetNeutralPulseWidth(neutralPulseWidth); err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	if err = handler.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	return &ContinuousHandler{
+		handler:             handler,
+		isDirectionInverted: isDirectionInverted,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetSpeed sets the speed and direction of rotation
+//
+// Parameters:
+//
+// percent: The speed from -100 to 100 percent of the full speed, zero stops the servo
+//
+// Returns:
+//
+// An error if the speed is out of range or the pulse could not be set
+func (c *ContinuousHandler) SetSpeed(percent int8) tinygoerrors.ErrorCode {
+	if percent < -MaxContinuousSpeed || percent > MaxContinuousSpeed {
+		return c.handler.reportError(ErrorCodeServoSpeedOutOfRange)
+	}
+
+	// Map the speed to the angle of the underlying handler
+	directed := percent
+	if c.isDirectionInverted {
+		directed = -percent
+	}
+	if err := c.handler.SetAngle(uint16(int16(MaxContinuousSpeed) + int16(directed))); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	c.speed = percent
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetSpeed returns the speed and direction of rotation
+//
+// Returns:
+//
+// The speed from -100 to 100 percent of the full speed
+func (c *ContinuousHandler) GetSpeed() int8 {
+	return c.speed
+}
+
+// Stop stops the servo by outputting the neutral pulse width
+//
+// Returns:
+//
+// An error if the pulse could not be set
+func (c *ContinuousHandler) Stop() tinygoerrors.ErrorCode {
+	return c.SetSpeed(0)
+}
+
+// IsStopped checks if the servo is stopped
+//
+// Returns:
+//
+// True if the speed is zero, false otherwise
+func (c *ContinuousHandler) IsStopped() bool {
+	return c.speed == 0
+}
+
+// SetDirectionInverted flips the direction of rotation at runtime, the servo keeps its speed in the new direction
+//
+// Parameters:
+//
+// isDirectionInverted: Whether the direction of rotation is inverted
+//
+// Returns:
+//
+// An error if the pulse could not be set
+func (c *ContinuousHandler) SetDirectionInverted(isDirectionInverted bool) tinygoerrors.ErrorCode {
+	c.isDirectionInverted = isDirectionInverted
+	return c.SetSpeed(c.speed)
+}
+
+// IsDirectionInverted returns whether the direction of rotation is inverted
+//
+// Returns:
+//
+// True if the direction is inverted, false otherwise
+func (c *ContinuousHandler) IsDirectionInverted() bool {
+	return c.isDirectionInverted
+}
+
+// SetNeutralPulseWidth trims the pulse width that stops the servo, e.g. when it creeps while stopped
+//
+// Parameters:
+//
+// neutralPulseWidth: The pulse width that stops the servo, between the min and max pulse widths
+//
+// Returns:
+//
+// An error if the pulse width is not strictly between the min and max pulse widths
+func (c *ContinuousHandler) SetNeutralPulseWidth(neutralPulseWidth Nanoseconds) tinygoerrors.ErrorCode {
+	if neutralPulseWidth <= c.handler.minPulseWidth || neutralPulseWidth >= c.handler.maxPulseWidth {
+		return ErrorCodeServoInvalidNeutralPulseWidth
+	}
+	return c.handler.SetNeutralPulseWidth(neutralPulseWidth)
+}
+
+// GetNeutralPulseWidth returns the pulse width that stops the servo
+//
+// Returns:
+//
+// The neutral pulse width
+func (c *ContinuousHandler) GetNeutralPulseWidth() Nanoseconds {
+	return c.handler.GetNeutralPulseWidth()
+}
+
+// Handler returns the underlying DefaultHandler, for the features shared with the positional servos like Sleep,
+// SetPolarity or DisableMovement. Its angle commands bypass the speed state of the ContinuousHandler
+//
+// Returns:
+//
+// The underlying handler
+func (c *ContinuousHandler) Handler() *DefaultHandler {
+	return c.handler
+}
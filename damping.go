@@ -0,0 +1,133 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// dampedMaxStepMs is the longest integration step of the damped pointing mode, longer updates are split so the
+	// response stays accurate with a slow main loop. The implicit integration is stable for any step, the split only
+	// bounds its numerical damping
+	dampedMaxStepMs = 10
+
+	// dampedMaxElapsedMs is the longest time integrated by a single update, so a stalled main loop doesn't make the
+	// servo catch up in a burst of steps
+	dampedMaxElapsedMs = 100
+)
+
+// EnableDampedPointing makes the servo motor follow the targets set with SetDampedTarget like a physically damped
+// needle, with a second-order response, so a pointer driven by noisy sensor data (wind vane, compass) doesn't twitch.
+// The response is driven by Update and starts from the current angle at rest
+//
+// Parameters:
+//
+// naturalFrequencyHz: The natural frequency of the response in hertz, lower values respond slower
+// dampingRatio: The damping ratio of the response, 1 is critically damped, lower values overshoot and higher values
+// approach the target slower without overshooting
+//
+// Returns:
+//
+// An error if the natural frequency or the damping ratio are not positive
+func (h *DefaultHandler) EnableDampedPointing(naturalFrequencyHz float32, dampingRatio float32) tinygoerrors.ErrorCode {
+	if naturalFrequencyHz <= 0 || dampingRatio <= 0 {
+		return ErrorCodeServoInvalidDamping
+	}
+
+	// Precalculate the coefficients of the response, in rad/s
+	naturalFrequency := 2 * 3.14159265 * naturalFrequencyHz
+	h.dampedStiffness = naturalFrequency * naturalFrequency
+	h.dampedFriction = 2 * dampingRatio * naturalFrequency
+
+	// Start at rest from the current angle
+	if !h.isDamped {
+		h.dampedPosition = float32(h.GetAngleMilliDegrees())
+		h.dampedTarget = h.dampedPosition
+		h.dampedVelocity = 0
+		h.hasDampedTimestamp = false
+	}
+	h.isDamped = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// DisableDampedPointing stops the damped response, the servo motor stays at its current angle
+func (h *DefaultHandler) DisableDampedPointing() {
+	h.isDamped = false
+}
+
+// IsDampedPointingEnabled returns whether the damped pointing mode is enabled
+//
+// Returns:
+//
+// True if the servo motor follows its targets with the damped response, false otherwise
+func (h *DefaultHandler) IsDampedPointingEnabled() bool {
+	return h.isDamped
+}
+
+// SetDampedTarget sets the angle the damped response moves the servo motor towards. It is clamped to the limits, so
+// noisy readings beyond them don't fail
+//
+// Parameters:
+//
+// milliDegrees: The target angle in millidegrees
+func (h *DefaultHandler) SetDampedTarget(milliDegrees uint32) {
+	leftLimit := uint32(h.LeftLimit()) * 1000
+	rightLimit := uint32(h.RightLimit()) * 1000
+	if milliDegrees < leftLimit {
+		milliDegrees = leftLimit
+	} else if milliDegrees > rightLimit {
+		milliDegrees = rightLimit
+	}
+	h.dampedTarget = float32(milliDegrees)
+	h.recordAngle(milliDegrees)
+}
+
+// updateDamped integrates the damped response and moves the servo motor
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateDamped(nowMs uint32) {
+	if !h.isDamped {
+		return
+	}
+
+	// Start integrating on the first update
+	if !h.hasDampedTimestamp {
+		h.hasDampedTimestamp = true
+		h.dampedTimestampMs = nowMs
+		return
+	}
+	elapsedMs := nowMs - h.dampedTimestampMs
+	h.dampedTimestampMs = nowMs
+	if elapsedMs > dampedMaxElapsedMs {
+		elapsedMs = dampedMaxElapsedMs
+	}
+
+	// Integrate with the implicit Euler method, solving the velocity at the end of the step, so the response settles
+	// for any natural frequency and damping ratio instead of diverging once they span several steps
+	for elapsedMs > 0 {
+		stepMs := elapsedMs
+		if stepMs > dampedMaxStepMs {
+			stepMs = dampedMaxStepMs
+		}
+		elapsedMs -= stepMs
+		dt := float32(stepMs) / 1000
+		h.dampedVelocity = (h.dampedVelocity + h.dampedStiffness*(h.dampedTarget-h.dampedPosition)*dt) /
+			(1 + h.dampedFriction*dt + h.dampedStiffness*dt*dt)
+		h.dampedPosition += h.dampedVelocity * dt
+	}
+
+	// Keep the response within the limits, stopping it against them
+	leftLimit := float32(uint32(h.LeftLimit()) * 1000)
+	rightLimit := float32(uint32(h.RightLimit()) * 1000)
+	if h.dampedPosition < leftLimit {
+		h.dampedPosition = leftLimit
+		h.dampedVelocity = 0
+	} else if h.dampedPosition > rightLimit {
+		h.dampedPosition = rightLimit
+		h.dampedVelocity = 0
+	}
+
+	// Move the servo motor to the rounded position
+	_ = h.setAngleMilliDegrees(uint32(h.dampedPosition + 0.5))
+}
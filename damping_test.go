@@ -0,0 +1,81 @@
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestDampedPointingConverges sweeps the natural frequency and the damping ratio, checking the response settles on
+// the target for every pair, without overshooting it when critically damped or overdamped
+func TestDampedPointingConverges(t *testing.T) {
+	const (
+		targetMilliDegrees = 100000
+		toleranceMilliDeg  = 500
+	)
+	frequencies := []float32{0.1, 0.5, 1, 2, 5, 10, 20, 50}
+	dampingRatios := []float32{0.1, 0.3, 0.7, 1, 2, 5, 10, 20}
+	updateIntervalsMs := []uint32{1, 10, 33, 100}
+	for _, frequency := range frequencies {
+		for _, dampingRatio := range dampingRatios {
+			for _, intervalMs := range updateIntervalsMs {
+				handler, err := NewOutputHandler(servotest.NewOutput())
+				if err != 0 {
+					t.Fatalf("NewOutputHandler: %d", err)
+				}
+				if err = handler.EnableDampedPointing(frequency, dampingRatio); err != 0 {
+					t.Fatalf("EnableDampedPointing(%v, %v): %d", frequency, dampingRatio, err)
+				}
+				handler.SetDampedTarget(targetMilliDegrees)
+
+				// Simulate long enough for the slowest pole of the response to settle
+				omega := 2 * 3.14159265 * frequency
+				settlingS := 20 / (dampingRatio * omega)
+				if dampingRatio > 1 {
+					settlingS = 20 * 2 * dampingRatio / omega
+				}
+				steps := uint32(settlingS*1000)/intervalMs + 2
+				peak := uint32(0)
+				for step := uint32(0); step <= steps; step++ {
+					handler.Update(step * intervalMs)
+					peak = max(peak, handler.GetAngleMilliDegrees())
+				}
+
+				angle := handler.GetAngleMilliDegrees()
+				if angle+toleranceMilliDeg < targetMilliDegrees || angle > targetMilliDegrees+toleranceMilliDeg {
+					t.Errorf(
+						"frequency %vHz, damping ratio %v, update every %dms: settled at %d, expected %d",
+						frequency,
+						dampingRatio,
+						intervalMs,
+						angle,
+						targetMilliDegrees,
+					)
+				}
+				if dampingRatio >= 1 && peak > targetMilliDegrees+toleranceMilliDeg {
+					t.Errorf(
+						"frequency %vHz, damping ratio %v, update every %dms: overshot to %d",
+						frequency,
+						dampingRatio,
+						intervalMs,
+						peak,
+					)
+				}
+			}
+		}
+	}
+}
+
+// TestDampedPointingRejectsInvalidParameters checks the natural frequency and the damping ratio must be positive
+func TestDampedPointingRejectsInvalidParameters(t *testing.T) {
+	handler, err := NewOutputHandler(servotest.NewOutput())
+	if err != 0 {
+		t.Fatalf("NewOutputHandler: %d", err)
+	}
+	for _, parameters := range [][2]float32{{0, 1}, {1, 0}, {-1, 1}, {1, -1}} {
+		if err = handler.EnableDampedPointing(parameters[0], parameters[1]); err != ErrorCodeServoInvalidDamping {
+			t.Errorf("EnableDampedPointing(%v, %v) = %d, expected %d", parameters[0], parameters[1], err,
+				ErrorCodeServoInvalidDamping)
+		}
+	}
+}
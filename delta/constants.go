@@ -0,0 +1,6 @@
+package delta
+
+const (
+	// ArmsCount is the number of arms of a delta mechanism, spaced 120 degrees apart around the vertical axis
+	ArmsCount = 3
+)
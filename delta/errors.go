@@ -0,0 +1,19 @@
+package delta
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeDeltaStartNumber is the starting number for delta mechanism related error codes.
+	ErrorCodeDeltaStartNumber uint16 = 5620
+)
+
+const (
+	ErrorCodeDeltaNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeDeltaStartNumber)
+	ErrorCodeDeltaInvalidGeometry
+	ErrorCodeDeltaInvalidWorkspace
+	ErrorCodeDeltaOutsideWorkspace
+	ErrorCodeDeltaUnreachable
+	ErrorCodeDeltaAngleOutOfRange
+)
@@ -0,0 +1,15 @@
+package delta
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the delta mechanisms, so the kinematics
+	// can be checked against fake arms
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		LeftLimit() uint16
+		RightLimit() uint16
+	}
+)
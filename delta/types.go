@@ -0,0 +1,210 @@
+package delta
+
+import (
+	"math"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Geometry holds the dimensions of a rotary delta mechanism, in any length unit as long as it is the same for
+	// every dimension and the coordinates. The origin is the center of the base, with the Z axis pointing up, so the
+	// effector works at negative Z, and the first arm pivots on the positive X axis
+	Geometry struct {
+		// BaseRadius is the distance from the center of the base to the pivot axis of the upper arms
+		BaseRadius float32
+
+		// EffectorRadius is the distance from the center of the effector to the joints of the forearms
+		EffectorRadius float32
+
+		// UpperArmLength is the length of the upper arms driven by the servos
+		UpperArmLength float32
+
+		// ForearmLength is the length of the parallelograms linking the upper arms to the effector
+		ForearmLength float32
+
+		// HorizontalAngle is the servo angle in degrees at which the upper arms are horizontal, the servo angle
+		// increases as the arms swing down
+		HorizontalAngle float32
+	}
+
+	// Delta drives a 3-servo rotary delta mechanism, e.g. a small pick-and-place robot or a camera stabilizer
+	// platform, solving the servo angles of effector positions
+	Delta struct {
+		servos       [ArmsCount]Servo
+		geometry     Geometry
+		hasWorkspace bool
+		radius       float32
+		minZ         float32
+		maxZ         float32
+	}
+)
+
+// NewDelta creates a new instance of Delta
+//
+// Parameters:
+//
+// servos: The servos of the arms, ordered counterclockwise seen from above starting at the positive X axis
+// geometry: The dimensions of the mechanism
+//
+// Returns:
+//
+// An instance of Delta and an error if any servo is nil or a dimension is not positive
+func NewDelta(servos [ArmsCount]Servo, geometry Geometry) (*Delta, tinygoerrors.ErrorCode) {
+	for _, servo := range servos {
+		if servo == nil {
+			return nil, ErrorCodeDeltaNilServo
+		}
+	}
+	if geometry.BaseRadius < 0 || geometry.EffectorRadius < 0 || geometry.UpperArmLength <= 0 ||
+		geometry.ForearmLength <= 0 {
+		return nil, ErrorCodeDeltaInvalidGeometry
+	}
+	return &Delta{
+		servos:   servos,
+		geometry: geometry,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetWorkspace restricts the effector to a vertical cylinder centered on the Z axis, on top of the reach of the arms
+// and the limits of the servos, e.g. to keep it clear of the frame and the work surface
+//
+// Parameters:
+//
+// radius: The radius of the cylinder
+// minZ: The lowest Z coordinate
+// maxZ: The highest Z coordinate
+//
+// Returns:
+//
+// An error if the radius is not positive or the Z range is inverted
+func (d *Delta) SetWorkspace(radius float32, minZ float32, maxZ float32) tinygoerrors.ErrorCode {
+	if radius <= 0 || minZ > maxZ {
+		return ErrorCodeDeltaInvalidWorkspace
+	}
+	d.radius = radius
+	d.minZ = minZ
+	d.maxZ = maxZ
+	d.hasWorkspace = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ClearWorkspace removes the workspace restriction, leaving only the reach of the arms and the limits of the servos
+func (d *Delta) ClearWorkspace() {
+	d.hasWorkspace = false
+}
+
+// IsInWorkspace checks if an effector position is within the workspace
+//
+// Parameters:
+//
+// x: The X coordinate of the effector
+// y: The Y coordinate of the effector
+// z: The Z coordinate of the effector
+//
+// Returns:
+//
+// True if there is no workspace or the position is within it, false otherwise
+func (d *Delta) IsInWorkspace(x float32, y float32, z float32) bool {
+	if !d.hasWorkspace {
+		return true
+	}
+	return x*x+y*y <= d.radius*d.radius && z >= d.minZ && z <= d.maxZ
+}
+
+// solveArm solves the angle of an upper arm below the horizontal for an effector position in the frame of the arm,
+// where the arm pivots on the positive X axis and swings in the XZ plane
+//
+// Parameters:
+//
+// x: The X coordinate of the effector in the frame of the arm
+// y: The Y coordinate of the effector in the frame of the arm
+// z: The Z coordinate of the effector
+//
+// Returns:
+//
+// The angle of the arm in radians and an error if the arm can't reach the position
+func (d *Delta) solveArm(x float64, y float64, z float64) (float64, tinygoerrors.ErrorCode) {
+	upperArm := float64(d.geometry.UpperArmLength)
+	forearm := float64(d.geometry.ForearmLength)
+
+	// The elbow and the forearm joint of the effector must be a forearm length apart, which reduces to
+	// a*cos(angle) + b*sin(angle) = k
+	offset := x + float64(d.geometry.EffectorRadius) - float64(d.geometry.BaseRadius)
+	a := -2 * offset * upperArm
+	b := 2 * z * upperArm
+	k := forearm*forearm - upperArm*upperArm - offset*offset - y*y - z*z
+	norm := math.Sqrt(a*a + b*b)
+	if norm == 0 || math.Abs(k) > norm {
+		return 0, ErrorCodeDeltaUnreachable
+	}
+
+	// Keep the solution with the elbow out
+	return math.Atan2(b, a) + math.Acos(k/norm), tinygoerrors.ErrorCodeNil
+}
+
+// Solve solves the servo angles of an effector position without moving the servos
+//
+// Parameters:
+//
+// x: The X coordinate of the effector
+// y: The Y coordinate of the effector
+// z: The Z coordinate of the effector
+//
+// Returns:
+//
+// The servo angles in millidegrees, and an error if the position is outside the workspace, the arms can't reach it or
+// a servo angle is out of the limits of its servo
+func (d *Delta) Solve(x float32, y float32, z float32) ([ArmsCount]uint32, tinygoerrors.ErrorCode) {
+	var angles [ArmsCount]uint32
+	if !d.IsInWorkspace(x, y, z) {
+		return angles, ErrorCodeDeltaOutsideWorkspace
+	}
+
+	for index := range d.servos {
+		// Rotate the position into the frame of the arm
+		sin, cos := math.Sincos(float64(index) * 2 * math.Pi / ArmsCount)
+		armX := float64(x)*cos + float64(y)*sin
+		armY := -float64(x)*sin + float64(y)*cos
+		radians, err := d.solveArm(armX, armY, float64(z))
+		if err != tinygoerrors.ErrorCodeNil {
+			return angles, err
+		}
+
+		// Map the arm angle to the servo angle and check it against the servo limits
+		milliDegrees := math.Round((float64(d.geometry.HorizontalAngle) + radians*180/math.Pi) * 1000)
+		servo := d.servos[index]
+		if milliDegrees < float64(servo.LeftLimit())*1000 || milliDegrees > float64(servo.RightLimit())*1000 {
+			return angles, ErrorCodeDeltaAngleOutOfRange
+		}
+		angles[index] = uint32(milliDegrees)
+	}
+	return angles, tinygoerrors.ErrorCodeNil
+}
+
+// MoveToXYZ moves the effector to a position
+//
+// Parameters:
+//
+// x: The X coordinate of the effector
+// y: The Y coordinate of the effector
+// z: The Z coordinate of the effector
+//
+// Returns:
+//
+// An error if the position can't be solved, in which case no servo is moved, or the first error returned by the servos
+func (d *Delta) MoveToXYZ(x float32, y float32, z float32) tinygoerrors.ErrorCode {
+	angles, err := d.Solve(x, y, z)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	firstErr := tinygoerrors.ErrorCodeNil
+	for index, servo := range d.servos {
+		err = servo.SetAngleMilliDegrees(angles[index])
+		if err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,47 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetDirectionInverted flips the direction of the servo motor at runtime, e.g. when the same firmware runs on
+// mirrored left and right mechanisms. The center angle, the limits and the current angle are mirrored across the
+// actuation range, as the constructor does for the inverted handlers, so the relative commands and the trim keep their
+// meaning and the servo motor is moved to the mirrored angle
+//
+// Parameters:
+//
+// isDirectionInverted: Whether the direction of the servo motor is inverted
+//
+// Returns:
+//
+// An error if the servo motor could not be moved to the mirrored angle
+func (h *DefaultHandler) SetDirectionInverted(isDirectionInverted bool) tinygoerrors.ErrorCode {
+	if isDirectionInverted == h.isDirectionInverted {
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.isDirectionInverted = isDirectionInverted
+
+	// Mirror the limits and the center angle, keeping the limits warning of the requested limits
+	h.leftLimitAngle, h.rightLimitAngle = h.actuationRange-h.rightLimitAngle, h.actuationRange-h.leftLimitAngle
+	h.setCenterAngle(h.actuationRange - h.centerAngle)
+
+	// Move the servo motor to the mirrored angle, without initializing a deferred handler
+	milliDegrees := uint32(h.actuationRange)*1000 - h.GetAngleMilliDegrees()
+	if !h.isInitialized {
+		h.angle = uint16(milliDegrees / 1000)
+		h.angleFraction = uint16(milliDegrees % 1000)
+		h.pulse = h.calculatePulseMilliDegrees(milliDegrees)
+		return tinygoerrors.ErrorCodeNil
+	}
+	return h.SetAngleMilliDegrees(milliDegrees)
+}
+
+// IsDirectionInverted returns whether the direction of the servo motor is inverted
+//
+// Returns:
+//
+// True if the direction is inverted, set by the constructor or SetDirectionInverted, false otherwise
+func (h *DefaultHandler) IsDirectionInverted() bool {
+	return h.isDirectionInverted
+}
@@ -0,0 +1,44 @@
+package tinygo_servo
+
+const (
+	// EasingScale is the fixed-point scale of the progress of the timed moves, 1.0 in 16.16 fixed point
+	EasingScale = 1 << 16
+)
+
+// Ease maps the linear progress of a timed move to its eased progress with integer math, shared with the pose moves of
+// the group package
+//
+// Parameters:
+//
+// easing: The progress curve
+// progress: The linear progress, from 0 to EasingScale
+//
+// Returns:
+//
+// The eased progress, from 0 to EasingScale
+func Ease(easing Easing, progress uint32) uint32 {
+	p := uint64(progress)
+	remaining := EasingScale - p
+	switch easing {
+	case EasingIn:
+		// Quadratic acceleration from rest
+		return uint32(p * p / EasingScale)
+	case EasingOut:
+		// Quadratic deceleration to rest
+		return uint32(EasingScale - remaining*remaining/EasingScale)
+	case EasingInOut:
+		// Quadratic acceleration during the first half and deceleration during the second one
+		if p < EasingScale/2 {
+			return uint32(2 * p * p / EasingScale)
+		}
+		return uint32(EasingScale - 2*remaining*remaining/EasingScale)
+	case EasingCubic:
+		// Cubic acceleration during the first half and deceleration during the second one
+		if p < EasingScale/2 {
+			return uint32(4 * p * p / EasingScale * p / EasingScale)
+		}
+		return uint32(EasingScale - 4*remaining*remaining/EasingScale*remaining/EasingScale)
+	default:
+		return progress
+	}
+}
@@ -3,6 +3,25 @@ package tinygo_servo
 type (
 	// Direction is an enum to represent the different servo directions for the vehicle.
 	Direction uint8
+
+	// Rounding is an enum to represent the rounding behavior of the angle to pulse width and pulse width to timer
+	// ticks conversions.
+	Rounding uint8
+
+	// Polarity is an enum to represent the logic level of the pulses on the signal line.
+	Polarity uint8
+
+	// DisabledCommandPolicy is an enum to represent how angle commands are handled while movement is disabled.
+	DisabledCommandPolicy uint8
+
+	// ControlSource is an enum to represent the sources that can command a servo.
+	ControlSource uint8
+
+	// Easing is an enum to represent the progress curves of the timed moves.
+	Easing uint8
+
+	// CalibratorPhase is an enum to represent the pulse widths a Calibrator walks through.
+	CalibratorPhase uint8
 )
 
 const (
@@ -12,6 +31,47 @@ const (
 	DirectionStraight
 )
 
+const (
+	RoundingFloor Rounding = iota
+	RoundingNearest
+	RoundingCeiling
+)
+
+const (
+	PolarityNormal Polarity = iota
+	PolarityInverted
+)
+
+const (
+	DisabledCommandPolicyStore DisabledCommandPolicy = iota
+	DisabledCommandPolicyQueue
+	DisabledCommandPolicyDiscard
+)
+
+const (
+	ControlSourceAutonomous ControlSource = iota
+	ControlSourceRC
+	ControlSourceSerial
+	ControlSourceManual
+	controlSourcesCount
+)
+
+const (
+	EasingLinear Easing = iota
+	EasingIn
+	EasingOut
+	EasingInOut
+	EasingCubic
+	easingsCount
+)
+
+const (
+	CalibratorPhaseMin CalibratorPhase = iota
+	CalibratorPhaseCenter
+	CalibratorPhaseMax
+	CalibratorPhaseDone
+)
+
 // InvertedDirection returns the inverted direction.
 func (d Direction) InvertedDirection() Direction {
 	switch d {
@@ -25,3 +85,8 @@ func (d Direction) InvertedDirection() Direction {
 		return DirectionNil
 	}
 }
+
+// IsValid returns whether the easing is one of the known progress curves.
+func (e Easing) IsValid() bool {
+	return e < easingsCount
+}
@@ -20,4 +20,56 @@ const (
 	ErrorCodeServoFailedToGetPWMChannel
 	ErrorCodeServoInvalidActuationRange
 	ErrorCodeServoInvalidCenterAngle
+	ErrorCodeServoInvalidSleepRefresh
+	ErrorCodeServoUnknownRounding
+	ErrorCodeServoUnknownPolarity
+	ErrorCodeServoUnknownDisabledCommandPolicy
+	ErrorCodeServoMovementDisabled
+	ErrorCodeServoInvalidGlobalRangeCap
+	ErrorCodeServoNilPWM
+	ErrorCodeServoPWMPeriodConflict
+	ErrorCodeServoTooManyHandlers
+	ErrorCodeServoTooManySyncTargets
+	ErrorCodeServoSyncStartArmed
+	ErrorCodeServoNotInitialized
+	ErrorCodeServoFailedToSetInterrupt
+	ErrorCodeServoInvalidLimits
+	ErrorCodeServoNotLearningLimits
+	ErrorCodeServoLimitsAlreadyMarked
+	ErrorCodeServoLimitsNotMarked
+	ErrorCodeServoLimitsClamped
+	ErrorCodeServoInvalidDamping
+	ErrorCodeServoUnknownControlSource
+	ErrorCodeServoTooManyAlarmZones
+	ErrorCodeServoInvalidAlarmZone
+	ErrorCodeServoInvalidNeutralPulseWidth
+	ErrorCodeServoInvalidPulseTicks
+	ErrorCodeServoUnreachablePulseTicks
+	ErrorCodeServoZeroTop
+	ErrorCodeServoSpeedOutOfRange
+	ErrorCodeServoInvalidHeartbeat
+	ErrorCodeServoInvalidPulseWidth
+	ErrorCodeServoInvalidUserScale
+	ErrorCodeServoUserScaleNotSet
+	ErrorCodeServoUnknownEasing
+	ErrorCodeServoStaleCommand
+	ErrorCodeServoInvalidMotionProfile
+	ErrorCodeServoInvalidSweepPeriod
+	ErrorCodeServoEmptyRecording
+	ErrorCodeServoImmediateUnavailable
+	ErrorCodeServoNilOutput
+	ErrorCodeServoNoPWM
+	ErrorCodeServoNilCapture
+	ErrorCodeServoSelfCheckUnavailable
+	ErrorCodeServoCaptureTimeout
+	ErrorCodeServoPulseMismatch
+	ErrorCodeServoInvalidSlowZone
+	ErrorCodeServoInvalidTrim
+	ErrorCodeServoPulseWidthNotInNanoseconds
+	ErrorCodeServoInvalidCalibration
+	ErrorCodeServoInvalidCalibratorCommand
+	ErrorCodeServoCalibrationIncomplete
+	ErrorCodeServoNilTuningStorage
+	ErrorCodeServoInvalidTuning
+	ErrorCodeServoImmediateSpeedCapped
 )
@@ -0,0 +1,63 @@
+package tinygo_servo
+
+// estimatedAngleAt dead-reckons the angle of the horn at a point in time from the last command and the response
+// model
+//
+// Parameters:
+//
+// nowMs: The time to estimate the angle at, it may wrap around
+//
+// Returns:
+//
+// The estimated angle in millidegrees
+func (h *DefaultHandler) estimatedAngleAt(nowMs uint32) uint32 {
+	target := h.GetAngleMilliDegrees()
+	if h.responseMaxSpeed == 0 {
+		return target
+	}
+
+	// The horn doesn't move until the lag has elapsed
+	elapsedMs := nowMs - h.estimateStartMs
+	if elapsedMs <= h.responseLagMs {
+		return h.estimateFrom
+	}
+
+	// Then it moves towards the target at the identified speed, degrees per second times milliseconds are millidegrees
+	moved := uint64(h.responseMaxSpeed) * uint64(elapsedMs-h.responseLagMs)
+	if h.estimateFrom < target {
+		if moved >= uint64(target-h.estimateFrom) {
+			return target
+		}
+		return h.estimateFrom + uint32(moved)
+	}
+	if moved >= uint64(h.estimateFrom-target) {
+		return target
+	}
+	return h.estimateFrom - uint32(moved)
+}
+
+// startEstimate starts dead-reckoning a new command from the angle the horn most likely is at
+func (h *DefaultHandler) startEstimate() {
+	h.estimateFrom = h.estimatedAngleAt(h.lastUpdateMs)
+	h.estimateStartMs = h.lastUpdateMs
+}
+
+// EstimatedAngleMilliDegrees returns the angle the horn of an open-loop servo most likely is at, dead-reckoned from
+// the commanded angles and the response model set with SetResponseModel, as of the last Update. Without a response
+// model it is the commanded angle
+//
+// Returns:
+//
+// The estimated angle in millidegrees
+func (h *DefaultHandler) EstimatedAngleMilliDegrees() uint32 {
+	return h.estimatedAngleAt(h.lastUpdateMs)
+}
+
+// EstimatedAngle returns the angle the horn of an open-loop servo most likely is at, see EstimatedAngleMilliDegrees
+//
+// Returns:
+//
+// The estimated angle in degrees, rounded to the nearest one
+func (h *DefaultHandler) EstimatedAngle() uint16 {
+	return uint16((h.EstimatedAngleMilliDegrees() + 500) / 1000)
+}
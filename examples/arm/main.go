@@ -0,0 +1,92 @@
+//go:build rp2040 || nrf52840
+
+// Arm moves a 4-servo arm (base, shoulder, elbow and gripper) through a pick-and-place sequence of poses.
+package main
+
+import (
+	"time"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/examples/internal/board"
+)
+
+const (
+	// jointBase is the index of the base joint
+	jointBase = iota
+
+	// jointShoulder is the index of the shoulder joint
+	jointShoulder
+
+	// jointElbow is the index of the elbow joint
+	jointElbow
+
+	// jointGripper is the index of the gripper joint
+	jointGripper
+
+	// jointsCount is the number of joints of the arm
+	jointsCount
+)
+
+var (
+	// poses are the absolute angles of every joint for each step of the sequence
+	poses = [][jointsCount]uint16{
+		{90, 90, 90, 60},
+		{45, 120, 60, 60},
+		{45, 120, 60, 120},
+		{135, 90, 90, 120},
+		{135, 120, 60, 60},
+	}
+)
+
+// newJoint creates the handler of a joint of the arm
+//
+// Parameters:
+//
+// slot: The board servo slot the joint is connected to
+// centerAngle: The rest angle of the joint
+// maxAngle: The maximum angle from the center in both directions
+//
+// Returns:
+//
+// The joint handler and an error if any occurred during initialization
+func newJoint(slot board.Servo, centerAngle uint16, maxAngle uint16) (*tinygoservo.DefaultHandler, tinygoerrors.ErrorCode) {
+	return tinygoservo.NewHandler(
+		slot.PWM,
+		slot.Pin,
+		tinygoservo.WithCenterAngle(centerAngle),
+		tinygoservo.WithLimits(maxAngle, maxAngle),
+	)
+}
+
+func main() {
+	logger := tinygologger.NewDefaultLogger(256)
+
+	// Create every joint of the arm
+	var joints [jointsCount]*tinygoservo.DefaultHandler
+	for index := range joints {
+		joint, err := newJoint(board.Servos[index], 90, 90)
+		if err != tinygoerrors.ErrorCodeNil {
+			logger.ErrorMessageWithErrorCode([]byte("Failed to create an arm joint:"), err, true)
+			return
+		}
+		joints[index] = joint
+	}
+
+	for {
+		for _, pose := range poses {
+			// Move the gripper once the arm has reached the pose, so objects aren't dropped mid-move
+			for index := jointBase; index < jointGripper; index++ {
+				if err := joints[index].SetAngle(pose[index]); err != tinygoerrors.ErrorCodeNil {
+					logger.WarningMessageWithErrorCode([]byte("Failed to move an arm joint:"), err, true)
+				}
+			}
+			time.Sleep(500 * time.Millisecond)
+			if err := joints[jointGripper].SetAngle(pose[jointGripper]); err != tinygoerrors.ErrorCodeNil {
+				logger.WarningMessageWithErrorCode([]byte("Failed to move the gripper:"), err, true)
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+	}
+}
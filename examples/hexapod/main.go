@@ -0,0 +1,240 @@
+//go:build rp2040 || nrf52840
+
+// Hexapod walks a six-legged robot forward with a tripod gait. Its 18 servos, three per leg, are driven by two PCA9685
+// boards chained on the I2C bus, and each tripod of legs is a group moved through the gait by its own pose move, so
+// one tripod swings forward while the other pushes the body.
+package main
+
+import (
+	"machine"
+	"time"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/group"
+	"github.com/ralvarezdev/tinygo-servo/v2/pca9685"
+)
+
+const (
+	// jointCoxa is the index of the joint that swings a leg forward and backward
+	jointCoxa = iota
+
+	// jointFemur is the index of the joint that lifts a leg
+	jointFemur
+
+	// jointTibia is the index of the joint that holds the body up
+	jointTibia
+
+	// jointsCount is the number of joints of a leg
+	jointsCount
+)
+
+const (
+	// legsCount is the number of legs, the first half on the left side on the first board and the second half on the
+	// right side on the second board
+	legsCount = 6
+
+	// legsPerSide is the number of legs on each side
+	legsPerSide = legsCount / 2
+
+	// tripodsCount is the number of tripods of legs
+	tripodsCount = 2
+
+	// legsPerTripod is the number of legs of a tripod
+	legsPerTripod = legsCount / tripodsCount
+
+	// centerAngle is the rest angle of every joint
+	centerAngle = 90
+
+	// strideAngle is the angle the coxa joints swing forward and backward from the center
+	strideAngle = 20
+
+	// liftAngle is the angle the femur joints lift the swinging legs
+	liftAngle = 30
+
+	// phaseDurationMs is the duration of every phase of the gait
+	phaseDurationMs = 300
+)
+
+type (
+	// legPose is the coxa and femur angles of the legs of a tripod, relative to the center
+	legPose struct {
+		coxa  int16
+		femur int16
+	}
+
+	// gaitPhase is the pose of the swinging and the pushing tripods during a phase of the gait
+	gaitPhase struct {
+		swing legPose
+		push  legPose
+	}
+)
+
+var (
+	// tripods are the legs of each tripod: the front and rear legs of a side with the middle leg of the other one
+	tripods = [tripodsCount][legsPerTripod]uint8{
+		{0, 2, 4},
+		{3, 5, 1},
+	}
+
+	// gait are the phases of a step, after which the tripods swap roles. The swinging tripod is lifted, swung forward
+	// while the pushing tripod moves the body, and then lowered
+	gait = [...]gaitPhase{
+		{swing: legPose{coxa: -strideAngle, femur: liftAngle}, push: legPose{coxa: strideAngle}},
+		{swing: legPose{coxa: strideAngle, femur: liftAngle}, push: legPose{coxa: -strideAngle}},
+		{swing: legPose{coxa: strideAngle}, push: legPose{coxa: -strideAngle}},
+	}
+)
+
+// channel returns the channel of a joint on the chain, the legs of each side being on their own board
+//
+// Parameters:
+//
+// leg: The index of the leg
+// joint: The index of the joint
+//
+// Returns:
+//
+// The channel number across the chain
+func channel(leg uint8, joint uint8) machine.Pin {
+	board := leg / legsPerSide
+	return machine.Pin(board*pca9685.ChannelsCount + (leg%legsPerSide)*jointsCount + joint)
+}
+
+// jointID returns the ID of a joint within its tripod group
+//
+// Parameters:
+//
+// leg: The index of the leg
+// joint: The index of the joint
+//
+// Returns:
+//
+// The ID of the joint
+func jointID(leg uint8, joint uint8) uint8 {
+	return leg*jointsCount + joint
+}
+
+// newJoint creates the handler of a leg joint. The joints of the right side are mirrored, so they are inverted to
+// move the legs of both sides the same way
+//
+// Parameters:
+//
+// chain: The boards driving the servos
+// leg: The index of the leg
+// joint: The index of the joint
+//
+// Returns:
+//
+// The joint handler and an error if any occurred during initialization
+func newJoint(chain *pca9685.Chain, leg uint8, joint uint8) (*tinygoservo.DefaultHandler, tinygoerrors.ErrorCode) {
+	options := []tinygoservo.Option{tinygoservo.WithLimits(45, 45)}
+	if leg >= legsPerSide {
+		options = append(options, tinygoservo.WithInvertedDirection())
+	}
+	return tinygoservo.NewHandler(chain, channel(leg, joint), options...)
+}
+
+// startPhase starts the pose move of a tripod to its pose during a gait phase
+//
+// Parameters:
+//
+// move: The pose move of the tripod
+// legs: The legs of the tripod
+// pose: The pose of the legs
+// nowMs: The current time in milliseconds
+//
+// Returns:
+//
+// An error if the move could not be started
+func startPhase(move *group.PoseMove, legs [legsPerTripod]uint8, pose legPose, nowMs uint32) tinygoerrors.ErrorCode {
+	var targets [legsPerTripod * jointsCount]group.PoseTarget
+	for index, leg := range legs {
+		angles := [jointsCount]int16{
+			jointCoxa:  pose.coxa,
+			jointFemur: pose.femur,
+			jointTibia: 0,
+		}
+		for joint, angle := range angles {
+			targets[index*jointsCount+joint] = group.PoseTarget{
+				ID:                jointID(leg, uint8(joint)),
+				AngleMilliDegrees: uint32(centerAngle+angle) * 1000,
+				Easing:            tinygoservo.EasingInOut,
+			}
+		}
+	}
+	return move.Start(targets[:], phaseDurationMs, true, nowMs)
+}
+
+func main() {
+	logger := tinygologger.NewDefaultLogger(256)
+
+	// Chain the boards of both sides, the second one with its first address pin high
+	if err := machine.I2C0.Configure(machine.I2CConfig{Frequency: 400000}); err != nil {
+		logger.ErrorMessage([]byte("Failed to configure the I2C bus"))
+		return
+	}
+	chain, err := pca9685.NewChain(machine.I2C0, 0, 1)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to chain the PCA9685 boards:"), err, true)
+		return
+	}
+
+	// Create the joints of every leg and group them by tripod
+	var moves [tripodsCount]*group.PoseMove
+	for tripod, legs := range tripods {
+		servos := group.NewGroup()
+		for _, leg := range legs {
+			for joint := uint8(0); joint < jointsCount; joint++ {
+				servo, err := newJoint(chain, leg, joint)
+				if err != tinygoerrors.ErrorCodeNil {
+					logger.ErrorMessageWithErrorCode([]byte("Failed to create a leg joint:"), err, true)
+					return
+				}
+				if err = servos.Add(jointID(leg, joint), servo); err != tinygoerrors.ErrorCodeNil {
+					logger.ErrorMessageWithErrorCode([]byte("Failed to add a leg joint:"), err, true)
+					return
+				}
+			}
+		}
+		moves[tripod], err = group.NewPoseMove(servos)
+		if err != tinygoerrors.ErrorCodeNil {
+			logger.ErrorMessageWithErrorCode([]byte("Failed to create a tripod move:"), err, true)
+			return
+		}
+	}
+
+	// Walk, starting every phase once both tripods have reached the previous one
+	start := time.Now()
+	swinging := 0
+	phase := 0
+	for {
+		nowMs := uint32(time.Since(start).Milliseconds())
+		for _, move := range moves {
+			if err = move.Update(nowMs); err != tinygoerrors.ErrorCodeNil {
+				logger.WarningMessageWithErrorCode([]byte("Failed to move a tripod:"), err, true)
+			}
+		}
+
+		if !moves[0].IsMoving() && !moves[1].IsMoving() {
+			pushing := 1 - swinging
+			err = startPhase(moves[swinging], tripods[swinging], gait[phase].swing, nowMs)
+			if err == tinygoerrors.ErrorCodeNil {
+				err = startPhase(moves[pushing], tripods[pushing], gait[phase].push, nowMs)
+			}
+			if err != tinygoerrors.ErrorCodeNil {
+				logger.ErrorMessageWithErrorCode([]byte("Failed to start a gait phase:"), err, true)
+				return
+			}
+
+			// Swap the tripods once a step is done
+			phase++
+			if phase == len(gait) {
+				phase = 0
+				swinging = pushing
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
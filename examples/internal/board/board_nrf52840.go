@@ -0,0 +1,17 @@
+//go:build nrf52840
+
+package board
+
+import (
+	"machine"
+)
+
+var (
+	// Servos are the servo slots of the board, each one on its own PWM peripheral so they can use different periods
+	Servos = [4]Servo{
+		{PWM: machine.PWM0, Pin: machine.P0_02},
+		{PWM: machine.PWM1, Pin: machine.P0_03},
+		{PWM: machine.PWM2, Pin: machine.P0_28},
+		{PWM: machine.PWM3, Pin: machine.P0_29},
+	}
+)
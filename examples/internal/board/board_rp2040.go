@@ -0,0 +1,17 @@
+//go:build rp2040
+
+package board
+
+import (
+	"machine"
+)
+
+var (
+	// Servos are the servo slots of the board, each one on its own PWM slice so they can use different periods
+	Servos = [4]Servo{
+		{PWM: machine.PWM0, Pin: machine.GPIO0},
+		{PWM: machine.PWM1, Pin: machine.GPIO2},
+		{PWM: machine.PWM2, Pin: machine.GPIO4},
+		{PWM: machine.PWM3, Pin: machine.GPIO6},
+	}
+)
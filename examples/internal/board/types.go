@@ -0,0 +1,17 @@
+//go:build rp2040 || nrf52840
+
+package board
+
+import (
+	"machine"
+
+	tinygopwm "github.com/ralvarezdev/tinygo-pwm"
+)
+
+type (
+	// Servo is the PWM peripheral and signal pin used by a servo slot of the board
+	Servo struct {
+		PWM tinygopwm.PWM
+		Pin machine.Pin
+	}
+)
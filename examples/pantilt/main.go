@@ -0,0 +1,206 @@
+//go:build rp2040 || nrf52840
+
+// Pantilt points a pan-tilt camera mount at a target. The vision code, e.g. on a camera module or a host computer,
+// writes a line to the serial port for every processed frame:
+//
+//	<dx> <dy>  the distance in pixels from the image center to the target, e.g. -12 30
+//	-          no target in the frame
+//
+// The mount scans its field of view row by row until a target is seen, then a tracker keeps it centered. Once the
+// target has been lost for a while, the scan starts over.
+package main
+
+import (
+	"machine"
+	"time"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/examples/internal/board"
+	"github.com/ralvarezdev/tinygo-servo/v2/pantilt"
+)
+
+const (
+	// lostTimeoutMs is the time without a target after which the scan starts over
+	lostTimeoutMs = 1000
+)
+
+// parseInt parses a signed decimal number
+//
+// Parameters:
+//
+// data: The ASCII digits, optionally preceded by a minus sign
+//
+// Returns:
+//
+// The parsed number and false if the input is not a valid number
+func parseInt(data []byte) (int16, bool) {
+	if len(data) == 0 {
+		return 0, false
+	}
+
+	// Check for the sign
+	negative := data[0] == '-'
+	if negative {
+		data = data[1:]
+		if len(data) == 0 {
+			return 0, false
+		}
+	}
+
+	// Parse the digits
+	var value int32
+	for _, digit := range data {
+		if digit < '0' || digit > '9' {
+			return 0, false
+		}
+		value = value*10 + int32(digit-'0')
+		if value > 4096 {
+			return 0, false
+		}
+	}
+	if negative {
+		value = -value
+	}
+	return int16(value), true
+}
+
+// parseFrame parses the line written for a frame
+//
+// Parameters:
+//
+// line: The line without the line terminator
+//
+// Returns:
+//
+// The horizontal and vertical distances to the target and false if there is no target or the line is invalid
+func parseFrame(line []byte) (int16, int16, bool) {
+	for index, character := range line {
+		if character != ' ' {
+			continue
+		}
+		dx, ok := parseInt(line[:index])
+		if !ok {
+			return 0, 0, false
+		}
+		dy, ok := parseInt(line[index+1:])
+		return dx, dy, ok
+	}
+	return 0, 0, false
+}
+
+func main() {
+	logger := tinygologger.NewDefaultLogger(256)
+
+	// Create the pan servo, covering the full 180 degrees
+	panSlot := board.Servos[0]
+	pan, err := tinygoservo.NewHandler(panSlot.PWM, panSlot.Pin)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the pan servo:"), err, true)
+		return
+	}
+
+	// Create the tilt servo, mounted upside down and limited to avoid hitting the base
+	tiltSlot := board.Servos[1]
+	tilt, err := tinygoservo.NewHandler(
+		tiltSlot.PWM,
+		tiltSlot.Pin,
+		tinygoservo.WithLimits(45, 60),
+		tinygoservo.WithInvertedDirection(),
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the tilt servo:"), err, true)
+		return
+	}
+
+	// Create the head and the tracker, turning 50 millidegrees per pixel of error at up to 120 degrees per second
+	head, err := pantilt.NewPanTilt(pan, tilt)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the pan-tilt head:"), err, true)
+		return
+	}
+	tracker, err := pantilt.NewTracker(head, 50, 50)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the tracker:"), err, true)
+		return
+	}
+	tracker.SetDeadzone(4)
+	tracker.SetMaxSpeed(120)
+
+	// Scan in 15 degree steps, about the field of view of the camera, until a target is seen
+	isTargetSeen := false
+	search, err := pantilt.NewSearch(
+		head,
+		pantilt.SearchPatternLawnmower,
+		15,
+		90,
+		100,
+		func() bool { return isTargetSeen },
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the search:"), err, true)
+		return
+	}
+	search.SetTracker(tracker)
+	if err = search.Start(); err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to start the search:"), err, true)
+		return
+	}
+
+	// Read the frames from the serial port, scanning or tracking the target
+	start := time.Now()
+	var lastSeenMs uint32
+	var line [16]byte
+	length := 0
+	overflow := false
+	for {
+		nowMs := uint32(time.Since(start).Milliseconds())
+		if err = search.Update(nowMs); err != tinygoerrors.ErrorCodeNil {
+			logger.WarningMessageWithErrorCode([]byte("Failed to scan:"), err, true)
+		}
+
+		// Start the scan over once the target is lost
+		if search.IsFound() && nowMs-lastSeenMs >= lostTimeoutMs {
+			isTargetSeen = false
+			if err = search.Start(); err != tinygoerrors.ErrorCodeNil {
+				logger.WarningMessageWithErrorCode([]byte("Failed to start the search:"), err, true)
+			}
+		}
+
+		if machine.Serial.Buffered() == 0 {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		data, readErr := machine.Serial.ReadByte()
+		if readErr != nil {
+			continue
+		}
+		if data != '\r' && data != '\n' {
+			if length < len(line) {
+				line[length] = data
+				length++
+			} else {
+				overflow = true
+			}
+			continue
+		}
+
+		// Track the target once the search has found it, ignoring the lines longer than the buffer
+		dx, dy, ok := parseFrame(line[:length])
+		ok = ok && !overflow
+		length = 0
+		overflow = false
+		if !ok {
+			continue
+		}
+		isTargetSeen = true
+		lastSeenMs = nowMs
+		if !search.IsFound() {
+			continue
+		}
+		if err = tracker.Track(dx, dy, nowMs); err != tinygoerrors.ErrorCodeNil {
+			logger.WarningMessageWithErrorCode([]byte("Failed to track the target:"), err, true)
+		}
+	}
+}
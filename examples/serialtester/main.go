@@ -0,0 +1,214 @@
+//go:build rp2040 || nrf52840
+
+// Serialtester lets a servo be tested from any serial terminal with single-line commands:
+//
+//	a<angle>  sets the absolute angle, e.g. a120
+//	r<angle>  sets the angle relative to the center, e.g. r-30
+//	c         centers the servo
+//	?         prints the current angle
+//
+// The servo pulse range can be calibrated from the terminal too, with no buttons or display on the device. The k
+// command starts a tinygoservo.Calibrator, which takes the command lines until the calibration is done:
+//
+//	save  marks the pulse width being looked for, walking from the min pulse width to the center and the max one
+//	[     marks the current pulse width as the min pulse width
+//	]     marks the current pulse width as the max pulse width
+//	w     finishes with the marked min and max pulse widths
+//	+     steps the pulse width up, "+++" steps it up three times
+//	-     steps the pulse width down, "---" steps it down three times
+//
+// The calibrated pulse widths are then printed in nanoseconds, to be passed to the servo constructor.
+package main
+
+import (
+	"machine"
+	"time"
+
+	tinygobuffers "github.com/ralvarezdev/tinygo-buffers"
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/examples/internal/board"
+)
+
+var (
+	// servoConfig is the configuration of the servo under test, allowing the full range
+	servoConfig = tinygoservo.Config{
+		Frequency:      50,
+		MinPulseWidth:  500000,
+		MaxPulseWidth:  2500000,
+		ActuationRange: 180,
+		CenterAngle:    90,
+		MaxLeftAngle:   90,
+		MaxRightAngle:  90,
+	}
+
+	// okResponse is the response sent when a command succeeds
+	okResponse = []byte("OK\r\n")
+
+	// errorResponse is the response sent when a command fails
+	errorResponse = []byte("ERR\r\n")
+
+	// newlineResponse terminates a response
+	newlineResponse = []byte("\r\n")
+)
+
+// parseInt parses a signed decimal number
+//
+// Parameters:
+//
+// data: The ASCII digits, optionally preceded by a minus sign
+//
+// Returns:
+//
+// The parsed number and false if the input is not a valid number
+func parseInt(data []byte) (int16, bool) {
+	if len(data) == 0 {
+		return 0, false
+	}
+
+	// Check for the sign
+	negative := data[0] == '-'
+	if negative {
+		data = data[1:]
+		if len(data) == 0 {
+			return 0, false
+		}
+	}
+
+	// Parse the digits
+	var value int32
+	for _, digit := range data {
+		if digit < '0' || digit > '9' {
+			return 0, false
+		}
+		value = value*10 + int32(digit-'0')
+		if value > 360 {
+			return 0, false
+		}
+	}
+	if negative {
+		value = -value
+	}
+	return int16(value), true
+}
+
+// handleCommand executes a command line and writes its response to the serial port. While a calibration is in
+// progress, the lines are passed to the calibrator instead
+//
+// Parameters:
+//
+// servo: The servo under test
+// calibrator: The calibrator of the servo
+// isCalibrating: Whether a calibration is in progress, cleared once it is done
+// response: The buffer the calibrator responses are built in
+// line: The command line without the line terminator
+func handleCommand(
+	servo tinygoservo.Handler,
+	calibrator *tinygoservo.Calibrator,
+	isCalibrating *bool,
+	response []byte,
+	line []byte,
+) {
+	if *isCalibrating {
+		response, _ = calibrator.HandleCommand(line, response[:0])
+		machine.Serial.Write(response)
+		*isCalibrating = calibrator.Phase() != tinygoservo.CalibratorPhaseDone
+		return
+	}
+	if len(line) == 0 {
+		return
+	}
+
+	err := tinygoerrors.ErrorCodeNil
+	switch line[0] {
+	case 'a':
+		angle, ok := parseInt(line[1:])
+		if !ok || angle < 0 {
+			machine.Serial.Write(errorResponse)
+			return
+		}
+		err = servo.SetAngle(uint16(angle))
+	case 'r':
+		angle, ok := parseInt(line[1:])
+		if !ok {
+			machine.Serial.Write(errorResponse)
+			return
+		}
+		err = servo.SetAngleRelativeToCenter(angle)
+	case 'c':
+		err = servo.SetAngleToCenter()
+	case '?':
+		machine.Serial.Write(tinygobuffers.UintToDecimal(uint64(servo.GetAngle())))
+		machine.Serial.Write(newlineResponse)
+		return
+	case 'k':
+		response, err = calibrator.Start(response[:0])
+		machine.Serial.Write(response)
+		*isCalibrating = err == tinygoerrors.ErrorCodeNil
+		return
+	default:
+		machine.Serial.Write(errorResponse)
+		return
+	}
+
+	if err != tinygoerrors.ErrorCodeNil {
+		machine.Serial.Write(errorResponse)
+		return
+	}
+	machine.Serial.Write(okResponse)
+}
+
+func main() {
+	logger := tinygologger.NewDefaultLogger(256)
+
+	// Create the servo under test
+	slot := board.Servos[0]
+	servo, err := tinygoservo.NewFromConfig(slot.PWM, slot.Pin, servoConfig, tinygoservo.WithLogger(logger))
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the servo under test:"), err, true)
+		return
+	}
+	calibrator, err := tinygoservo.NewCalibrator(servo, servoConfig, 0)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the calibrator:"), err, true)
+		return
+	}
+
+	// Read command lines from the serial port
+	var response [48]byte
+	isCalibrating := false
+	var line [16]byte
+	length := 0
+	overflow := false
+	for {
+		if machine.Serial.Buffered() == 0 {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+
+		data, readErr := machine.Serial.ReadByte()
+		if readErr != nil {
+			continue
+		}
+
+		switch data {
+		case '\r', '\n':
+			// Reject lines longer than the buffer instead of executing them truncated
+			if overflow {
+				machine.Serial.Write(errorResponse)
+			} else {
+				handleCommand(servo, calibrator, &isCalibrating, response[:], line[:length])
+			}
+			length = 0
+			overflow = false
+		default:
+			if length < len(line) {
+				line[length] = data
+				length++
+			} else {
+				overflow = true
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+//go:build rp2040 || nrf52840
+
+// Steering drives the steering servo of a small car, turning left and right around the center before straightening up again.
+package main
+
+import (
+	"time"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/examples/internal/board"
+)
+
+func main() {
+	logger := tinygologger.NewDefaultLogger(256)
+
+	// Create the steering servo, limited to 30 degrees on each side of the center
+	slot := board.Servos[0]
+	steering, err := tinygoservo.NewHandler(
+		slot.PWM,
+		slot.Pin,
+		tinygoservo.WithLimits(30, 30),
+		tinygoservo.WithLogger(logger),
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		logger.ErrorMessageWithErrorCode([]byte("Failed to create the steering servo:"), err, true)
+		return
+	}
+
+	for {
+		// Turn progressively to the left and to the right
+		for angle := uint16(0); angle <= 30; angle += 5 {
+			_ = steering.SetAngleToLeft(angle)
+			time.Sleep(100 * time.Millisecond)
+		}
+		for angle := uint16(0); angle <= 30; angle += 5 {
+			_ = steering.SetAngleToRight(angle)
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		// Straighten up the wheels
+		_ = steering.SetAngleToCenter()
+		time.Sleep(time.Second)
+	}
+}
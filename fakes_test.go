@@ -0,0 +1,38 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+type (
+	// fakePWM is a PWM peripheral recording the duty cycles of its channels, with one channel per pin
+	fakePWM struct {
+		period uint64
+		top    uint32
+		duties map[uint8]uint32
+	}
+)
+
+// newFakePWM creates a new instance of fakePWM with a 16-bit counter
+func newFakePWM() *fakePWM {
+	return &fakePWM{top: 0xffff, duties: make(map[uint8]uint32)}
+}
+
+// Configure records the period of the peripheral
+func (p *fakePWM) Configure(config PWMConfig) error {
+	p.period = config.Period
+	return nil
+}
+
+// Channel returns the pin as the channel
+func (p *fakePWM) Channel(pin Pin) (uint8, error) {
+	return uint8(pin), nil
+}
+
+// Top returns the value of a full duty cycle
+func (p *fakePWM) Top() uint32 {
+	return p.top
+}
+
+// Set records the duty cycle of a channel
+func (p *fakePWM) Set(channel uint8, value uint32) {
+	p.duties[channel] = value
+}
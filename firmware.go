@@ -0,0 +1,111 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// parkMessage is the message logged when the servo is parked for a firmware update
+	parkMessage = []byte("Servo parked for firmware update")
+
+	// prepareFirmwareUpdateHook and resumeFirmwareUpdateHook are the functions set by SetFirmwareUpdateHooks
+	prepareFirmwareUpdateHook func()
+	resumeFirmwareUpdateHook  func()
+)
+
+// SetFirmwareUpdateHooks sets the functions that stop and restart the failsafes around a firmware update, which would
+// otherwise fire while the bootloader runs: the heartbeat of an external watchdog, see Heartbeat.Stop, and the
+// watchdogs of the backends whose outputs stop unless they are kept alive, since Update doesn't refresh the parked
+// handlers
+//
+// Parameters:
+//
+// prepare: The function called by PrepareForFirmwareUpdate once every servo is parked, or nil
+// resume: The function called by ResumeFromFirmwareUpdate before the servos are resumed, or nil
+func SetFirmwareUpdateHooks(prepare func(), resume func()) {
+	prepareFirmwareUpdateHook = prepare
+	resumeFirmwareUpdateHook = resume
+}
+
+// SetParkAngle sets the angle the servo motor holds while the firmware is updated, instead of being detached
+//
+// Parameters:
+//
+// angle: The absolute park angle, it must be within the limits
+//
+// Returns:
+//
+// An error if the angle is outside the limits
+func (h *DefaultHandler) SetParkAngle(angle uint16) tinygoerrors.ErrorCode {
+	if angle < h.LeftLimit() || angle > h.RightLimit() {
+		return ErrorCodeServoAngleOutOfRange
+	}
+	h.parkAngle = angle
+	h.hasParkAngle = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ClearParkAngle makes the servo motor detach while the firmware is updated
+func (h *DefaultHandler) ClearParkAngle() {
+	h.hasParkAngle = false
+}
+
+// park holds the servo motor at its park angle, or detaches it if it has none, and freezes its time-based behaviors
+func (h *DefaultHandler) park() {
+	if !h.hasParkAngle {
+		h.PrepareForSleep()
+		return
+	}
+
+	// Hold the park pulse, the PWM peripheral keeps generating it while the bootloader runs. The detached, suspended
+	// or warming up servos only store it, like any other angle
+	if h.isInitialized && !h.isPreparedForSleep && h.IsMovementEnabled() {
+		h.angle = h.parkAngle
+		h.angleFraction = 0
+		h.pulse = h.calculatePulse(h.parkAngle)
+		h.StopMove()
+		if h.canWritePulse() {
+			h.writePulse(h.pulse)
+		}
+	}
+	h.isPreparedForSleep = true
+
+	// Log the park if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(parkMessage)
+	}
+}
+
+// PrepareForFirmwareUpdate parks or detaches every initialized handler before jumping to the bootloader, so the
+// mechanisms don't fight their mounts during the update, whatever their output. The handlers with a park angle hold
+// it and the others stop their pulses. The time-based behaviors driven by Update, like the refresh bursts, the keep
+// alive writes, the damped pointing and the take over blends, are frozen until ResumeFromFirmwareUpdate is called,
+// e.g. if the update is aborted. The prepare hook set by SetFirmwareUpdateHooks is called last
+func PrepareForFirmwareUpdate() {
+	for handler := firstRegisteredHandler; handler != nil; handler = handler.nextRegistered {
+		handler.park()
+	}
+	if prepareFirmwareUpdateHook != nil {
+		prepareFirmwareUpdateHook()
+	}
+}
+
+// ResumeFromFirmwareUpdate resumes every initialized handler parked by PrepareForFirmwareUpdate, see
+// DefaultHandler.ResumeFromSleep, once the resume hook set by SetFirmwareUpdateHooks restarted the failsafes
+//
+// Returns:
+//
+// The first error of the handlers that could not be resumed, the others are resumed anyway
+func ResumeFromFirmwareUpdate() tinygoerrors.ErrorCode {
+	if resumeFirmwareUpdateHook != nil {
+		resumeFirmwareUpdateHook()
+	}
+	firstErr := tinygoerrors.ErrorCodeNil
+	for handler := firstRegisteredHandler; handler != nil; handler = handler.nextRegistered {
+		if err := handler.ResumeFromSleep(); err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,100 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestFirmwareUpdateParksEveryHandler checks the handlers writing through a custom output are parked or detached
+// too, that the failsafe hooks are called around the update and that the handlers resume afterwards
+func TestFirmwareUpdateParksEveryHandler(t *testing.T) {
+	parkedOutput := servotest.NewOutput()
+	parked, err := NewOutputHandler(parkedOutput)
+	if err != 0 {
+		t.Fatalf("parked handler: %d", err)
+	}
+	if err = parked.SetParkAngle(30); err != 0 {
+		t.Fatalf("SetParkAngle: %d", err)
+	}
+	detachedOutput := servotest.NewOutput()
+	detached, err := NewOutputHandler(detachedOutput)
+	if err != 0 {
+		t.Fatalf("detached handler: %d", err)
+	}
+	releaseAll(t, parked, detached)
+
+	var prepared, resumed int
+	SetFirmwareUpdateHooks(func() { prepared++ }, func() { resumed++ })
+	t.Cleanup(func() { SetFirmwareUpdateHooks(nil, nil) })
+
+	PrepareForFirmwareUpdate()
+	if prepared != 1 || resumed != 0 {
+		t.Fatalf("hooks after prepare: prepared %d, resumed %d", prepared, resumed)
+	}
+	if pulse, _ := parkedOutput.LastPulse(); Nanoseconds(pulse) != parked.calculatePulse(30) {
+		t.Fatalf("parked pulse: %d, expected %d", pulse, parked.calculatePulse(30))
+	}
+	if pulse, _ := detachedOutput.LastPulse(); pulse != 0 {
+		t.Fatalf("detached pulse: %d, expected 0", pulse)
+	}
+
+	// Update must not refresh the parked handlers while the bootloader runs
+	parkedOutput.ClearPulses()
+	parked.SetKeepAliveInterval(20)
+	for nowMs := uint32(0); nowMs < 1000; nowMs += 10 {
+		parked.Update(nowMs)
+	}
+	if pulses := parkedOutput.Pulses(); len(pulses) != 0 {
+		t.Fatalf("%d pulses written by Update while parked", len(pulses))
+	}
+
+	if err = ResumeFromFirmwareUpdate(); err != 0 {
+		t.Fatalf("ResumeFromFirmwareUpdate: %d", err)
+	}
+	if resumed != 1 {
+		t.Fatalf("resume hook called %d times", resumed)
+	}
+	if pulse, _ := detachedOutput.LastPulse(); Nanoseconds(pulse) != detached.calculatePulse(detached.GetAngle()) {
+		t.Fatalf("detached pulse after resume: %d", pulse)
+	}
+}
+
+// TestFirmwareUpdateKeepsDetachedHandlerLimp checks parking a detached handler or one with its output suspended
+// doesn't write its park pulse, neither while the bootloader runs nor once resumed
+func TestFirmwareUpdateKeepsDetachedHandlerLimp(t *testing.T) {
+	detachedOutput := servotest.NewOutput()
+	detached, err := NewOutputHandler(detachedOutput)
+	if err != 0 {
+		t.Fatalf("detached handler: %d", err)
+	}
+	suspendedOutput := servotest.NewOutput()
+	suspended, err := NewOutputHandler(suspendedOutput)
+	if err != 0 {
+		t.Fatalf("suspended handler: %d", err)
+	}
+	releaseAll(t, detached, suspended)
+	for _, handler := range []*DefaultHandler{detached, suspended} {
+		if err = handler.SetParkAngle(30); err != 0 {
+			t.Fatalf("SetParkAngle: %d", err)
+		}
+	}
+	detached.Detach()
+	suspended.SuspendOutput()
+	detachedOutput.ClearPulses()
+	suspendedOutput.ClearPulses()
+
+	PrepareForFirmwareUpdate()
+	if err = ResumeFromFirmwareUpdate(); err != 0 {
+		t.Fatalf("ResumeFromFirmwareUpdate: %d", err)
+	}
+	for _, output := range []*servotest.Output{detachedOutput, suspendedOutput} {
+		for _, pulse := range output.Pulses() {
+			if pulse.PulseWidth != 0 {
+				t.Fatalf("pulse of %dns written to a limp servo", pulse.PulseWidth)
+			}
+		}
+	}
+}
@@ -1,11 +1,10 @@
-module github.com/ralvarezdev/tinygo-servo
+module github.com/ralvarezdev/tinygo-servo/v2
 
 go 1.25.0
 
 require (
+	github.com/ralvarezdev/tinygo-buffers v0.1.3
 	github.com/ralvarezdev/tinygo-errors v0.0.4
 	github.com/ralvarezdev/tinygo-logger v0.0.9
 	github.com/ralvarezdev/tinygo-pwm v0.1.1
 )
-
-require github.com/ralvarezdev/tinygo-buffers v0.1.3 // indirect
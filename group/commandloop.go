@@ -0,0 +1,150 @@
+//go:build !scheduler.none
+
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Command is a typed command run by a CommandLoop
+	Command struct {
+		Kind              CommandKind
+		ID                uint8
+		AngleMilliDegrees uint32
+		Snapshot          *Snapshot
+		NowMs             uint32
+
+		// Done receives the result of the command if it is not nil, it must be buffered or read by the sender
+		Done chan tinygoerrors.ErrorCode
+	}
+
+	// CommandLoop runs the commands of a Group on its own goroutine, fed by a buffered channel, giving applications a
+	// message-passing concurrency model instead of manual locking. Once started, the group must only be accessed
+	// through the loop, the servos included: they are updated by sending a CommandKindUpdate command periodically,
+	// e.g. from a ticker, instead of calling their Update from the main loop
+	CommandLoop struct {
+		group    *Group
+		commands chan Command
+		errFunc  func(command Command, err tinygoerrors.ErrorCode)
+	}
+)
+
+// NewCommandLoop creates a new instance of CommandLoop
+//
+// Parameters:
+//
+// group: The group the commands are run on
+// capacity: The number of commands buffered before the senders block
+//
+// Returns:
+//
+// An instance of CommandLoop and an error if the group is nil
+func NewCommandLoop(group *Group, capacity int) (*CommandLoop, tinygoerrors.ErrorCode) {
+	if group == nil {
+		return nil, ErrorCodeGroupNilGroup
+	}
+	return &CommandLoop{
+		group:    group,
+		commands: make(chan Command, capacity),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetErrorHandler sets the function called when a command without a Done channel fails
+//
+// Parameters:
+//
+// errFunc: The function receiving the failed command and its error, or nil
+func (l *CommandLoop) SetErrorHandler(errFunc func(command Command, err tinygoerrors.ErrorCode)) {
+	l.errFunc = errFunc
+}
+
+// Commands returns the channel the commands are sent to
+//
+// Returns:
+//
+// The command channel
+func (l *CommandLoop) Commands() chan<- Command {
+	return l.commands
+}
+
+// TrySend sends a command without blocking
+//
+// Parameters:
+//
+// command: The command to send
+//
+// Returns:
+//
+// True if the command was queued, false if the buffer is full
+func (l *CommandLoop) TrySend(command Command) bool {
+	select {
+	case l.commands <- command:
+		return true
+	default:
+		return false
+	}
+}
+
+// Start runs the loop on a new goroutine
+func (l *CommandLoop) Start() {
+	go l.Run()
+}
+
+// Stop closes the command channel, the loop returns once the buffered commands are run. No command can be sent
+// afterwards
+func (l *CommandLoop) Stop() {
+	close(l.commands)
+}
+
+// Run runs the commands until the loop is stopped, it blocks so it is usually called through Start
+func (l *CommandLoop) Run() {
+	for command := range l.commands {
+		err := l.run(&command)
+		if command.Done != nil {
+			command.Done <- err
+		} else if err != tinygoerrors.ErrorCodeNil && l.errFunc != nil {
+			l.errFunc(command, err)
+		}
+	}
+}
+
+// run runs a command on the group
+//
+// Parameters:
+//
+// command: The command to run
+//
+// Returns:
+//
+// The error of the command, if any
+func (l *CommandLoop) run(command *Command) tinygoerrors.ErrorCode {
+	switch command.Kind {
+	case CommandKindSetAngle:
+		servo, err := l.group.Servo(command.ID)
+		if err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		return servo.SetAngleMilliDegrees(command.AngleMilliDegrees)
+	case CommandKindSetAngleAll:
+		firstErr := tinygoerrors.ErrorCodeNil
+		for index := 0; index < l.group.count; index++ {
+			err := l.group.servos[index].SetAngleMilliDegrees(command.AngleMilliDegrees)
+			if err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	case CommandKindSnapshot:
+		if command.Snapshot == nil {
+			return ErrorCodeGroupNilSnapshot
+		}
+		l.group.Snapshot(command.Snapshot, command.NowMs)
+		return tinygoerrors.ErrorCodeNil
+	case CommandKindUpdate:
+		l.group.Update(command.NowMs)
+		return tinygoerrors.ErrorCodeNil
+	default:
+		return ErrorCodeGroupUnknownCommand
+	}
+}
@@ -0,0 +1,20 @@
+package group
+
+const (
+	// MaxServos is the number of servos a Group can hold
+	MaxServos = 16
+
+	// maxTelemetryLineSize is the size of the buffer a Publisher serializes a snapshot into: the header, and for every
+	// servo its ID, angles and flags
+	maxTelemetryLineSize = 16 + MaxServos*31
+
+	// microRadiansPerMilliDegreeScaled is the number of microradians in a millidegree, pi / 180 * 1000, scaled by
+	// microRadiansScale
+	microRadiansPerMilliDegreeScaled = 17453293
+
+	// microRadiansScale is the fixed-point scale of microRadiansPerMilliDegreeScaled
+	microRadiansScale = 1000000
+
+	// radiansPerMilliDegree is the number of radians in a millidegree
+	radiansPerMilliDegree = 3.14159265358979 / 180000
+)
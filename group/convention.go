@@ -0,0 +1,117 @@
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Convention maps the angles of a servo to the joint angle conventions of robotics tools like ROS and URDF
+	// viewers: zero at a reference pose, usually horizontal, positive counterclockwise and in radians, wrapped to
+	// (-pi, pi]
+	Convention struct {
+		// ZeroMilliDegrees is the servo angle in millidegrees at which the joint is at zero
+		ZeroMilliDegrees uint32
+
+		// IsClockwise is whether increasing servo angles turn the joint clockwise, seen with the joint axis pointing
+		// towards the viewer
+		IsClockwise bool
+	}
+)
+
+// difference returns the joint angle of a servo angle in millidegrees, wrapped to (-180000, 180000]
+//
+// Parameters:
+//
+// angleMilliDegrees: The servo angle in millidegrees
+//
+// Returns:
+//
+// The joint angle in millidegrees, positive counterclockwise
+func (c Convention) difference(angleMilliDegrees uint32) int64 {
+	difference := int64(angleMilliDegrees) - int64(c.ZeroMilliDegrees)
+	if c.IsClockwise {
+		difference = -difference
+	}
+	for difference > 180000 {
+		difference -= 360000
+	}
+	for difference <= -180000 {
+		difference += 360000
+	}
+	return difference
+}
+
+// ToMicroRadians converts a servo angle to the joint angle of the convention with integer math
+//
+// Parameters:
+//
+// angleMilliDegrees: The servo angle in millidegrees
+//
+// Returns:
+//
+// The joint angle in microradians, rounded to the nearest one
+func (c Convention) ToMicroRadians(angleMilliDegrees uint32) int32 {
+	scaled := c.difference(angleMilliDegrees) * microRadiansPerMilliDegreeScaled
+	if scaled < 0 {
+		return int32(-((-scaled + microRadiansScale/2) / microRadiansScale))
+	}
+	return int32((scaled + microRadiansScale/2) / microRadiansScale)
+}
+
+// ToRadians converts a servo angle to the joint angle of the convention
+//
+// Parameters:
+//
+// angleMilliDegrees: The servo angle in millidegrees
+//
+// Returns:
+//
+// The joint angle in radians
+func (c Convention) ToRadians(angleMilliDegrees uint32) float32 {
+	return float32(c.difference(angleMilliDegrees)) * radiansPerMilliDegree
+}
+
+// SetConvention sets the convention the angle of a servo is published with in radians. Servos without a convention
+// are published with zero at their 0 degrees angle, positive counterclockwise
+//
+// Parameters:
+//
+// id: The ID of the servo
+// convention: The convention of its joint
+//
+// Returns:
+//
+// An error if the conventions of every servo a Group holds are already set
+func (p *Publisher) SetConvention(id uint8, convention Convention) tinygoerrors.ErrorCode {
+	for index := 0; index < p.conventionsCount; index++ {
+		if p.conventionIDs[index] == id {
+			p.conventions[index] = convention
+			return tinygoerrors.ErrorCodeNil
+		}
+	}
+	if p.conventionsCount == MaxServos {
+		return ErrorCodeGroupFull
+	}
+	p.conventionIDs[p.conventionsCount] = id
+	p.conventions[p.conventionsCount] = convention
+	p.conventionsCount++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// convention returns the convention of a servo
+//
+// Parameters:
+//
+// id: The ID of the servo
+//
+// Returns:
+//
+// The convention set for the servo, or the default one
+func (p *Publisher) convention(id uint8) Convention {
+	for index := 0; index < p.conventionsCount; index++ {
+		if p.conventionIDs[index] == id {
+			return p.conventions[index]
+		}
+	}
+	return Convention{}
+}
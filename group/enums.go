@@ -0,0 +1,40 @@
+package group
+
+type (
+	// TelemetryField is a bit mask to represent the fields included in every servo of the telemetry.
+	TelemetryField uint8
+
+	// CommandKind is an enum to represent the commands run by a CommandLoop.
+	CommandKind uint8
+)
+
+const (
+	// TelemetryFieldAngle includes the angle in millidegrees
+	TelemetryFieldAngle TelemetryField = 1 << iota
+
+	// TelemetryFieldEnabled includes whether the movement is enabled
+	TelemetryFieldEnabled
+
+	// TelemetryFieldSleeping includes whether the servo is sleeping
+	TelemetryFieldSleeping
+
+	// TelemetryFieldRadians includes the joint angle in radians with the convention set for the servo
+	TelemetryFieldRadians
+
+	// TelemetryFieldAll includes every field but the radians, so the lines keep the format parsers already expect
+	TelemetryFieldAll = TelemetryFieldAngle | TelemetryFieldEnabled | TelemetryFieldSleeping
+)
+
+const (
+	// CommandKindSetAngle sets the angle of the servo with the command ID
+	CommandKindSetAngle CommandKind = iota
+
+	// CommandKindSetAngleAll sets the angle of every servo of the group
+	CommandKindSetAngleAll
+
+	// CommandKindSnapshot captures the state of the group into the command snapshot
+	CommandKindSnapshot
+
+	// CommandKindUpdate updates every servo of the group with the command time, see Group.Update
+	CommandKindUpdate
+)
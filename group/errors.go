@@ -0,0 +1,27 @@
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeGroupStartNumber is the starting number for group-related error codes.
+	ErrorCodeGroupStartNumber uint16 = 5520
+)
+
+const (
+	ErrorCodeGroupNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeGroupStartNumber)
+	ErrorCodeGroupFull
+	ErrorCodeGroupDuplicatedID
+	ErrorCodeGroupUnknownID
+	ErrorCodeGroupNilGroup
+	ErrorCodeGroupNilTransport
+	ErrorCodeGroupZeroInterval
+	ErrorCodeGroupTransportFailed
+	ErrorCodeGroupUnknownCommand
+	ErrorCodeGroupNilSnapshot
+	ErrorCodeGroupPoseTooLarge
+	ErrorCodeGroupUnknownEasing
+	ErrorCodeGroupAngleOutOfRange
+	ErrorCodeGroupUntimedServo
+)
@@ -0,0 +1,36 @@
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the groups, kept narrow so the groups
+	// also take the servotest fakes and the handlers of other drivers
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		GetAngleMilliDegrees() uint32
+		LeftLimit() uint16
+		RightLimit() uint16
+		IsMovementEnabled() bool
+		IsSleeping() bool
+	}
+
+	// TimedServo is implemented by the servos that interpolate timed moves from their own updates, like the
+	// tinygo-servo DefaultHandler
+	TimedServo interface {
+		MoveToMilliDegrees(milliDegrees uint32, durationMs uint32) tinygoerrors.ErrorCode
+	}
+
+	// UpdatedServo is implemented by the servos that advance their speed caps, timed moves and warm-ups from their
+	// own updates, like the tinygo-servo DefaultHandler
+	UpdatedServo interface {
+		Update(nowMs uint32)
+	}
+
+	// Transport is the link telemetry is pushed to. It is satisfied by the TinyGo UART and USB CDC serial ports, and
+	// TransportFunc adapts any other callback, like an RF module
+	Transport interface {
+		Write(data []byte) (int, error)
+	}
+)
@@ -0,0 +1,201 @@
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+)
+
+type (
+	// PoseTarget is the target of a servo within a pose move. MaxSpeed overrides the arrival of the servo with its own
+	// speed in degrees per second, e.g. for a gripper that snaps while the arm glides, 0 makes the servo arrive at the
+	// end of the move
+	PoseTarget struct {
+		ID                uint8
+		AngleMilliDegrees uint32
+		Easing            tinygoservo.Easing
+		MaxSpeed          uint16
+	}
+
+	// poseTrack is the interpolation of a servo within a pose move
+	poseTrack struct {
+		servo      Servo
+		from       uint32
+		to         uint32
+		durationMs uint32
+		easing     tinygoservo.Easing
+	}
+
+	// PoseMove interpolates the servos of a Group between poses, it must be updated periodically from the main loop.
+	// Every servo follows its own easing and, optionally, its own speed, while the servos without a speed override
+	// arrive together at the end of the move
+	PoseMove struct {
+		group      *Group
+		tracks     [MaxServos]poseTrack
+		count      int
+		startMs    uint32
+		durationMs uint32
+		isMoving   bool
+	}
+)
+
+// NewPoseMove creates a new instance of PoseMove
+//
+// Parameters:
+//
+// group: The group whose servos are moved
+//
+// Returns:
+//
+// An instance of PoseMove and an error if the group is nil
+func NewPoseMove(group *Group) (*PoseMove, tinygoerrors.ErrorCode) {
+	if group == nil {
+		return nil, ErrorCodeGroupNilGroup
+	}
+	return &PoseMove{group: group}, tinygoerrors.ErrorCodeNil
+}
+
+// Start starts a move from the current angles of the servos to a pose, replacing the move in progress. The servos
+// with a speed override arrive at their own pace, if one of them arrives after the duration and the move is
+// synchronized, the duration is extended so the other servos still arrive together with the last one
+//
+// Parameters:
+//
+// pose: The targets of the servos to move, the servos not in the pose hold their angle
+// durationMs: The duration of the move in milliseconds
+// synchronized: True to extend the duration to the slowest speed override
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// An error if the pose is too large, a servo ID is unknown or repeated, an easing is unknown or an angle is out of the
+// limits of its servo, in which case no servo is moved
+func (m *PoseMove) Start(
+	pose []PoseTarget,
+	durationMs uint32,
+	synchronized bool,
+	nowMs uint32,
+) tinygoerrors.ErrorCode {
+	if len(pose) > MaxServos {
+		return ErrorCodeGroupPoseTooLarge
+	}
+
+	// Check every target before replacing the move in progress
+	var tracks [MaxServos]poseTrack
+	moveDurationMs := durationMs
+	for index, target := range pose {
+		for _, previous := range pose[:index] {
+			if previous.ID == target.ID {
+				return ErrorCodeGroupDuplicatedID
+			}
+		}
+		servo, err := m.group.Servo(target.ID)
+		if err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		if !target.Easing.IsValid() {
+			return ErrorCodeGroupUnknownEasing
+		}
+		if target.AngleMilliDegrees < uint32(servo.LeftLimit())*1000 ||
+			target.AngleMilliDegrees > uint32(servo.RightLimit())*1000 {
+			return ErrorCodeGroupAngleOutOfRange
+		}
+
+		// Convert the speed override to an arrival time, millidegrees over degrees per second are milliseconds
+		track := poseTrack{
+			servo:      servo,
+			from:       servo.GetAngleMilliDegrees(),
+			to:         target.AngleMilliDegrees,
+			durationMs: durationMs,
+			easing:     target.Easing,
+		}
+		if target.MaxSpeed != 0 {
+			distance := angleDifferenceMilliDegrees(track.from, track.to)
+			track.durationMs = (distance + uint32(target.MaxSpeed) - 1) / uint32(target.MaxSpeed)
+		}
+		if track.durationMs > moveDurationMs {
+			moveDurationMs = track.durationMs
+		}
+		tracks[index] = track
+	}
+
+	// Stretch the servos without a speed override to the slowest one
+	if synchronized {
+		for index, target := range pose {
+			if target.MaxSpeed == 0 {
+				tracks[index].durationMs = moveDurationMs
+			}
+		}
+	}
+
+	m.tracks = tracks
+	m.count = len(pose)
+	m.startMs = nowMs
+	m.durationMs = moveDurationMs
+	m.isMoving = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Update moves the servos to their interpolated angles
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The first error returned by the servos, if any
+func (m *PoseMove) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if !m.isMoving {
+		return tinygoerrors.ErrorCodeNil
+	}
+	elapsedMs := nowMs - m.startMs
+
+	firstErr := tinygoerrors.ErrorCodeNil
+	for index := 0; index < m.count; index++ {
+		track := &m.tracks[index]
+
+		// Interpolate the angle, the servos that already arrived hold their target
+		angle := track.to
+		if elapsedMs < track.durationMs {
+			linear := uint32(uint64(elapsedMs) * tinygoservo.EasingScale / uint64(track.durationMs))
+			progress := tinygoservo.Ease(track.easing, linear)
+			if track.to >= track.from {
+				angle = track.from + uint32(uint64(track.to-track.from)*uint64(progress)/tinygoservo.EasingScale)
+			} else {
+				angle = track.from - uint32(uint64(track.from-track.to)*uint64(progress)/tinygoservo.EasingScale)
+			}
+		}
+		err := track.servo.SetAngleMilliDegrees(angle)
+		if err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+
+	if elapsedMs >= m.durationMs {
+		m.isMoving = false
+	}
+	return firstErr
+}
+
+// Stop stops the move in progress, the servos hold their current angles
+func (m *PoseMove) Stop() {
+	m.isMoving = false
+}
+
+// IsMoving checks if a move is in progress
+//
+// Returns:
+//
+// True if a move is in progress, false otherwise
+func (m *PoseMove) IsMoving() bool {
+	return m.isMoving
+}
+
+// DurationMs returns the duration of the last move, including the extension of the synchronized moves
+//
+// Returns:
+//
+// The duration in milliseconds
+func (m *PoseMove) DurationMs() uint32 {
+	return m.durationMs
+}
@@ -0,0 +1,178 @@
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// TransportFunc adapts a function to the Transport interface
+	TransportFunc func(data []byte) (int, error)
+
+	// Publisher periodically serializes the snapshots of a Group and pushes them to a transport. Every snapshot is sent
+	// as a text line, so it can be read by dashboards and serial plotters:
+	//
+	//	T,<timestamp ms>,<id>:<angle millidegrees>:<enabled>:<sleeping>:<radians>,...
+	//
+	// where only the selected fields are included, in that order. The radians are written with six decimals, in the
+	// convention set for every servo with SetConvention
+	Publisher struct {
+		group        *Group
+		transport    Transport
+		intervalMs   uint32
+		fields       TelemetryField
+		snapshot     Snapshot
+		buffer       [maxTelemetryLineSize]byte
+		hasPublished bool
+		publishedMs  uint32
+		failedWrites uint32
+
+		conventions      [MaxServos]Convention
+		conventionIDs    [MaxServos]uint8
+		conventionsCount int
+	}
+)
+
+// Write calls the function
+//
+// Parameters:
+//
+// data: The data to send
+//
+// Returns:
+//
+// The number of bytes sent and an error if the data couldn't be sent
+func (f TransportFunc) Write(data []byte) (int, error) {
+	return f(data)
+}
+
+// NewPublisher creates a new instance of Publisher
+//
+// Parameters:
+//
+// group: The group to publish
+// transport: The link the telemetry is pushed to
+// intervalMs: The time between two snapshots in milliseconds
+// fields: The fields included for every servo
+//
+// Returns:
+//
+// An instance of Publisher and an error if any parameter is invalid
+func NewPublisher(
+	group *Group,
+	transport Transport,
+	intervalMs uint32,
+	fields TelemetryField,
+) (*Publisher, tinygoerrors.ErrorCode) {
+	if group == nil {
+		return nil, ErrorCodeGroupNilGroup
+	}
+	if transport == nil {
+		return nil, ErrorCodeGroupNilTransport
+	}
+	if intervalMs == 0 {
+		return nil, ErrorCodeGroupZeroInterval
+	}
+	return &Publisher{
+		group:      group,
+		transport:  transport,
+		intervalMs: intervalMs,
+		fields:     fields,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetInterval sets the time between two snapshots
+//
+// Parameters:
+//
+// intervalMs: The time in milliseconds, zero is ignored
+func (p *Publisher) SetInterval(intervalMs uint32) {
+	if intervalMs != 0 {
+		p.intervalMs = intervalMs
+	}
+}
+
+// SetFields sets the fields included for every servo
+//
+// Parameters:
+//
+// fields: The fields
+func (p *Publisher) SetFields(fields TelemetryField) {
+	p.fields = fields
+}
+
+// serialize writes the current snapshot as a text line
+//
+// Returns:
+//
+// The serialized line
+func (p *Publisher) serialize() []byte {
+	line := append(p.buffer[:0], 'T', ',')
+	line = appendUint(line, p.snapshot.TimestampMs)
+	for index := 0; index < p.snapshot.Count; index++ {
+		servo := &p.snapshot.Servos[index]
+		line = append(line, ',')
+		line = appendUint(line, uint32(servo.ID))
+		if p.fields&TelemetryFieldAngle != 0 {
+			line = append(line, ':')
+			line = appendUint(line, servo.AngleMilliDegrees)
+		}
+		if p.fields&TelemetryFieldEnabled != 0 {
+			line = append(line, ':')
+			line = appendBool(line, servo.IsMovementEnabled)
+		}
+		if p.fields&TelemetryFieldSleeping != 0 {
+			line = append(line, ':')
+			line = appendBool(line, servo.IsSleeping)
+		}
+		if p.fields&TelemetryFieldRadians != 0 {
+			line = append(line, ':')
+			line = appendMicroRadians(line, p.convention(servo.ID).ToMicroRadians(servo.AngleMilliDegrees))
+		}
+	}
+	return append(line, '\r', '\n')
+}
+
+// Publish captures and sends a snapshot immediately
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+//
+// Returns:
+//
+// An error if the transport failed
+func (p *Publisher) Publish(nowMs uint32) tinygoerrors.ErrorCode {
+	p.hasPublished = true
+	p.publishedMs = nowMs
+	p.group.Snapshot(&p.snapshot, nowMs)
+	if _, err := p.transport.Write(p.serialize()); err != nil {
+		p.failedWrites++
+		return ErrorCodeGroupTransportFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Update publishes a snapshot when the interval has elapsed, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// An error if the transport failed
+func (p *Publisher) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if p.hasPublished && nowMs-p.publishedMs < p.intervalMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+	return p.Publish(nowMs)
+}
+
+// FailedWrites returns the number of snapshots the transport failed to send
+//
+// Returns:
+//
+// The number of failed writes
+func (p *Publisher) FailedWrites() uint32 {
+	return p.failedWrites
+}
@@ -0,0 +1,181 @@
+package group
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// ServoSnapshot is the state of a servo of a Group at the time of a snapshot
+	ServoSnapshot struct {
+		ID                uint8
+		AngleMilliDegrees uint32
+		IsMovementEnabled bool
+		IsSleeping        bool
+	}
+
+	// Snapshot is the state of every servo of a Group at a point in time
+	Snapshot struct {
+		TimestampMs uint32
+		Count       int
+		Servos      [MaxServos]ServoSnapshot
+	}
+
+	// Group holds the servos of a mechanism, identified by an application-defined ID, e.g. the joints of an arm
+	Group struct {
+		servos [MaxServos]Servo
+		ids    [MaxServos]uint8
+		count  int
+	}
+)
+
+// NewGroup creates a new instance of Group
+//
+// Returns:
+//
+// An empty instance of Group
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add adds a servo to the group
+//
+// Parameters:
+//
+// id: The ID of the servo within the group
+// servo: The servo
+//
+// Returns:
+//
+// An error if the servo is nil, the ID is already used or the group is full
+func (g *Group) Add(id uint8, servo Servo) tinygoerrors.ErrorCode {
+	if servo == nil {
+		return ErrorCodeGroupNilServo
+	}
+	if g.index(id) >= 0 {
+		return ErrorCodeGroupDuplicatedID
+	}
+	if g.count == MaxServos {
+		return ErrorCodeGroupFull
+	}
+	g.servos[g.count] = servo
+	g.ids[g.count] = id
+	g.count++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// index returns the position of a servo in the group
+//
+// Parameters:
+//
+// id: The ID of the servo
+//
+// Returns:
+//
+// The position of the servo, or -1 if there is no servo with the ID
+func (g *Group) index(id uint8) int {
+	for index := 0; index < g.count; index++ {
+		if g.ids[index] == id {
+			return index
+		}
+	}
+	return -1
+}
+
+// Servo returns a servo of the group
+//
+// Parameters:
+//
+// id: The ID of the servo
+//
+// Returns:
+//
+// The servo, and an error if there is no servo with the ID
+func (g *Group) Servo(id uint8) (Servo, tinygoerrors.ErrorCode) {
+	index := g.index(id)
+	if index < 0 {
+		return nil, ErrorCodeGroupUnknownID
+	}
+	return g.servos[index], tinygoerrors.ErrorCodeNil
+}
+
+// Len returns the number of servos of the group
+//
+// Returns:
+//
+// The number of servos
+func (g *Group) Len() int {
+	return g.count
+}
+
+// Snapshot captures the state of every servo of the group
+//
+// Parameters:
+//
+// snapshot: The snapshot to fill, passed by pointer so no allocation is needed
+// nowMs: The current time in milliseconds, stored as the timestamp of the snapshot
+func (g *Group) Snapshot(snapshot *Snapshot, nowMs uint32) {
+	snapshot.TimestampMs = nowMs
+	snapshot.Count = g.count
+	for index := 0; index < g.count; index++ {
+		servo := g.servos[index]
+		snapshot.Servos[index] = ServoSnapshot{
+			ID:                g.ids[index],
+			AngleMilliDegrees: servo.GetAngleMilliDegrees(),
+			IsMovementEnabled: servo.IsMovementEnabled(),
+			IsSleeping:        servo.IsSleeping(),
+		}
+	}
+}
+
+// Update updates every servo of the group that implements UpdatedServo, advancing their speed caps, timed moves and
+// warm-ups
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (g *Group) Update(nowMs uint32) {
+	for index := 0; index < g.count; index++ {
+		if servo, ok := g.servos[index].(UpdatedServo); ok {
+			servo.Update(nowMs)
+		}
+	}
+}
+
+// MoveAllTo moves servos of the group to their targets at once, scaling the speed of every servo so they all arrive
+// together, e.g. for the joints of an arm or the legs of a walking robot. Every servo interpolates its own timed move,
+// so the handlers must be updated from the main loop
+//
+// Parameters:
+//
+// angles: The absolute target angles in degrees by servo ID, the servos not included hold their angle
+// durationMs: The duration of the move in milliseconds
+//
+// Returns:
+//
+// An error if a servo ID is unknown, an angle is out of the limits of its servo or a servo doesn't support timed
+// moves, in which case no servo is moved, or the first error returned by the servos
+func (g *Group) MoveAllTo(angles map[uint8]uint16, durationMs uint32) tinygoerrors.ErrorCode {
+	// Check every target before moving any servo
+	for id, angle := range angles {
+		servo, err := g.Servo(id)
+		if err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		if angle < servo.LeftLimit() || angle > servo.RightLimit() {
+			return ErrorCodeGroupAngleOutOfRange
+		}
+		if _, ok := servo.(TimedServo); !ok {
+			return ErrorCodeGroupUntimedServo
+		}
+	}
+
+	firstErr := tinygoerrors.ErrorCodeNil
+	for id, angle := range angles {
+		servo, _ := g.Servo(id)
+		err := servo.(TimedServo).MoveToMilliDegrees(uint32(angle)*1000, durationMs)
+		if err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,84 @@
+package group
+
+// appendUint appends the decimal representation of a number to a buffer
+//
+// Parameters:
+//
+// buffer: The buffer to append to
+// value: The number
+//
+// Returns:
+//
+// The extended buffer
+func appendUint(buffer []byte, value uint32) []byte {
+	var digits [10]byte
+	index := len(digits)
+	for {
+		index--
+		digits[index] = byte('0' + value%10)
+		value /= 10
+		if value == 0 {
+			break
+		}
+	}
+	return append(buffer, digits[index:]...)
+}
+
+// appendBool appends a boolean as 1 or 0 to a buffer
+//
+// Parameters:
+//
+// buffer: The buffer to append to
+// value: The boolean
+//
+// Returns:
+//
+// The extended buffer
+func appendBool(buffer []byte, value bool) []byte {
+	if value {
+		return append(buffer, '1')
+	}
+	return append(buffer, '0')
+}
+
+// appendMicroRadians appends an angle in microradians as radians with six decimals to a buffer
+//
+// Parameters:
+//
+// buffer: The buffer to append to
+// value: The angle in microradians
+//
+// Returns:
+//
+// The extended buffer
+func appendMicroRadians(buffer []byte, value int32) []byte {
+	magnitude := uint32(value)
+	if value < 0 {
+		buffer = append(buffer, '-')
+		magnitude = uint32(-value)
+	}
+	buffer = appendUint(buffer, magnitude/1000000)
+	buffer = append(buffer, '.')
+	fraction := magnitude % 1000000
+	for divisor := uint32(100000); divisor > 0; divisor /= 10 {
+		buffer = append(buffer, byte('0'+fraction/divisor%10))
+	}
+	return buffer
+}
+
+// angleDifferenceMilliDegrees returns the absolute difference between two angles in millidegrees
+//
+// Parameters:
+//
+// a: The first angle
+// b: The second angle
+//
+// Returns:
+//
+// The absolute difference
+func angleDifferenceMilliDegrees(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,156 @@
+//go:build tinygo
+
+package tinygo_servo
+
+import (
+	"machine"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Heartbeat toggles a GPIO at a fixed rate only while the motion engine is healthy, so an external hardware
+	// watchdog or safety relay can cut the servo power when the firmware stalls. The pin stops toggling when the
+	// ticks fall behind schedule, a fault is latched or the health function reports a failure
+	Heartbeat struct {
+		pin               machine.Pin
+		toggleIntervalMs  uint32
+		maxTickGapMs      uint32
+		isHealthyFunc     func() bool
+		hasTickTimestamp  bool
+		tickTimestampMs   uint32
+		toggleTimestampMs uint32
+		isHigh            bool
+		isFaultLatched    bool
+		isStopped         bool
+		fault             tinygoerrors.ErrorCode
+		lateTicks         uint32
+	}
+)
+
+// NewHeartbeat creates a new instance of Heartbeat, configuring the pin as an output held low
+//
+// Parameters:
+//
+// pin: The heartbeat pin
+// toggleIntervalMs: The time between two toggles of the pin in milliseconds
+// maxTickGapMs: The longest time between two calls to Update in milliseconds that is still on schedule
+//
+// Returns:
+//
+// An instance of Heartbeat and an error if any interval is zero
+func NewHeartbeat(pin machine.Pin, toggleIntervalMs uint32, maxTickGapMs uint32) (*Heartbeat, tinygoerrors.ErrorCode) {
+	if toggleIntervalMs == 0 || maxTickGapMs == 0 {
+		return nil, ErrorCodeServoInvalidHeartbeat
+	}
+	pin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	pin.Low()
+	return &Heartbeat{
+		pin:              pin,
+		toggleIntervalMs: toggleIntervalMs,
+		maxTickGapMs:     maxTickGapMs,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetHealthFunc sets a function checked before every toggle, e.g. whether a mode.Manager is not in its fault state
+//
+// Parameters:
+//
+// isHealthy: The function returning whether the motion engine is healthy, or nil
+func (h *Heartbeat) SetHealthFunc(isHealthy func() bool) {
+	h.isHealthyFunc = isHealthy
+}
+
+// LatchFault stops the heartbeat until ClearFault is called
+//
+// Parameters:
+//
+// err: The error that caused the fault
+func (h *Heartbeat) LatchFault(err tinygoerrors.ErrorCode) {
+	h.isFaultLatched = true
+	h.fault = err
+}
+
+// ClearFault resumes the heartbeat after a latched fault
+func (h *Heartbeat) ClearFault() {
+	h.isFaultLatched = false
+	h.fault = tinygoerrors.ErrorCodeNil
+}
+
+// Fault returns the latched fault
+//
+// Returns:
+//
+// The error that caused the fault and true if a fault is latched, false otherwise
+func (h *Heartbeat) Fault() (tinygoerrors.ErrorCode, bool) {
+	return h.fault, h.isFaultLatched
+}
+
+// Stop stops the heartbeat on purpose, holding the pin low until Start is called, e.g. from the prepare hook of
+// SetFirmwareUpdateHooks, so the watchdog cuts the servo power during the update instead of the ticks stopping
+// mid-toggle
+func (h *Heartbeat) Stop() {
+	h.isStopped = true
+	h.isHigh = false
+	h.pin.Low()
+}
+
+// Start restarts the heartbeat stopped by Stop, the first tick is on schedule whenever it comes
+func (h *Heartbeat) Start() {
+	h.isStopped = false
+	h.hasTickTimestamp = false
+}
+
+// IsStopped checks if the heartbeat was stopped by Stop
+//
+// Returns:
+//
+// True if the heartbeat is stopped, false otherwise
+func (h *Heartbeat) IsStopped() bool {
+	return h.isStopped
+}
+
+// LateTicks returns the number of calls to Update that came later than the max tick gap
+//
+// Returns:
+//
+// The number of late ticks
+func (h *Heartbeat) LateTicks() uint32 {
+	return h.lateTicks
+}
+
+// Update toggles the pin if the toggle interval elapsed and the motion engine is healthy. It must be called from the
+// same loop as the Update of the handlers, so a stall of the loop stops the heartbeat
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+func (h *Heartbeat) Update(nowMs uint32) {
+	if h.isStopped {
+		return
+	}
+
+	// Check if the tick is on schedule, restarting the toggle interval after a late one
+	isOnSchedule := !h.hasTickTimestamp || nowMs-h.tickTimestampMs <= h.maxTickGapMs
+	h.hasTickTimestamp = true
+	h.tickTimestampMs = nowMs
+	if !isOnSchedule {
+		h.lateTicks++
+		h.toggleTimestampMs = nowMs
+		return
+	}
+
+	// Check if the motion engine is healthy
+	if h.isFaultLatched || (h.isHealthyFunc != nil && !h.isHealthyFunc()) {
+		h.toggleTimestampMs = nowMs
+		return
+	}
+
+	// Toggle the pin once the interval elapsed
+	if nowMs-h.toggleTimestampMs < h.toggleIntervalMs {
+		return
+	}
+	h.toggleTimestampMs = nowMs
+	h.isHigh = !h.isHigh
+	h.pin.Set(h.isHigh)
+}
@@ -0,0 +1,45 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetAngleImmediate writes an angle straight to the PWM, for control loops running at several hundred Hz like
+// reaction wheels and balancing robots. It bypasses the speed cap of the handler, the queued and pending pulses, the
+// smoothing modes, the logger, the alarm zones, the recordings and the after set angle function, and it doesn't report
+// its errors. The global speed cap set by SetGlobalCaps is a safety override, and the write can't be ramped, so it is
+// refused while the cap is set. Its worst-case execution time is bounded: a bounds check, the two 64-bit
+// multiplications and divisions of the pulse width and duty cycle calculations and the PWM register write, with no
+// allocations. The only loop is the search of the segment of the calibration table, if set, over at most
+// MaxCalibrationPoints+1 segments, and the only callback is the movement enabled function, if set, so it must be kept
+// short
+//
+// Parameters:
+//
+// milliDegrees: The absolute angle in millidegrees, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the angle is out of range, the global speed cap is set, or if the pulses can't be written because the
+// handler is not initialized, sleeping, warming up, detached, suspended or its movement is disabled
+func (h *DefaultHandler) SetAngleImmediate(milliDegrees uint32) tinygoerrors.ErrorCode {
+	if milliDegrees < uint32(h.LeftLimit())*1000 || milliDegrees > uint32(h.RightLimit())*1000 {
+		return ErrorCodeServoAngleOutOfRange
+	}
+	if globalMaxSpeed != 0 {
+		return ErrorCodeServoImmediateSpeedCapped
+	}
+	if h.isSleeping || !h.canWritePulse() {
+		return ErrorCodeServoImmediateUnavailable
+	}
+
+	// Replace the move in progress so it doesn't fight the control loop
+	h.StopMove()
+
+	pulse := h.calculatePulseMilliDegrees(milliDegrees)
+	h.angle = uint16(milliDegrees / 1000)
+	h.angleFraction = uint16(milliDegrees % 1000)
+	h.pulse = pulse
+	h.writePulse(pulse)
+	return tinygoerrors.ErrorCodeNil
+}
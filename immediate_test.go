@@ -0,0 +1,42 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestSetAngleImmediateHonorsGlobalSpeedCap checks the immediate writes are refused while the global speed cap is
+// set, leaving the pulse untouched, and are applied again once the cap is cleared
+func TestSetAngleImmediateHonorsGlobalSpeedCap(t *testing.T) {
+	output := servotest.NewOutput()
+	handler, err := NewOutputHandler(output)
+	if err != 0 {
+		t.Fatalf("NewOutputHandler: %d", err)
+	}
+	releaseAll(t, handler)
+	t.Cleanup(ClearGlobalCaps)
+
+	if err = SetGlobalCaps(30, 100); err != 0 {
+		t.Fatalf("SetGlobalCaps: %d", err)
+	}
+	output.ClearPulses()
+	if err = handler.SetAngleImmediate(170000); err != ErrorCodeServoImmediateSpeedCapped {
+		t.Fatalf("SetAngleImmediate with the global speed cap = %d, want %d", err, ErrorCodeServoImmediateSpeedCapped)
+	}
+	if pulses := output.Pulses(); len(pulses) != 0 || handler.GetAngle() != 90 {
+		t.Fatalf("%d pulses written and angle %d with the global speed cap, want none at 90", len(pulses), handler.GetAngle())
+	}
+
+	// The cap of the handler alone doesn't refuse the writes
+	ClearGlobalCaps()
+	_ = handler.SetMaxSpeed(30)
+	if err = handler.SetAngleImmediate(170000); err != 0 {
+		t.Fatalf("SetAngleImmediate without the global speed cap: %d", err)
+	}
+	if pulse, _ := output.LastPulse(); handler.GetAngle() != 170 || pulse != 2388888 {
+		t.Errorf("angle %d and pulse %dns, want 170 and 2388888ns", handler.GetAngle(), pulse)
+	}
+}
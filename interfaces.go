@@ -14,5 +14,53 @@ type (
 		SetAngleToCenter() tinygoerrors.ErrorCode
 		SetAngleToRight(angle uint16) tinygoerrors.ErrorCode
 		SetAngleToLeft(angle uint16) tinygoerrors.ErrorCode
+		LeftLimit() uint16
+		RightLimit() uint16
+		EnableMovement()
+		DisableMovement()
+		IsMovementEnabled() bool
+		SetPulseMicroseconds(us uint32) tinygoerrors.ErrorCode
+		GetPulseMicroseconds() uint32
+	}
+
+	// PulseOutput is the interface the handlers write their pulses through, so hardware PWM peripherals, PWM
+	// expanders, software PWM and test fakes can drive the servos without changing the angle logic. The pulse widths
+	// are in nanoseconds, like the rest of the handler, so backends with a fine timer resolution don't lose precision
+	PulseOutput interface {
+		Configure(period uint32) tinygoerrors.ErrorCode
+		SetPulse(pulse uint32)
+	}
+
+	// PulseCapture is the interface implemented by the input captures of the boards with capture-capable timers, like
+	// the RP2040 PIO or the nRF52 timers through PPI, used to verify the looped back output of a handler.
+	// CapturePulseWidth waits for the next complete high pulse on the capture pin and returns its width in
+	// nanoseconds, or false if none arrived within the timeout of the capture
+	PulseCapture interface {
+		CapturePulseWidth() (uint32, bool)
+	}
+
+	// InvertingPWM is the interface implemented by PWM backends that can invert the output polarity of a channel in
+	// hardware, like the RP2040 PWM slices
+	InvertingPWM interface {
+		SetInverting(channel uint8, inverting bool)
+	}
+
+	// KeepAlivePWM is the interface implemented by PWM backends whose outputs stop unless the duty cycles are written
+	// periodically, like smart servo buses and I2C controllers with a watchdog
+	KeepAlivePWM interface {
+		KeepAliveIntervalMs() uint32
+	}
+
+	// TuningStorage is the non-volatile memory the tuning of a handler is saved to, e.g. a slot of a flash or EEPROM
+	// store. Store replaces the saved bytes, and Load reads them back into a buffer
+	TuningStorage interface {
+		Load(buffer []byte) ([]byte, tinygoerrors.ErrorCode)
+		Store(data []byte) tinygoerrors.ErrorCode
+	}
+
+	// ErrorReporter receives the errors of the servo handlers attributed to the component that raised them, so
+	// system-level error handlers can tell which joint failed
+	ErrorReporter interface {
+		ReportError(componentID uint16, errCode tinygoerrors.ErrorCode)
 	}
 )
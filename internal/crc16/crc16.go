@@ -0,0 +1,47 @@
+// Package crc16 implements the CRC-16 checksum with the 0x1021 polynomial shared by the remote configuration frames
+// and the records of the store package
+package crc16
+
+const (
+	// Initial is the checksum of no bytes, the one the first bytes are added to
+	Initial uint16 = 0xFFFF
+)
+
+// Update adds a byte to a CRC-16 checksum, using the 0x1021 polynomial
+//
+// Parameters:
+//
+// checksum: The checksum of the previous bytes
+// data: The byte to add
+//
+// Returns:
+//
+// The updated checksum
+func Update(checksum uint16, data byte) uint16 {
+	checksum ^= uint16(data) << 8
+	for bit := 0; bit < 8; bit++ {
+		if checksum&0x8000 != 0 {
+			checksum = checksum<<1 ^ 0x1021
+		} else {
+			checksum <<= 1
+		}
+	}
+	return checksum
+}
+
+// Checksum adds a byte slice to a CRC-16 checksum, using the 0x1021 polynomial
+//
+// Parameters:
+//
+// checksum: The checksum of the previous bytes, Initial for the first ones
+// data: The bytes to add
+//
+// Returns:
+//
+// The updated checksum
+func Checksum(checksum uint16, data []byte) uint16 {
+	for _, value := range data {
+		checksum = Update(checksum, value)
+	}
+	return checksum
+}
@@ -0,0 +1,86 @@
+//go:build !tinygo
+
+// Package fuzzservos creates the servos commanded by the fuzzers of the protocol packages and checks they are never
+// driven beyond their travel, so every fuzzer covers the same free, limited and inverted wide servos
+package fuzzservos
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+type (
+	// Servos are the handlers commanded by a fuzzer, writing to fake outputs: one free to move over its whole
+	// actuation range, one with limits and one inverted with a wide actuation range
+	Servos struct {
+		Handlers []*tinygoservo.DefaultHandler
+		outputs  []*servotest.Output
+	}
+)
+
+// New creates the servos commanded by a fuzzer, released at the end of the test
+//
+// Parameters:
+//
+// t: The test the servos are created for
+//
+// Returns:
+//
+// An instance of Servos
+func New(t testing.TB) *Servos {
+	t.Helper()
+	servos := &Servos{}
+	for index, options := range [][]tinygoservo.Option{
+		nil,
+		{tinygoservo.WithLimits(60, 60)},
+		{tinygoservo.WithActuationRange(tinygoservo.WideActuationRange), tinygoservo.WithInvertedDirection()},
+	} {
+		output := servotest.NewOutput()
+		handler, err := tinygoservo.NewOutputHandler(output, options...)
+		if err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("servo %d: %d", index, err)
+		}
+		t.Cleanup(handler.Release)
+		servos.Handlers = append(servos.Handlers, handler)
+		servos.outputs = append(servos.outputs, output)
+	}
+	return servos
+}
+
+// Update updates every servo, failing the test if any of them is outside its limits
+//
+// Parameters:
+//
+// t: The test the servos were created for
+// nowMs: The current time in milliseconds
+func (s *Servos) Update(t testing.TB, nowMs uint32) {
+	t.Helper()
+	for index, handler := range s.Handlers {
+		handler.Update(nowMs)
+		if angle := handler.GetAngle(); angle < handler.LeftLimit() || angle > handler.RightLimit() {
+			t.Fatalf("servo %d at %d, outside %d to %d", index, angle, handler.LeftLimit(), handler.RightLimit())
+		}
+	}
+}
+
+// CheckPulses fails the test if any servo output a pulse width beyond the default pulse range, and clears the
+// recorded pulses
+//
+// Parameters:
+//
+// t: The test the servos were created for
+func (s *Servos) CheckPulses(t testing.TB) {
+	t.Helper()
+	for index, output := range s.outputs {
+		if pulse, ok := output.PulseOutside(
+			uint32(tinygoservo.DefaultMinPulseWidth),
+			uint32(tinygoservo.DefaultMaxPulseWidth),
+		); ok {
+			t.Fatalf("servo %d output %dns at %dms", index, pulse.PulseWidth, pulse.TimestampMs)
+		}
+		output.ClearPulses()
+	}
+}
@@ -0,0 +1,17 @@
+package joint
+
+type (
+	// Member is an enum to represent the servos driving a Dual joint.
+	Member uint8
+)
+
+const (
+	// MemberPrimary is the servo whose angle is the angle of the joint
+	MemberPrimary Member = iota
+
+	// MemberSecondary is the servo following the primary one with the preload offset
+	MemberSecondary
+
+	// membersCount is the number of servos of a Dual joint
+	membersCount
+)
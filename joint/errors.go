@@ -0,0 +1,19 @@
+package joint
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeJointStartNumber is the starting number for joint-related error codes.
+	ErrorCodeJointStartNumber uint16 = 5580
+)
+
+const (
+	ErrorCodeJointNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeJointStartNumber)
+	ErrorCodeJointUnknownMember
+	ErrorCodeJointNoMemberLeft
+	ErrorCodeJointNilFeedbackFunc
+	ErrorCodeJointSecondaryOutOfRange
+	ErrorCodeJointPrimaryOutOfRange
+)
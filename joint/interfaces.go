@@ -0,0 +1,23 @@
+package joint
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the joints, so a joint member can be any
+	// handler or a fake in the tests
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		GetAngleMilliDegrees() uint32
+		LeftLimit() uint16
+		RightLimit() uint16
+	}
+
+	// Detacher is implemented by the servos that can stop driving their signal line, so a failed member of a joint
+	// goes limp instead of dragging the shaft
+	Detacher interface {
+		Detach()
+		Attach() tinygoerrors.ErrorCode
+	}
+)
@@ -0,0 +1,322 @@
+package joint
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Dual drives a joint with two servos on the same shaft, sharing its torque. The secondary servo is commanded
+	// with a static offset from the primary one, the preload, which keeps both gear trains loaded against each other
+	// to eliminate the backlash. If the feedback of the servos diverges for too long they are fighting, and the joint
+	// degrades to the servo that follows its command best, detaching the other one if possible
+	//
+	// The servos can be mounted facing each other, mirroring their angles around a sum: the secondary servo is then
+	// commanded to the sum minus the angle of the primary one
+	Dual struct {
+		servos                    [membersCount]Servo
+		isFailed                  [membersCount]bool
+		mirrorSumMilliDegrees     uint32
+		preloadMilliDegrees       int32
+		angleMilliDegrees         uint32
+		feedbackFuncs             [membersCount]func() uint32
+		maxDivergenceMilliDegrees uint32
+		maxFightMs                uint32
+		isFighting                bool
+		fightStartMs              uint32
+		degradedFunc              func(failed Member)
+	}
+)
+
+// NewDual creates a new instance of Dual
+//
+// Parameters:
+//
+// primary: The servo whose angle is the angle of the joint
+// secondary: The servo following the primary one
+// mirrorSumMilliDegrees: The sum of the angles of both servos in millidegrees if they are mirrored, or zero if they
+// turn in the same direction
+//
+// Returns:
+//
+// An instance of Dual and an error if any servo is nil
+func NewDual(primary Servo, secondary Servo, mirrorSumMilliDegrees uint32) (*Dual, tinygoerrors.ErrorCode) {
+	if primary == nil || secondary == nil {
+		return nil, ErrorCodeJointNilServo
+	}
+	return &Dual{
+		servos:                [membersCount]Servo{primary, secondary},
+		mirrorSumMilliDegrees: mirrorSumMilliDegrees,
+		angleMilliDegrees:     primary.GetAngleMilliDegrees(),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetPreload sets the static offset of the secondary servo, in the direction of the primary one, and commands it
+//
+// Parameters:
+//
+// preloadMilliDegrees: The offset in millidegrees, positive or negative
+//
+// Returns:
+//
+// An error if the secondary angle is out of range or can't be set
+func (d *Dual) SetPreload(preloadMilliDegrees int32) tinygoerrors.ErrorCode {
+	d.preloadMilliDegrees = preloadMilliDegrees
+	if d.isFailed[MemberSecondary] {
+		return tinygoerrors.ErrorCodeNil
+	}
+	return d.commandSecondary(d.angleMilliDegrees)
+}
+
+// SetMonitor enables the fight monitor, comparing the feedback of both servos from Update
+//
+// Parameters:
+//
+// primaryFeedback: The function returning the measured angle of the primary servo in millidegrees
+// secondaryFeedback: The function returning the measured angle of the secondary servo in millidegrees
+// maxDivergenceMilliDegrees: The largest difference in millidegrees between the measured angles, mapped to the
+// primary servo, that is not a fight
+// maxFightMs: The time in milliseconds the divergence must persist to degrade the joint
+//
+// Returns:
+//
+// An error if any feedback function is nil
+func (d *Dual) SetMonitor(
+	primaryFeedback func() uint32,
+	secondaryFeedback func() uint32,
+	maxDivergenceMilliDegrees uint32,
+	maxFightMs uint32,
+) tinygoerrors.ErrorCode {
+	if primaryFeedback == nil || secondaryFeedback == nil {
+		return ErrorCodeJointNilFeedbackFunc
+	}
+	d.feedbackFuncs = [membersCount]func() uint32{primaryFeedback, secondaryFeedback}
+	d.maxDivergenceMilliDegrees = maxDivergenceMilliDegrees
+	d.maxFightMs = maxFightMs
+	d.isFighting = false
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetDegradedFunc sets a function called when the joint degrades to a single servo
+//
+// Parameters:
+//
+// degradedFunc: The function called with the failed servo, or nil
+func (d *Dual) SetDegradedFunc(degradedFunc func(failed Member)) {
+	d.degradedFunc = degradedFunc
+}
+
+// SetAngleMilliDegrees moves the joint, commanding both servos
+//
+// Parameters:
+//
+// milliDegrees: The angle of the primary servo in millidegrees
+//
+// Returns:
+//
+// An error if both servos failed, the angle of any working servo is out of its limits or it rejected its angle
+func (d *Dual) SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	if d.isFailed[MemberPrimary] && d.isFailed[MemberSecondary] {
+		return ErrorCodeJointNoMemberLeft
+	}
+
+	// Check the angles of both servos first, so a rejected move doesn't leave the servos fighting each other
+	if !d.isFailed[MemberPrimary] && !isWithinLimits(d.servos[MemberPrimary], milliDegrees) {
+		return ErrorCodeJointPrimaryOutOfRange
+	}
+	if !d.isFailed[MemberSecondary] {
+		if _, ok := d.secondaryAngle(milliDegrees); !ok {
+			return ErrorCodeJointSecondaryOutOfRange
+		}
+	}
+	if !d.isFailed[MemberPrimary] {
+		if err := d.servos[MemberPrimary].SetAngleMilliDegrees(milliDegrees); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+	}
+	d.angleMilliDegrees = milliDegrees
+	if !d.isFailed[MemberSecondary] {
+		return d.commandSecondary(milliDegrees)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetAngleMilliDegrees returns the angle of the joint
+//
+// Returns:
+//
+// The last angle commanded to the joint in millidegrees
+func (d *Dual) GetAngleMilliDegrees() uint32 {
+	return d.angleMilliDegrees
+}
+
+// Fail degrades the joint to the other servo, e.g. when its current sensor reports an overload. The failed servo is
+// detached if it implements Detacher
+//
+// Parameters:
+//
+// member: The failed servo
+//
+// Returns:
+//
+// An error if the member is unknown
+func (d *Dual) Fail(member Member) tinygoerrors.ErrorCode {
+	if member >= membersCount {
+		return ErrorCodeJointUnknownMember
+	}
+	if d.isFailed[member] {
+		return tinygoerrors.ErrorCodeNil
+	}
+	d.isFailed[member] = true
+	d.isFighting = false
+	if detacher, ok := d.servos[member].(Detacher); ok {
+		detacher.Detach()
+	}
+	if d.degradedFunc != nil {
+		d.degradedFunc(member)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Restore drives the joint with both servos again after a failure was fixed, moving the restored servo to the angle
+// of the joint. The servos detached by Fail are attached again
+//
+// Parameters:
+//
+// member: The restored servo
+//
+// Returns:
+//
+// An error if the member is unknown or its angle can't be set
+func (d *Dual) Restore(member Member) tinygoerrors.ErrorCode {
+	if member >= membersCount {
+		return ErrorCodeJointUnknownMember
+	}
+	if !d.isFailed[member] {
+		return tinygoerrors.ErrorCodeNil
+	}
+	d.isFailed[member] = false
+	d.isFighting = false
+	if detacher, ok := d.servos[member].(Detacher); ok {
+		if err := detacher.Attach(); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+	}
+	if member == MemberPrimary {
+		return d.servos[MemberPrimary].SetAngleMilliDegrees(d.angleMilliDegrees)
+	}
+	return d.commandSecondary(d.angleMilliDegrees)
+}
+
+// IsFailed checks if a servo of the joint failed
+//
+// Parameters:
+//
+// member: The servo
+//
+// Returns:
+//
+// True if the servo failed, false otherwise or if the member is unknown
+func (d *Dual) IsFailed(member Member) bool {
+	return member < membersCount && d.isFailed[member]
+}
+
+// IsDegraded checks if the joint is driven by a single servo
+//
+// Returns:
+//
+// True if any servo failed, false otherwise
+func (d *Dual) IsDegraded() bool {
+	return d.isFailed[MemberPrimary] || d.isFailed[MemberSecondary]
+}
+
+// Update monitors the servos for fighting, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (d *Dual) Update(nowMs uint32) {
+	if d.feedbackFuncs[MemberPrimary] == nil || d.IsDegraded() {
+		return
+	}
+
+	// Map the secondary feedback to the angle of the primary servo and compare them
+	primary := d.feedbackFuncs[MemberPrimary]()
+	secondary := d.primaryAngle(d.feedbackFuncs[MemberSecondary]())
+	if difference(primary, secondary) <= d.maxDivergenceMilliDegrees {
+		d.isFighting = false
+		return
+	}
+	if !d.isFighting {
+		d.isFighting = true
+		d.fightStartMs = nowMs
+	}
+	if nowMs-d.fightStartMs < d.maxFightMs {
+		return
+	}
+
+	// Keep the servo closest to the commanded angle
+	if difference(primary, d.angleMilliDegrees) <= difference(secondary, d.angleMilliDegrees) {
+		_ = d.Fail(MemberSecondary)
+	} else {
+		_ = d.Fail(MemberPrimary)
+	}
+}
+
+// commandSecondary moves the secondary servo to follow an angle of the primary one
+//
+// Parameters:
+//
+// milliDegrees: The angle of the primary servo in millidegrees
+//
+// Returns:
+//
+// An error if the secondary angle is out of range or can't be set
+func (d *Dual) commandSecondary(milliDegrees uint32) tinygoerrors.ErrorCode {
+	angle, ok := d.secondaryAngle(milliDegrees)
+	if !ok {
+		return ErrorCodeJointSecondaryOutOfRange
+	}
+	return d.servos[MemberSecondary].SetAngleMilliDegrees(angle)
+}
+
+// secondaryAngle maps an angle of the primary servo to the secondary one, applying the mirroring and the preload
+//
+// Parameters:
+//
+// milliDegrees: The angle of the primary servo in millidegrees
+//
+// Returns:
+//
+// The angle of the secondary servo in millidegrees, and false if it is negative or out of the limits of the secondary
+// servo
+func (d *Dual) secondaryAngle(milliDegrees uint32) (uint32, bool) {
+	angle := int64(milliDegrees) + int64(d.preloadMilliDegrees)
+	if d.mirrorSumMilliDegrees != 0 {
+		angle = int64(d.mirrorSumMilliDegrees) - angle
+	}
+	if angle < 0 || angle > int64(^uint32(0)) || !isWithinLimits(d.servos[MemberSecondary], uint32(angle)) {
+		return 0, false
+	}
+	return uint32(angle), true
+}
+
+// primaryAngle maps an angle of the secondary servo back to the primary one, removing the mirroring and the preload
+//
+// Parameters:
+//
+// milliDegrees: The angle of the secondary servo in millidegrees
+//
+// Returns:
+//
+// The angle of the primary servo in millidegrees, clamped to zero
+func (d *Dual) primaryAngle(milliDegrees uint32) uint32 {
+	angle := int64(milliDegrees)
+	if d.mirrorSumMilliDegrees != 0 {
+		angle = int64(d.mirrorSumMilliDegrees) - angle
+	}
+	angle -= int64(d.preloadMilliDegrees)
+	if angle < 0 {
+		return 0
+	}
+	return uint32(angle)
+}
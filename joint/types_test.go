@@ -0,0 +1,66 @@
+//go:build !tinygo
+
+package joint
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// newTestDual creates a mirrored joint of two fake servos over the standard actuation range, the secondary one limited
+// to 60 to 120 degrees
+func newTestDual(t *testing.T) (*Dual, *servotest.Handler, *servotest.Handler) {
+	t.Helper()
+	primary, err := servotest.NewHandler(180, 500000, 2500000)
+	if err != 0 {
+		t.Fatalf("servotest.NewHandler: %d", err)
+	}
+	secondary, err := servotest.NewHandler(180, 500000, 2500000)
+	if err != 0 {
+		t.Fatalf("servotest.NewHandler: %d", err)
+	}
+	if err = secondary.SetLimits(60, 120); err != 0 {
+		t.Fatalf("SetLimits: %d", err)
+	}
+	dual, err := NewDual(primary, secondary, 180000)
+	if err != 0 {
+		t.Fatalf("NewDual: %d", err)
+	}
+	return dual, primary, secondary
+}
+
+// TestDualRejectsMovesOutOfEitherLimits checks a joint moves neither servo if the angle of any of them is out of its
+// limits, so they are never left apart on the same shaft
+func TestDualRejectsMovesOutOfEitherLimits(t *testing.T) {
+	dual, primary, secondary := newTestDual(t)
+	if err := dual.SetAngleMilliDegrees(150000); err != ErrorCodeJointSecondaryOutOfRange {
+		t.Fatalf("SetAngleMilliDegrees out of the secondary limits = %d, want %d", err, ErrorCodeJointSecondaryOutOfRange)
+	}
+	if err := primary.SetLimits(80, 100); err != 0 {
+		t.Fatalf("SetLimits: %d", err)
+	}
+	if err := dual.SetAngleMilliDegrees(110000); err != ErrorCodeJointPrimaryOutOfRange {
+		t.Fatalf("SetAngleMilliDegrees out of the primary limits = %d, want %d", err, ErrorCodeJointPrimaryOutOfRange)
+	}
+	if len(primary.Commands()) != 0 || len(secondary.Commands()) != 0 || dual.GetAngleMilliDegrees() != 90000 {
+		t.Fatalf(
+			"%d and %d commands with the joint at %d millidegrees, want none at 90000",
+			len(primary.Commands()),
+			len(secondary.Commands()),
+			dual.GetAngleMilliDegrees(),
+		)
+	}
+
+	// A move within both limits commands both servos, mirrored
+	if err := dual.SetAngleMilliDegrees(95000); err != 0 {
+		t.Fatalf("SetAngleMilliDegrees: %d", err)
+	}
+	if primary.GetAngleMilliDegrees() != 95000 || secondary.GetAngleMilliDegrees() != 85000 {
+		t.Errorf(
+			"angles %d and %d millidegrees, want 95000 and 85000",
+			primary.GetAngleMilliDegrees(),
+			secondary.GetAngleMilliDegrees(),
+		)
+	}
+}
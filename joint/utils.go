@@ -0,0 +1,32 @@
+package joint
+
+// difference returns the absolute difference between two angles
+//
+// Parameters:
+//
+// a: The first angle in millidegrees
+// b: The second angle in millidegrees
+//
+// Returns:
+//
+// The absolute difference in millidegrees
+func difference(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// isWithinLimits checks if an angle is between the limits of a servo
+//
+// Parameters:
+//
+// servo: The servo
+// milliDegrees: The angle in millidegrees
+//
+// Returns:
+//
+// True if the angle is between the left and right limits of the servo, false otherwise
+func isWithinLimits(servo Servo, milliDegrees uint32) bool {
+	return milliDegrees >= uint32(servo.LeftLimit())*1000 && milliDegrees <= uint32(servo.RightLimit())*1000
+}
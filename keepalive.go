@@ -0,0 +1,45 @@
+package tinygo_servo
+
+// SetKeepAliveInterval sets the interval Update writes the current pulse width again at, for backends whose outputs
+// stop unless they are refreshed. Backends implementing KeepAlivePWM are refreshed at their own interval without it
+//
+// Parameters:
+//
+// intervalMs: The time between refreshes in milliseconds, or zero to use the interval of the backend, if any
+func (h *DefaultHandler) SetKeepAliveInterval(intervalMs uint32) {
+	h.keepAliveIntervalMs = intervalMs
+}
+
+// GetKeepAliveInterval returns the interval the current pulse width is written again at
+//
+// Returns:
+//
+// The interval in milliseconds, set or required by the backend, or zero if the pulses are not refreshed
+func (h *DefaultHandler) GetKeepAliveInterval() uint32 {
+	if h.keepAliveIntervalMs != 0 {
+		return h.keepAliveIntervalMs
+	}
+	if keepAlive, ok := h.output.(KeepAlivePWM); ok {
+		return keepAlive.KeepAliveIntervalMs()
+	}
+	if keepAlive, ok := h.pwm.(KeepAlivePWM); ok {
+		return keepAlive.KeepAliveIntervalMs()
+	}
+	return 0
+}
+
+// updateKeepAlive writes the current pulse width again once the keep-alive interval elapsed since the last write
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateKeepAlive(nowMs uint32) {
+	intervalMs := h.GetKeepAliveInterval()
+	if intervalMs == 0 || nowMs-h.keepAliveTimestampMs < intervalMs {
+		return
+	}
+	if h.isSleeping || !h.canWritePulse() {
+		return
+	}
+	h.writePulse(h.pulse)
+}
@@ -0,0 +1,58 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetLatencyBudget sets the maximum age of the timestamped commands, so a backlog of commands built up in queues or
+// links during a hiccup is dropped instead of replaying stale inputs, e.g. old steering setpoints
+//
+// Parameters:
+//
+// maxAgeMs: The maximum age of a command in milliseconds when it enters the handler, or zero to accept every command
+func (h *DefaultHandler) SetLatencyBudget(maxAgeMs uint32) {
+	h.latencyBudgetMs = maxAgeMs
+}
+
+// GetLatencyBudget returns the maximum age of the timestamped commands
+//
+// Returns:
+//
+// The maximum age in milliseconds, zero if every command is accepted
+func (h *DefaultHandler) GetLatencyBudget() uint32 {
+	return h.latencyBudgetMs
+}
+
+// SetAngleMilliDegreesIssuedAt sets the angle of the servo motor from a command issued at a given time, dropping it
+// if it is older than the latency budget
+//
+// Parameters:
+//
+// milliDegrees: The angle to set the servo motor to in millidegrees, must be between the left and right limits
+// issuedMs: The time the command was issued in milliseconds, on the same clock as nowMs
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// An error if the command is stale, the angle is out of range or the servo motor could not be initialized
+func (h *DefaultHandler) SetAngleMilliDegreesIssuedAt(
+	milliDegrees uint32,
+	issuedMs uint32,
+	nowMs uint32,
+) tinygoerrors.ErrorCode {
+	// Commands issued after now, e.g. by a clock slightly ahead, have no age
+	if age := int32(nowMs - issuedMs); h.latencyBudgetMs != 0 && age > 0 && uint32(age) > h.latencyBudgetMs {
+		h.staleCommands++
+		return h.reportError(ErrorCodeServoStaleCommand)
+	}
+	return h.SetAngleMilliDegrees(milliDegrees)
+}
+
+// StaleCommands returns the number of commands dropped because they were older than the latency budget
+//
+// Returns:
+//
+// The number of stale commands
+func (h *DefaultHandler) StaleCommands() uint32 {
+	return h.staleCommands
+}
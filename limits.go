@@ -0,0 +1,242 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// startLimitsLearningMessage is the message logged when the limits learning mode starts
+	startLimitsLearningMessage = []byte("Servo learning limits")
+
+	// markLimitPrefix is the prefix for the log message when a limit is marked during the limits learning mode
+	markLimitPrefix = []byte("Servo limit marked at:")
+
+	// cancelLimitsLearningMessage is the message logged when the limits learning mode is cancelled
+	cancelLimitsLearningMessage = []byte("Servo limits learning cancelled")
+)
+
+// setLimits replaces the left and right limit angles of the servo motor, clearing the limits warning
+//
+// Parameters:
+//
+// leftLimitAngle: The lowest absolute angle the servo motor can be set to
+// rightLimitAngle: The highest absolute angle the servo motor can be set to
+//
+// Returns:
+//
+// An error if the limits are not ordered around the center angle or exceed the actuation range
+func (h *DefaultHandler) setLimits(leftLimitAngle uint16, rightLimitAngle uint16) tinygoerrors.ErrorCode {
+	// Check if the limits are valid
+	if leftLimitAngle > h.centerAngle || rightLimitAngle < h.centerAngle || rightLimitAngle > h.actuationRange {
+		return ErrorCodeServoInvalidLimits
+	}
+	h.leftLimitAngle = leftLimitAngle
+	h.rightLimitAngle = rightLimitAngle
+	h.limitsWarning = tinygoerrors.ErrorCodeNil
+
+	// Log the new limits if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.AddMessageWithUint16(setLeftLimitAnglePrefix, leftLimitAngle, true, true, false)
+		h.addLogName()
+		h.logger.AddMessageWithUint16(setRightLimitAnglePrefix, rightLimitAngle, true, true, false)
+		h.logger.Debug()
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetLimits replaces the left and right limit angles of the servo motor at runtime, e.g. to tighten the travel after
+// detecting a mechanical obstruction or relax it once it is cleared. The limits are absolute angles, like the ones
+// returned by LeftLimit and RightLimit, and must keep the center angle between them. If the servo motor is outside
+// the new limits it is moved to the closest one
+//
+// Parameters:
+//
+// leftLimitAngle: The lowest absolute angle the servo motor can be set to
+// rightLimitAngle: The highest absolute angle the servo motor can be set to, up to the actuation range
+//
+// Returns:
+//
+// An error if the limits are not ordered around the center angle, exceed the actuation range or the servo motor could
+// not be moved within them
+func (h *DefaultHandler) SetLimits(leftLimitAngle uint16, rightLimitAngle uint16) tinygoerrors.ErrorCode {
+	if err := h.setLimits(leftLimitAngle, rightLimitAngle); err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+
+	// Check if the servo motor is within the new limits
+	milliDegrees := h.GetAngleMilliDegrees()
+	leftLimit := uint32(h.LeftLimit()) * 1000
+	rightLimit := uint32(h.RightLimit()) * 1000
+	if milliDegrees >= leftLimit && milliDegrees <= rightLimit {
+		return tinygoerrors.ErrorCodeNil
+	}
+	milliDegrees = max(min(milliDegrees, rightLimit), leftLimit)
+
+	// Move the servo within the new limits, without initializing a deferred handler
+	if !h.isInitialized {
+		h.angle = uint16(milliDegrees / 1000)
+		h.angleFraction = 0
+		h.pulse = h.calculatePulseMilliDegrees(milliDegrees)
+		return tinygoerrors.ErrorCodeNil
+	}
+	return h.SetAngleMilliDegrees(milliDegrees)
+}
+
+// StartLimitsLearning starts the limits learning mode, used to commission newly assembled mechanisms. While it is
+// active the soft limits are lifted to the whole actuation range, so the servo can be jogged to each mechanical
+// extreme and the extreme recorded with MarkLimit
+//
+// Returns:
+//
+// An error if the PWM peripheral could not be configured
+func (h *DefaultHandler) StartLimitsLearning() tinygoerrors.ErrorCode {
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.isLearningLimits = true
+	h.learnedLimitMarks = 0
+
+	// Log the mode change if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(startLimitsLearningMessage)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// IsLearningLimits returns whether the limits learning mode is active
+//
+// Returns:
+//
+// True if the limits are being learned, false otherwise
+func (h *DefaultHandler) IsLearningLimits() bool {
+	return h.isLearningLimits
+}
+
+// Jog moves the servo motor by a number of degrees from its current angle, clamped to its limits. It is meant to be
+// driven by buttons or an encoder, particularly during the limits learning mode
+//
+// Parameters:
+//
+// deltaAngle: The number of degrees to move, negative values move towards the left limit
+//
+// Returns:
+//
+// An error if the angle could not be set
+func (h *DefaultHandler) Jog(deltaAngle int16) tinygoerrors.ErrorCode {
+	angle := int32(h.angle) + int32(deltaAngle)
+	leftLimitAngle := int32(h.LeftLimit())
+	rightLimitAngle := int32(h.RightLimit())
+	if angle < leftLimitAngle {
+		angle = leftLimitAngle
+	} else if angle > rightLimitAngle {
+		angle = rightLimitAngle
+	}
+	return h.SetAngle(uint16(angle))
+}
+
+// MarkLimit records the current angle as one of the mechanical extremes during the limits learning mode. Both
+// extremes can be marked in any order
+//
+// Returns:
+//
+// An error if the limits learning mode is not active or both extremes were already marked
+func (h *DefaultHandler) MarkLimit() tinygoerrors.ErrorCode {
+	if !h.isLearningLimits {
+		return ErrorCodeServoNotLearningLimits
+	}
+	if int(h.learnedLimitMarks) == len(h.learnedLimitAngles) {
+		return ErrorCodeServoLimitsAlreadyMarked
+	}
+	h.learnedLimitAngles[h.learnedLimitMarks] = h.angle
+	h.learnedLimitMarks++
+
+	// Log the marked limit if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.AddMessageWithUint16(markLimitPrefix, h.angle, true, true, false)
+		h.logger.Debug()
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// FinishLimitsLearning ends the limits learning mode, storing the marked extremes as the soft limits and recentering
+// the servo motor. The center angle is kept if it lies between the learned limits, otherwise it is moved to their
+// midpoint
+//
+// Returns:
+//
+// An error if the limits learning mode is not active or the two extremes haven't been marked at different angles
+func (h *DefaultHandler) FinishLimitsLearning() tinygoerrors.ErrorCode {
+	if !h.isLearningLimits {
+		return ErrorCodeServoNotLearningLimits
+	}
+	if int(h.learnedLimitMarks) != len(h.learnedLimitAngles) ||
+		h.learnedLimitAngles[0] == h.learnedLimitAngles[1] {
+		return ErrorCodeServoLimitsNotMarked
+	}
+
+	// Order the marked extremes
+	leftLimitAngle, rightLimitAngle := h.learnedLimitAngles[0], h.learnedLimitAngles[1]
+	if leftLimitAngle > rightLimitAngle {
+		leftLimitAngle, rightLimitAngle = rightLimitAngle, leftLimitAngle
+	}
+
+	// Keep the center angle within the learned limits
+	if h.centerAngle < leftLimitAngle || h.centerAngle > rightLimitAngle {
+		h.centerAngle = leftLimitAngle + (rightLimitAngle-leftLimitAngle)/2
+	}
+
+	// Store the limits and recenter the servo
+	if err := h.setLimits(leftLimitAngle, rightLimitAngle); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.isLearningLimits = false
+	return h.SetAngleToCenter()
+}
+
+// CancelLimitsLearning ends the limits learning mode keeping the previous soft limits. If the servo motor was jogged
+// outside of them, it is moved back to the closest limit
+//
+// Returns:
+//
+// An error if the angle could not be set
+func (h *DefaultHandler) CancelLimitsLearning() tinygoerrors.ErrorCode {
+	if !h.isLearningLimits {
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.isLearningLimits = false
+
+	// Log the mode change if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(cancelLimitsLearningMessage)
+	}
+	return h.Jog(0)
+}
+
+// EffectiveRange returns the number of degrees the servo motor can actually move to each side of its center, after the
+// requested limits were clamped to the actuation range and the global range cap was applied
+//
+// Returns:
+//
+// The maximum angles to the left and to the right of the center
+func (h *DefaultHandler) EffectiveRange() (uint16, uint16) {
+	lowerAngle := h.centerAngle - h.LeftLimit()
+	upperAngle := h.RightLimit() - h.centerAngle
+	if h.isDirectionInverted {
+		return upperAngle, lowerAngle
+	}
+	return lowerAngle, upperAngle
+}
+
+// LimitsWarning returns whether the limits requested to the constructor or SetCenterAngleWithLimits had to be
+// adjusted, so the caller can tell the effective range differs from the requested one
+//
+// Returns:
+//
+// ErrorCodeServoLimitsClamped if the requested limits exceeded the actuation range, or ErrorCodeNil otherwise
+func (h *DefaultHandler) LimitsWarning() tinygoerrors.ErrorCode {
+	return h.limitsWarning
+}
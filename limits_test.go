@@ -0,0 +1,110 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+)
+
+var (
+	// fullTurnProfile is a full-turn positional servo, there is no preset for one
+	fullTurnProfile = Profile{
+		Name:           "360",
+		Frequency:      50,
+		MinPulseWidth:  500000,
+		MaxPulseWidth:  2500000,
+		ActuationRange: FullActuationRange,
+	}
+)
+
+// TestDeriveLimitAngles checks the limits are clamped to the actuation range of the wide and full-turn servos, the
+// max angles larger than the distance to the ends not wrapping around
+func TestDeriveLimitAngles(t *testing.T) {
+	tests := []struct {
+		centerAngle    uint16
+		maxLowerAngle  uint16
+		maxUpperAngle  uint16
+		actuationRange uint16
+		lowerLimit     uint16
+		upperLimit     uint16
+		isClamped      bool
+	}{
+		{135, 135, 135, WideActuationRange, 0, 270, false},
+		{135, 200, 200, WideActuationRange, 0, 270, true},
+		{135, 45, 90, WideActuationRange, 90, 225, false},
+		{10, 200, 30, WideActuationRange, 0, 40, true},
+		{260, 30, 200, WideActuationRange, 230, 270, true},
+		{0, 1, 0, WideActuationRange, 0, 0, true},
+		{270, 0, 1, WideActuationRange, 270, 270, true},
+		{180, 180, 180, FullActuationRange, 0, 360, false},
+		{180, 270, 270, FullActuationRange, 0, 360, true},
+		{0, 0, 360, FullActuationRange, 0, 360, false},
+		{360, 360, 0, FullActuationRange, 0, 360, false},
+		{5, 0xFFFF, 0xFFFF, FullActuationRange, 0, 360, true},
+		{355, 10, 0xFFFF, FullActuationRange, 345, 360, true},
+	}
+	for _, test := range tests {
+		lower, upper, isClamped := deriveLimitAngles(
+			test.centerAngle,
+			test.maxLowerAngle,
+			test.maxUpperAngle,
+			test.actuationRange,
+		)
+		if lower != test.lowerLimit || upper != test.upperLimit || isClamped != test.isClamped {
+			t.Errorf(
+				"center %d, max %d and %d over %d: %d to %d clamped %v, expected %d to %d clamped %v",
+				test.centerAngle,
+				test.maxLowerAngle,
+				test.maxUpperAngle,
+				test.actuationRange,
+				lower,
+				upper,
+				isClamped,
+				test.lowerLimit,
+				test.upperLimit,
+				test.isClamped,
+			)
+		}
+	}
+}
+
+// TestWideRangeRelativeAngles moves 270 and 360 degrees servos relative to off-center centers, checking the relative
+// angles beyond the limits, the largest ones included, clamp to them in both directions
+func TestWideRangeRelativeAngles(t *testing.T) {
+	tests := []struct {
+		name          string
+		preset        Profile
+		options       []Option
+		relativeAngle int16
+		expected      uint16
+	}{
+		{"DS3225 centered", PresetDS3225, nil, 120, 255},
+		{"DS3225 to the left end", PresetDS3225, nil, -135, 0},
+		{"DS3225 beyond the left end", PresetDS3225, nil, -200, 0},
+		{"DS3225 beyond the right end", PresetDS3225, nil, 200, 270},
+		{"DS3225 low center", PresetDS3225, []Option{WithCenterAngle(20), WithLimits(200, 100)}, -100, 0},
+		{"DS3225 low center right", PresetDS3225, []Option{WithCenterAngle(20), WithLimits(200, 100)}, 150, 120},
+		{"DS3225 high center", PresetDS3225, []Option{WithCenterAngle(250), WithLimits(100, 200)}, 100, 270},
+		{"DS3225 inverted", PresetDS3225, []Option{WithInvertedDirection()}, 100, 35},
+		{"DS3225 inverted beyond", PresetDS3225, []Option{WithInvertedDirection()}, -32768, 270},
+		{"360 centered", fullTurnProfile, nil, -180, 0},
+		{"360 largest", fullTurnProfile, nil, 32767, 360},
+		{"360 smallest", fullTurnProfile, nil, -32768, 0},
+		{"360 high center", fullTurnProfile, []Option{WithCenterAngle(350), WithLimits(360, 360)}, 20, 360},
+		{"360 low center", fullTurnProfile, []Option{WithCenterAngle(10), WithLimits(360, 360)}, -20, 0},
+		{"360 inverted", fullTurnProfile, []Option{WithInvertedDirection()}, 170, 10},
+	}
+	for _, test := range tests {
+		handler, err := NewFromPreset(newFakePWM(), 0, test.preset, test.options...)
+		if err != 0 && err != ErrorCodeServoLimitsClamped {
+			t.Fatalf("%s: %d", test.name, err)
+		}
+		if err = handler.SetAngleRelativeToCenter(test.relativeAngle); err != 0 {
+			t.Errorf("%s: SetAngleRelativeToCenter: %d", test.name, err)
+		}
+		if angle := handler.GetAngle(); angle != test.expected {
+			t.Errorf("%s: angle %d, expected %d", test.name, angle, test.expected)
+		}
+		handler.Release()
+	}
+}
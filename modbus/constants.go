@@ -0,0 +1,28 @@
+package modbus
+
+const (
+	// MaxServos is the number of servos a Slave can expose
+	MaxServos = 16
+
+	// RegistersPerServo is the number of holding registers of every servo, the registers of the servo at position n
+	// start at n*RegistersPerServo
+	RegistersPerServo = 4
+
+	// MaxFrameSize is the largest Modbus RTU frame
+	MaxFrameSize = 256
+
+	// BroadcastAddress is the slave address every slave executes the write requests of, without replying
+	BroadcastAddress uint8 = 0
+
+	// MaxSlaveAddress is the highest address a slave can have
+	MaxSlaveAddress uint8 = 247
+
+	// maxReadQuantity is the largest number of registers a read request can ask for
+	maxReadQuantity = 125
+
+	// maxWriteQuantity is the largest number of registers a write multiple registers request can carry
+	maxWriteQuantity = 123
+
+	// minFrameSize is the size of the shortest valid frame: the address, the function code and the CRC
+	minFrameSize = 4
+)
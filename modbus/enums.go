@@ -0,0 +1,69 @@
+package modbus
+
+type (
+	// Register is an enum to represent the holding registers of every servo, as an offset from its first register.
+	Register uint16
+
+	// Status is a bit mask to represent the flags of the status register.
+	Status uint16
+
+	// FunctionCode is an enum to represent the Modbus functions supported by the slave.
+	FunctionCode uint8
+
+	// ExceptionCode is an enum to represent the Modbus exceptions replied by the slave.
+	ExceptionCode uint8
+)
+
+const (
+	// RegisterTargetAngle is the angle of the servo in hundredths of a degree, writing it moves the servo
+	RegisterTargetAngle Register = iota
+
+	// RegisterSpeed is the maximum speed of the servo in degrees per second, zero means uncapped. It is applied if the
+	// servo implements SpeedServo
+	RegisterSpeed
+
+	// RegisterStatus is the read-only status of the servo
+	RegisterStatus
+
+	// RegisterErrorCode is the code of the last error of the servo, writing zero clears it
+	RegisterErrorCode
+)
+
+const (
+	// StatusMovementEnabled is set while the movement of the servo is enabled
+	StatusMovementEnabled Status = 1 << iota
+
+	// StatusSleeping is set while the servo is sleeping
+	StatusSleeping
+
+	// StatusError is set while the error code register is not zero
+	StatusError
+)
+
+const (
+	// FunctionCodeReadHoldingRegisters reads a range of holding registers
+	FunctionCodeReadHoldingRegisters FunctionCode = 0x03
+
+	// FunctionCodeWriteSingleRegister writes a holding register
+	FunctionCodeWriteSingleRegister FunctionCode = 0x06
+
+	// FunctionCodeWriteMultipleRegisters writes a range of holding registers
+	FunctionCodeWriteMultipleRegisters FunctionCode = 0x10
+
+	// functionCodeExceptionFlag is set in the function code of the exception responses
+	functionCodeExceptionFlag FunctionCode = 0x80
+)
+
+const (
+	// ExceptionCodeIllegalFunction is replied to unsupported function codes
+	ExceptionCodeIllegalFunction ExceptionCode = 0x01
+
+	// ExceptionCodeIllegalDataAddress is replied to requests for registers that don't exist or are read-only
+	ExceptionCodeIllegalDataAddress ExceptionCode = 0x02
+
+	// ExceptionCodeIllegalDataValue is replied to malformed requests and invalid register values
+	ExceptionCodeIllegalDataValue ExceptionCode = 0x03
+
+	// ExceptionCodeSlaveDeviceFailure is replied when a servo rejected a command, its error code register tells why
+	ExceptionCodeSlaveDeviceFailure ExceptionCode = 0x04
+)
@@ -0,0 +1,19 @@
+package modbus
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeModbusStartNumber is the starting number for Modbus-related error codes.
+	ErrorCodeModbusStartNumber uint16 = 5560
+)
+
+const (
+	ErrorCodeModbusNilTransport tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeModbusStartNumber)
+	ErrorCodeModbusInvalidAddress
+	ErrorCodeModbusZeroSilence
+	ErrorCodeModbusNilServo
+	ErrorCodeModbusFull
+	ErrorCodeModbusTransportFailed
+)
@@ -0,0 +1,60 @@
+//go:build !tinygo
+
+package modbus
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/internal/fuzzservos"
+)
+
+type (
+	// discardTransport is a link that drops the responses
+	discardTransport struct{}
+)
+
+func (discardTransport) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+// appendFrame appends a request with its CRC to a stream
+func appendFrame(stream []byte, request ...byte) []byte {
+	crc := crc16(request)
+	return append(append(stream, request...), byte(crc), byte(crc>>8))
+}
+
+// FuzzModbusFeed feeds arbitrary byte streams to a slave exposing three servos, the line going silent every few
+// bytes, and checks no request drives a servo outside its limits nor beyond its pulse range
+func FuzzModbusFeed(f *testing.F) {
+	f.Add(appendFrame(nil, 1, byte(FunctionCodeWriteSingleRegister), 0, 0, 0x46, 0x50), uint8(0))
+	f.Add(appendFrame(nil, 1, byte(FunctionCodeWriteMultipleRegisters), 0, 4, 0, 2, 4, 0xFF, 0xFF, 0, 30), uint8(0))
+	f.Add(appendFrame(appendFrame(nil, 0, 6, 0, 8, 0x69, 0x78), 1, 3, 0, 0, 0, 12), uint8(8))
+	f.Fuzz(func(t *testing.T, data []byte, silenceEvery uint8) {
+		slave, err := NewSlave(1, discardTransport{}, 2)
+		if err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("NewSlave: %d", err)
+		}
+		servos := fuzzservos.New(t)
+		for index, handler := range servos.Handlers {
+			if err = slave.AddServo(handler); err != tinygoerrors.ErrorCodeNil {
+				t.Fatalf("AddServo %d: %d", index, err)
+			}
+		}
+
+		// Feed a byte every millisecond, the line going silent long enough to end the frame every few bytes
+		nowMs := uint32(0)
+		for index, value := range data {
+			if silenceEvery != 0 && index%int(silenceEvery) == 0 {
+				nowMs += 2
+			}
+			slave.Feed(value, nowMs)
+			nowMs++
+			_ = slave.Update(nowMs)
+			servos.Update(t, nowMs)
+		}
+		_ = slave.Update(nowMs + 2)
+
+		servos.CheckPulses(t)
+	})
+}
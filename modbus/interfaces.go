@@ -0,0 +1,27 @@
+package modbus
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the slave, so the registers can expose
+	// any servo driver, or the fakes of the servotest package
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		GetAngleMilliDegrees() uint32
+		IsMovementEnabled() bool
+		IsSleeping() bool
+	}
+
+	// SpeedServo is implemented by the servos whose speed can be capped through the speed register
+	SpeedServo interface {
+		SetMaxSpeed(degreesPerSecond uint16) tinygoerrors.ErrorCode
+	}
+
+	// Transport is the RS-485 link the responses are written to. It is satisfied by the TinyGo UART, the direction of
+	// the transceiver must be switched by the application if it isn't automatic
+	Transport interface {
+		Write(data []byte) (int, error)
+	}
+)
@@ -0,0 +1,372 @@
+package modbus
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// slaveServo is a servo exposed by the slave with its stored registers
+	slaveServo struct {
+		servo     Servo
+		speed     uint16
+		errorCode tinygoerrors.ErrorCode
+	}
+
+	// Slave is a Modbus RTU slave exposing the holding registers of its servos over an RS-485 link, so PLCs and other
+	// industrial tooling can command them. The received bytes are fed from the main loop, and the frames end after a
+	// silence on the line, so Update must be called often
+	Slave struct {
+		address        uint8
+		transport      Transport
+		silenceMs      uint32
+		servos         [MaxServos]slaveServo
+		servosCount    int
+		request        [MaxFrameSize]byte
+		requestSize    int
+		isOverflowed   bool
+		lastByteMs     uint32
+		response       [MaxFrameSize]byte
+		invalidFrames  uint32
+		failedWrites   uint32
+		handledFrames  uint32
+		exceptionCount uint32
+	}
+)
+
+// NewSlave creates a new instance of Slave
+//
+// Parameters:
+//
+// address: The slave address, between 1 and MaxSlaveAddress
+// transport: The link the responses are written to
+// silenceMs: The silence on the line in milliseconds that ends a frame, at least the time of 3.5 characters at the baud
+// rate of the link rounded up
+//
+// Returns:
+//
+// An instance of Slave and an error if any parameter is invalid
+func NewSlave(address uint8, transport Transport, silenceMs uint32) (*Slave, tinygoerrors.ErrorCode) {
+	if address == BroadcastAddress || address > MaxSlaveAddress {
+		return nil, ErrorCodeModbusInvalidAddress
+	}
+	if transport == nil {
+		return nil, ErrorCodeModbusNilTransport
+	}
+	if silenceMs == 0 {
+		return nil, ErrorCodeModbusZeroSilence
+	}
+	return &Slave{
+		address:   address,
+		transport: transport,
+		silenceMs: silenceMs,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// AddServo exposes a servo, its registers follow the ones of the servos added before it
+//
+// Parameters:
+//
+// servo: The servo
+//
+// Returns:
+//
+// An error if the servo is nil or the slave is full
+func (s *Slave) AddServo(servo Servo) tinygoerrors.ErrorCode {
+	if servo == nil {
+		return ErrorCodeModbusNilServo
+	}
+	if s.servosCount == MaxServos {
+		return ErrorCodeModbusFull
+	}
+	s.servos[s.servosCount] = slaveServo{servo: servo}
+	s.servosCount++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Feed adds a byte received from the link to the current frame
+//
+// Parameters:
+//
+// value: The received byte
+// nowMs: The current time in milliseconds
+func (s *Slave) Feed(value byte, nowMs uint32) {
+	// End the previous frame if the line was silent long enough, in case Update wasn't called in between
+	if s.requestSize > 0 && nowMs-s.lastByteMs >= s.silenceMs {
+		s.Update(nowMs)
+	}
+	s.lastByteMs = nowMs
+
+	// Drop the frames longer than the largest valid frame
+	if s.requestSize == MaxFrameSize {
+		s.isOverflowed = true
+		return
+	}
+	s.request[s.requestSize] = value
+	s.requestSize++
+}
+
+// Update ends the current frame once the line has been silent long enough, handling it and writing its response
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+//
+// Returns:
+//
+// An error if the response couldn't be written
+func (s *Slave) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if s.requestSize == 0 || nowMs-s.lastByteMs < s.silenceMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+	frame := s.request[:s.requestSize]
+	isOverflowed := s.isOverflowed
+	s.requestSize = 0
+	s.isOverflowed = false
+
+	// Discard the frames that are truncated, too long or corrupted
+	if isOverflowed || len(frame) < minFrameSize {
+		s.invalidFrames++
+		return tinygoerrors.ErrorCodeNil
+	}
+	payloadSize := len(frame) - 2
+	if crc16(frame[:payloadSize]) != uint16(frame[payloadSize])|uint16(frame[payloadSize+1])<<8 {
+		s.invalidFrames++
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Ignore the frames for other slaves
+	address := frame[0]
+	if address != s.address && address != BroadcastAddress {
+		return tinygoerrors.ErrorCodeNil
+	}
+	s.handledFrames++
+	size := s.handle(frame[:payloadSize])
+
+	// The broadcast requests are not replied
+	if address == BroadcastAddress || size == 0 {
+		return tinygoerrors.ErrorCodeNil
+	}
+	crc := crc16(s.response[:size])
+	s.response[size] = byte(crc)
+	s.response[size+1] = byte(crc >> 8)
+	if _, err := s.transport.Write(s.response[:size+2]); err != nil {
+		s.failedWrites++
+		return ErrorCodeModbusTransportFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// InvalidFrames returns the number of frames discarded because they were truncated, too long or corrupted
+//
+// Returns:
+//
+// The number of invalid frames
+func (s *Slave) InvalidFrames() uint32 {
+	return s.invalidFrames
+}
+
+// HandledFrames returns the number of valid frames addressed to this slave or broadcast
+//
+// Returns:
+//
+// The number of handled frames
+func (s *Slave) HandledFrames() uint32 {
+	return s.handledFrames
+}
+
+// Exceptions returns the number of requests replied with an exception
+//
+// Returns:
+//
+// The number of exceptions
+func (s *Slave) Exceptions() uint32 {
+	return s.exceptionCount
+}
+
+// FailedWrites returns the number of responses that couldn't be written to the link
+//
+// Returns:
+//
+// The number of failed writes
+func (s *Slave) FailedWrites() uint32 {
+	return s.failedWrites
+}
+
+// handle runs a request and writes its response without the CRC
+//
+// Parameters:
+//
+// request: The request without the CRC
+//
+// Returns:
+//
+// The size of the response
+func (s *Slave) handle(request []byte) int {
+	s.response[0] = request[0]
+	s.response[1] = request[1]
+	function := FunctionCode(request[1])
+
+	switch function {
+	case FunctionCodeReadHoldingRegisters:
+		if len(request) != 6 {
+			return s.exception(function, ExceptionCodeIllegalDataValue)
+		}
+		start := decodeUint16(request[2:])
+		quantity := decodeUint16(request[4:])
+		if quantity == 0 || quantity > maxReadQuantity {
+			return s.exception(function, ExceptionCodeIllegalDataValue)
+		}
+		if !s.hasRegisters(start, quantity) {
+			return s.exception(function, ExceptionCodeIllegalDataAddress)
+		}
+		s.response[2] = byte(quantity * 2)
+		for offset := uint16(0); offset < quantity; offset++ {
+			encodeUint16(s.response[3+offset*2:], s.readRegister(start+offset))
+		}
+		return 3 + int(quantity)*2
+
+	case FunctionCodeWriteSingleRegister:
+		if len(request) != 6 {
+			return s.exception(function, ExceptionCodeIllegalDataValue)
+		}
+		register := decodeUint16(request[2:])
+		if !s.hasRegisters(register, 1) {
+			return s.exception(function, ExceptionCodeIllegalDataAddress)
+		}
+		if exception := s.writeRegister(register, decodeUint16(request[4:])); exception != 0 {
+			return s.exception(function, exception)
+		}
+
+		// The response echoes the request
+		copy(s.response[2:6], request[2:6])
+		return 6
+
+	case FunctionCodeWriteMultipleRegisters:
+		if len(request) < 7 {
+			return s.exception(function, ExceptionCodeIllegalDataValue)
+		}
+		start := decodeUint16(request[2:])
+		quantity := decodeUint16(request[4:])
+		byteCount := int(request[6])
+		if quantity == 0 || quantity > maxWriteQuantity || byteCount != int(quantity)*2 || len(request) != 7+byteCount {
+			return s.exception(function, ExceptionCodeIllegalDataValue)
+		}
+		if !s.hasRegisters(start, quantity) {
+			return s.exception(function, ExceptionCodeIllegalDataAddress)
+		}
+
+		// Write the registers in order, stopping at the first rejected one
+		for offset := uint16(0); offset < quantity; offset++ {
+			value := decodeUint16(request[7+offset*2:])
+			if exception := s.writeRegister(start+offset, value); exception != 0 {
+				return s.exception(function, exception)
+			}
+		}
+		copy(s.response[2:6], request[2:6])
+		return 6
+
+	default:
+		return s.exception(function, ExceptionCodeIllegalFunction)
+	}
+}
+
+// exception writes an exception response without the CRC
+//
+// Parameters:
+//
+// function: The function code of the request
+// exception: The exception code
+//
+// Returns:
+//
+// The size of the response
+func (s *Slave) exception(function FunctionCode, exception ExceptionCode) int {
+	s.exceptionCount++
+	s.response[1] = byte(function | functionCodeExceptionFlag)
+	s.response[2] = byte(exception)
+	return 3
+}
+
+// hasRegisters checks if a range of registers exists
+//
+// Parameters:
+//
+// start: The first register
+// quantity: The number of registers
+//
+// Returns:
+//
+// True if every register belongs to a servo of the slave, false otherwise
+func (s *Slave) hasRegisters(start uint16, quantity uint16) bool {
+	return uint32(start)+uint32(quantity) <= uint32(s.servosCount)*RegistersPerServo
+}
+
+// readRegister reads a holding register
+//
+// Parameters:
+//
+// address: The address of the register, that must exist
+//
+// Returns:
+//
+// The value of the register
+func (s *Slave) readRegister(address uint16) uint16 {
+	entry := &s.servos[address/RegistersPerServo]
+	switch Register(address % RegistersPerServo) {
+	case RegisterTargetAngle:
+		return uint16(entry.servo.GetAngleMilliDegrees() / 10)
+	case RegisterSpeed:
+		return entry.speed
+	case RegisterStatus:
+		var status Status
+		if entry.servo.IsMovementEnabled() {
+			status |= StatusMovementEnabled
+		}
+		if entry.servo.IsSleeping() {
+			status |= StatusSleeping
+		}
+		if entry.errorCode != tinygoerrors.ErrorCodeNil {
+			status |= StatusError
+		}
+		return uint16(status)
+	default:
+		return uint16(entry.errorCode)
+	}
+}
+
+// writeRegister writes a holding register, commanding the servo if needed
+//
+// Parameters:
+//
+// address: The address of the register, that must exist
+// value: The value to write
+//
+// Returns:
+//
+// The exception to reply, or zero if the register was written
+func (s *Slave) writeRegister(address uint16, value uint16) ExceptionCode {
+	entry := &s.servos[address/RegistersPerServo]
+	switch Register(address % RegistersPerServo) {
+	case RegisterTargetAngle:
+		if err := entry.servo.SetAngleMilliDegrees(uint32(value) * 10); err != tinygoerrors.ErrorCodeNil {
+			entry.errorCode = err
+			return ExceptionCodeSlaveDeviceFailure
+		}
+	case RegisterSpeed:
+		if speedServo, ok := entry.servo.(SpeedServo); ok {
+			if err := speedServo.SetMaxSpeed(value); err != tinygoerrors.ErrorCodeNil {
+				entry.errorCode = err
+				return ExceptionCodeSlaveDeviceFailure
+			}
+		}
+		entry.speed = value
+	case RegisterStatus:
+		return ExceptionCodeIllegalDataAddress
+	default:
+		if value != 0 {
+			return ExceptionCodeIllegalDataValue
+		}
+		entry.errorCode = tinygoerrors.ErrorCodeNil
+	}
+	return 0
+}
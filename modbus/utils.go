@@ -0,0 +1,50 @@
+package modbus
+
+// crc16 calculates the Modbus CRC-16 of the given data, with the reflected polynomial 0xA001 and an initial value of
+// 0xFFFF
+//
+// Parameters:
+//
+// data: The data to calculate the CRC of
+//
+// Returns:
+//
+// The CRC of the data
+func crc16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, value := range data {
+		crc ^= uint16(value)
+		for bit := 0; bit < 8; bit++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// decodeUint16 reads a value in big-endian order, the byte order of the Modbus registers
+//
+// Parameters:
+//
+// buffer: The buffer to read from, at least 2 bytes long
+//
+// Returns:
+//
+// The value read
+func decodeUint16(buffer []byte) uint16 {
+	return uint16(buffer[0])<<8 | uint16(buffer[1])
+}
+
+// encodeUint16 writes a value in big-endian order, the byte order of the Modbus registers
+//
+// Parameters:
+//
+// buffer: The buffer to write to, at least 2 bytes long
+// value: The value to write
+func encodeUint16(buffer []byte, value uint16) {
+	buffer[0] = byte(value >> 8)
+	buffer[1] = byte(value)
+}
@@ -0,0 +1,9 @@
+package mode
+
+const (
+	// MaxStates is the number of states a Manager can handle, including the predefined ones
+	MaxStates = 16
+
+	// MaxTransitions is the number of transitions a Manager can hold
+	MaxTransitions = 32
+)
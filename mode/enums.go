@@ -0,0 +1,24 @@
+package mode
+
+type (
+	// State is an enum to represent the states of a servo application. Applications can define their own states after
+	// the predefined ones, up to MaxStates
+	State uint8
+)
+
+const (
+	// StateIdle is the state where the servos are not driven
+	StateIdle State = iota
+
+	// StateArmed is the state where the servos are driven and waiting for a command
+	StateArmed
+
+	// StateRunning is the state where the application moves the servos
+	StateRunning
+
+	// StateFault is the state entered when an action fails or Fault is called, it can always be entered
+	StateFault
+
+	// StateUser is the first state available to the applications
+	StateUser
+)
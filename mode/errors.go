@@ -0,0 +1,17 @@
+package mode
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeModeStartNumber is the starting number for mode manager related error codes.
+	ErrorCodeModeStartNumber uint16 = 5480
+)
+
+const (
+	ErrorCodeModeInvalidState tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeModeStartNumber)
+	ErrorCodeModeTooManyTransitions
+	ErrorCodeModeUnknownTransition
+	ErrorCodeModeTransitionRejected
+)
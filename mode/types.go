@@ -0,0 +1,224 @@
+package mode
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// stateActions are the actions run when a state is entered or exited
+	stateActions struct {
+		onEntry func() tinygoerrors.ErrorCode
+		onExit  func() tinygoerrors.ErrorCode
+	}
+
+	// transition is an allowed change between two states
+	transition struct {
+		from  State
+		to    State
+		guard func() bool
+	}
+
+	// Manager is a finite state machine for servo applications, with per-state entry and exit actions and guarded
+	// transitions. A failing action moves the machine to StateFault
+	Manager struct {
+		state            State
+		previousState    State
+		actions          [MaxStates]stateActions
+		transitions      [MaxTransitions]transition
+		transitionsCount int
+		faultErr         tinygoerrors.ErrorCode
+	}
+)
+
+// NewManager creates a new instance of Manager
+//
+// Parameters:
+//
+// initialState: The state the machine starts in, its entry action is not run
+//
+// Returns:
+//
+// An instance of Manager and an error if the initial state is out of range
+func NewManager(initialState State) (*Manager, tinygoerrors.ErrorCode) {
+	if initialState >= MaxStates {
+		return nil, ErrorCodeModeInvalidState
+	}
+	return &Manager{
+		state:         initialState,
+		previousState: initialState,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetActions sets the actions run when a state is entered or exited, e.g. centering or disabling the servos
+//
+// Parameters:
+//
+// state: The state the actions belong to
+// onEntry: The action run when the state is entered, or nil
+// onExit: The action run when the state is exited, or nil
+//
+// Returns:
+//
+// An error if the state is out of range
+func (m *Manager) SetActions(
+	state State,
+	onEntry func() tinygoerrors.ErrorCode,
+	onExit func() tinygoerrors.ErrorCode,
+) tinygoerrors.ErrorCode {
+	if state >= MaxStates {
+		return ErrorCodeModeInvalidState
+	}
+	m.actions[state] = stateActions{
+		onEntry: onEntry,
+		onExit:  onExit,
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// AddTransition allows changing from a state to another one
+//
+// Parameters:
+//
+// from: The state the transition starts from
+// to: The state the transition ends in
+// guard: The condition checked before the transition, or nil to always allow it
+//
+// Returns:
+//
+// An error if any state is out of range or there is no room for more transitions
+func (m *Manager) AddTransition(from State, to State, guard func() bool) tinygoerrors.ErrorCode {
+	if from >= MaxStates || to >= MaxStates {
+		return ErrorCodeModeInvalidState
+	}
+	if m.transitionsCount == MaxTransitions {
+		return ErrorCodeModeTooManyTransitions
+	}
+	m.transitions[m.transitionsCount] = transition{
+		from:  from,
+		to:    to,
+		guard: guard,
+	}
+	m.transitionsCount++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Transition changes to another state, running the exit action of the current state and the entry action of the new
+// one
+//
+// Parameters:
+//
+// to: The state to change to
+//
+// Returns:
+//
+// An error if the transition isn't allowed, its guard rejected it or an action failed, in which case the machine is
+// in StateFault
+func (m *Manager) Transition(to State) tinygoerrors.ErrorCode {
+	// Find the transition
+	var found *transition
+	for index := 0; index < m.transitionsCount; index++ {
+		if m.transitions[index].from == m.state && m.transitions[index].to == to {
+			found = &m.transitions[index]
+			break
+		}
+	}
+	if found == nil {
+		return ErrorCodeModeUnknownTransition
+	}
+
+	// Check the guard
+	if found.guard != nil && !found.guard() {
+		return ErrorCodeModeTransitionRejected
+	}
+	return m.enter(to)
+}
+
+// Fault changes to StateFault whatever the current state, e.g. when a servo reports an error
+//
+// Parameters:
+//
+// err: The error that caused the fault, available through FaultError
+func (m *Manager) Fault(err tinygoerrors.ErrorCode) {
+	if m.state == StateFault {
+		return
+	}
+	m.faultErr = err
+	if enterErr := m.enter(StateFault); enterErr != tinygoerrors.ErrorCodeNil {
+		m.faultErr = enterErr
+	}
+}
+
+// enter runs the exit action of the current state and the entry action of the new one, faulting if any of them fails
+//
+// Parameters:
+//
+// to: The state to change to
+//
+// Returns:
+//
+// The error of the failing action, if any
+func (m *Manager) enter(to State) tinygoerrors.ErrorCode {
+	// Run the exit action of the current state
+	if onExit := m.actions[m.state].onExit; onExit != nil {
+		if err := onExit(); err != tinygoerrors.ErrorCodeNil {
+			m.fault(err)
+			return err
+		}
+	}
+
+	// Change the state and run its entry action
+	m.previousState = m.state
+	m.state = to
+	if onEntry := m.actions[to].onEntry; onEntry != nil {
+		if err := onEntry(); err != tinygoerrors.ErrorCodeNil {
+			m.fault(err)
+			return err
+		}
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// fault changes to StateFault after a failing action, running its entry action but ignoring its errors to avoid
+// faulting recursively
+//
+// Parameters:
+//
+// err: The error of the failing action
+func (m *Manager) fault(err tinygoerrors.ErrorCode) {
+	m.faultErr = err
+	if m.state == StateFault {
+		return
+	}
+	m.previousState = m.state
+	m.state = StateFault
+	if onEntry := m.actions[StateFault].onEntry; onEntry != nil {
+		_ = onEntry()
+	}
+}
+
+// State returns the current state
+//
+// Returns:
+//
+// The current state
+func (m *Manager) State() State {
+	return m.state
+}
+
+// PreviousState returns the state before the last change
+//
+// Returns:
+//
+// The previous state
+func (m *Manager) PreviousState() State {
+	return m.previousState
+}
+
+// FaultError returns the error that caused the last fault
+//
+// Returns:
+//
+// The error passed to Fault or returned by the failing action, or ErrorCodeNil if the machine never faulted
+func (m *Manager) FaultError() tinygoerrors.ErrorCode {
+	return m.faultErr
+}
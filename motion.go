@@ -0,0 +1,127 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// MoveTo moves the servo motor to an angle over a duration, interpolating the angle from Update, so smooth motions
+// don't need goroutines or blocking sleeps. A new angle command replaces the move in progress
+//
+// Parameters:
+//
+// angle: The absolute target angle, must be between the left and right limits
+// durationMs: The duration of the move in milliseconds, zero to jump to the angle
+//
+// Returns:
+//
+// An error if the angle is out of range, the command is discarded or the servo motor could not be initialized
+func (h *DefaultHandler) MoveTo(angle uint16, durationMs uint32) tinygoerrors.ErrorCode {
+	return h.MoveToMilliDegrees(uint32(angle)*1000, durationMs)
+}
+
+// MoveToMilliDegrees moves the servo motor to an angle in millidegrees over a duration, see MoveTo
+//
+// Parameters:
+//
+// milliDegrees: The absolute target angle in millidegrees, must be between the left and right limits
+// durationMs: The duration of the move in milliseconds, zero to jump to the angle
+//
+// Returns:
+//
+// An error if the angle is out of range, the command is discarded or the servo motor could not be initialized
+func (h *DefaultHandler) MoveToMilliDegrees(milliDegrees uint32, durationMs uint32) tinygoerrors.ErrorCode {
+	return h.MoveToMilliDegreesWithEasing(milliDegrees, durationMs, EasingLinear)
+}
+
+// MoveToWithEasing moves the servo motor to an angle over a duration following an easing curve, so camera pans and
+// animatronics start and stop smoothly instead of looking robotic, see MoveTo
+//
+// Parameters:
+//
+// angle: The absolute target angle, must be between the left and right limits
+// durationMs: The duration of the move in milliseconds, zero to jump to the angle
+// easing: The progress curve of the move
+//
+// Returns:
+//
+// An error if the easing is unknown, the angle is out of range, the command is discarded or the servo motor could
+// not be initialized
+func (h *DefaultHandler) MoveToWithEasing(angle uint16, durationMs uint32, easing Easing) tinygoerrors.ErrorCode {
+	return h.MoveToMilliDegreesWithEasing(uint32(angle)*1000, durationMs, easing)
+}
+
+// MoveToMilliDegreesWithEasing moves the servo motor to an angle in millidegrees over a duration following an easing
+// curve, see MoveToWithEasing
+//
+// Parameters:
+//
+// milliDegrees: The absolute target angle in millidegrees, must be between the left and right limits
+// durationMs: The duration of the move in milliseconds, zero to jump to the angle
+// easing: The progress curve of the move
+//
+// Returns:
+//
+// An error if the easing is unknown, the angle is out of range, the command is discarded or the servo motor could
+// not be initialized
+func (h *DefaultHandler) MoveToMilliDegreesWithEasing(
+	milliDegrees uint32,
+	durationMs uint32,
+	easing Easing,
+) tinygoerrors.ErrorCode {
+	if !easing.IsValid() {
+		return h.reportError(ErrorCodeServoUnknownEasing)
+	}
+	if durationMs == 0 {
+		return h.SetAngleMilliDegrees(milliDegrees)
+	}
+
+	// Check if the angle is within the valid range
+	if milliDegrees < uint32(h.LeftLimit())*1000 || milliDegrees > uint32(h.RightLimit())*1000 {
+		return h.reportError(ErrorCodeServoAngleOutOfRange)
+	}
+
+	// Configure the PWM on the first command if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the command must be discarded because movement is disabled
+	if !h.IsMovementEnabled() && h.disabledCommandPolicy == DisabledCommandPolicyDiscard {
+		return h.reportError(ErrorCodeServoMovementDisabled)
+	}
+
+	// Start the move from the current angle at the last update, replacing any other move
+	h.StopMove()
+	h.moveFrom = h.GetAngleMilliDegrees()
+	h.moveTo = milliDegrees
+	h.moveStartMs = h.lastUpdateMs
+	h.moveDurationMs = durationMs
+	h.moveEasing = easing
+	h.hasTimedMove = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// updateTimedMove interpolates the angle of the timed move in progress
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateTimedMove(nowMs uint32) {
+	if !h.hasTimedMove {
+		return
+	}
+
+	// Finish the move once its duration elapsed
+	elapsedMs := nowMs - h.moveStartMs
+	if elapsedMs >= h.moveDurationMs {
+		h.hasTimedMove = false
+		_ = h.commandAngle(h.moveTo)
+		return
+	}
+
+	// Interpolate between the start and the target angles along the easing curve
+	progress := Ease(h.moveEasing, uint32(uint64(elapsedMs)*EasingScale/uint64(h.moveDurationMs)))
+	delta := int64(h.moveTo) - int64(h.moveFrom)
+	offset := delta * int64(progress) / EasingScale
+	_ = h.commandAngle(uint32(int64(h.moveFrom) + offset))
+}
@@ -0,0 +1,349 @@
+package tinygo_servo
+
+import (
+	"math"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// profileMaxStepMs is the longest integration step of the profiled moves, longer updates are split so the
+	// servo stops at the target with a slow main loop
+	profileMaxStepMs = 5
+
+	// profileMaxElapsedMs is the longest time integrated by a single update, so a stalled main loop doesn't make the
+	// servo catch up in a burst of steps
+	profileMaxElapsedMs = 100
+)
+
+// SetMotionProfile sets the limits of the profiled moves, which accelerate, cruise and decelerate instead of starting
+// and stopping at full speed, reducing the gear wear on heavy loads. The speed caps, if lower, take precedence over
+// the max velocity
+//
+// Parameters:
+//
+// maxVelocity: The cruise velocity in degrees per second
+// maxAcceleration: The acceleration and deceleration in degrees per second squared
+//
+// Returns:
+//
+// An error if the velocity or the acceleration are not positive
+func (h *DefaultHandler) SetMotionProfile(maxVelocity float32, maxAcceleration float32) tinygoerrors.ErrorCode {
+	if maxVelocity <= 0 || maxAcceleration <= 0 {
+		return ErrorCodeServoInvalidMotionProfile
+	}
+	h.profileVelocityCap = maxVelocity * 1000
+	h.profileAcceleration = maxAcceleration * 1000
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetMotionProfileJerk sets the jerk limit of the profiled moves, turning the trapezoidal velocity profile into an
+// S-curve where the acceleration ramps up and down instead of stepping, e.g. for camera sliders where acceleration
+// steps cause visible vibration. The S-curve moves are planned from rest to rest, so a profiled move started during
+// one of them starts once it stops, keeping the motion jerk-limited
+//
+// Parameters:
+//
+// maxJerk: The jerk limit in degrees per second cubed, 0 to go back to the trapezoidal profile
+//
+// Returns:
+//
+// An error if the jerk is negative
+func (h *DefaultHandler) SetMotionProfileJerk(maxJerk float32) tinygoerrors.ErrorCode {
+	if maxJerk < 0 {
+		return ErrorCodeServoInvalidMotionProfile
+	}
+	h.profileJerk = maxJerk * 1000
+	return tinygoerrors.ErrorCodeNil
+}
+
+// MoveToProfiled moves the servo motor to an angle following a trapezoidal velocity profile driven by Update. A new
+// profiled move started during another one keeps the current velocity, other angle commands replace the move
+//
+// Parameters:
+//
+// angle: The absolute target angle, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the motion profile is not set, the angle is out of range, the command is discarded or the servo motor
+// could not be initialized
+func (h *DefaultHandler) MoveToProfiled(angle uint16) tinygoerrors.ErrorCode {
+	return h.MoveToProfiledMilliDegrees(uint32(angle) * 1000)
+}
+
+// MoveToProfiledMilliDegrees moves the servo motor to an angle in millidegrees following a trapezoidal velocity
+// profile, see MoveToProfiled
+//
+// Parameters:
+//
+// milliDegrees: The absolute target angle in millidegrees, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the motion profile is not set, the angle is out of range, the command is discarded or the servo motor
+// could not be initialized
+func (h *DefaultHandler) MoveToProfiledMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	if h.profileVelocityCap == 0 {
+		return h.reportError(ErrorCodeServoInvalidMotionProfile)
+	}
+
+	// Check if the angle is within the valid range
+	if milliDegrees < uint32(h.LeftLimit())*1000 || milliDegrees > uint32(h.RightLimit())*1000 {
+		return h.reportError(ErrorCodeServoAngleOutOfRange)
+	}
+
+	// Configure the PWM on the first command if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the command must be discarded because movement is disabled
+	if !h.IsMovementEnabled() && h.disabledCommandPolicy == DisabledCommandPolicyDiscard {
+		return h.reportError(ErrorCodeServoMovementDisabled)
+	}
+
+	// Start at rest from the current angle, unless a profiled move is in progress
+	if !h.hasProfiledMove {
+		h.StopMove()
+		h.profilePosition = float32(h.GetAngleMilliDegrees())
+		h.profileVelocity = 0
+		h.profileTimestampMs = h.lastUpdateMs
+		h.hasProfiledMove = true
+		h.profileTarget = milliDegrees
+		h.isJerkLimitedMove = h.profileJerk != 0
+		if h.isJerkLimitedMove {
+			h.planJerkLimitedMove()
+		}
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.profileTarget = milliDegrees
+	return tinygoerrors.ErrorCodeNil
+}
+
+// profileCruiseVelocity returns the cruise velocity of the profiled moves
+//
+// Returns:
+//
+// The lowest of the profile velocity, the speed caps and the speed of the slow zone the move is within, in
+// millidegrees per second
+func (h *DefaultHandler) profileCruiseVelocity() float32 {
+	velocity := h.profileVelocityCap
+	if speed := float32(uint32(h.effectiveMaxSpeed()) * 1000); speed != 0 && speed < velocity {
+		velocity = speed
+	}
+	position := uint32(h.profilePosition + 0.5)
+	if _, speed := h.limitSlowZoneStep(position, h.profileTarget, 0); speed != 0 && float32(speed)*1000 < velocity {
+		velocity = float32(speed) * 1000
+	}
+	return velocity
+}
+
+// limitProfileSlowZone stops the profiled move at the edge of the slow zone of the limit it heads towards if it
+// stepped into it, so the next steps are capped by profileCruiseVelocity
+//
+// Parameters:
+//
+// from: The position before the step in millidegrees
+//
+// Returns:
+//
+// True if the move was stopped at the edge of the zone, false otherwise
+func (h *DefaultHandler) limitProfileSlowZone(from float32) bool {
+	position := uint32(h.profilePosition + 0.5)
+	edge, _ := h.limitSlowZoneStep(uint32(from+0.5), position, 0)
+	if edge == position {
+		return false
+	}
+	h.profilePosition = float32(edge)
+	speed := float32(uint32(h.slowZoneSpeed) * 1000)
+	if h.profileVelocity > speed {
+		h.profileVelocity = speed
+	} else if h.profileVelocity < -speed {
+		h.profileVelocity = -speed
+	}
+	h.hasProfiledMove = true
+	return true
+}
+
+// updateProfiledMove advances the profiled move in progress
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateProfiledMove(nowMs uint32) {
+	if !h.hasProfiledMove {
+		return
+	}
+	elapsedMs := nowMs - h.profileTimestampMs
+	h.profileTimestampMs = nowMs
+	if elapsedMs > profileMaxElapsedMs {
+		elapsedMs = profileMaxElapsedMs
+	}
+
+	// Stop at the slow zone of the limit the move heads towards, the S-curve moves are planned again from its edge
+	if h.isJerkLimitedMove {
+		from := h.profilePosition
+		h.updateJerkLimitedMove(elapsedMs)
+		if h.limitProfileSlowZone(from) {
+			h.planJerkLimitedMove()
+		}
+	} else {
+		target := float32(h.profileTarget)
+		for elapsedMs > 0 && h.hasProfiledMove {
+			stepMs := min(elapsedMs, profileMaxStepMs)
+			elapsedMs -= stepMs
+			from := h.profilePosition
+			h.stepProfile(target, h.profileCruiseVelocity(), float32(stepMs)/1000)
+			h.limitProfileSlowZone(from)
+		}
+	}
+
+	// Move the servo motor to the rounded position
+	position := uint32(h.profilePosition + 0.5)
+	_ = h.setAngleWithPulse(position, h.calculatePulseMilliDegrees(position))
+}
+
+// stepProfile integrates the trapezoidal velocity profile over a step
+//
+// Parameters:
+//
+// target: The target angle in millidegrees
+// maxVelocity: The cruise velocity in millidegrees per second
+// dt: The duration of the step in seconds
+func (h *DefaultHandler) stepProfile(target float32, maxVelocity float32, dt float32) {
+	distance := target - h.profilePosition
+	direction := float32(1)
+	if distance < 0 {
+		direction = -1
+	}
+	speed := h.profileVelocity * direction
+	deltaVelocity := h.profileAcceleration * dt
+
+	// Accelerate towards the target until the distance left is the stopping distance, then decelerate
+	stoppingDistance := speed * speed / (2 * h.profileAcceleration)
+	if speed > 0 && stoppingDistance >= distance*direction {
+		speed = max(speed-deltaVelocity, 0)
+	} else {
+		speed = min(speed+deltaVelocity, maxVelocity)
+	}
+	speed = min(speed, maxVelocity)
+	h.profileVelocity = speed * direction
+	h.profilePosition += h.profileVelocity * dt
+
+	// Finish once the target is reached or passed while stopping
+	if (target-h.profilePosition)*direction <= 0 || (speed == 0 && distance*direction < 1) {
+		h.profilePosition = target
+		h.profileVelocity = 0
+		h.hasProfiledMove = false
+	}
+}
+
+// jerkLimitedAccelTime returns the time the S-curve profile takes to accelerate from rest to a velocity
+//
+// Parameters:
+//
+// velocity: The velocity in millidegrees per second
+//
+// Returns:
+//
+// The acceleration time in seconds
+func (h *DefaultHandler) jerkLimitedAccelTime(velocity float32) float32 {
+	if velocity >= h.profileAcceleration*h.profileAcceleration/h.profileJerk {
+		return velocity/h.profileAcceleration + h.profileAcceleration/h.profileJerk
+	}
+	return 2 * float32(math.Sqrt(float64(velocity/h.profileJerk)))
+}
+
+// planJerkLimitedMove plans the S-curve move from the current position to the target, lowering the peak velocity if
+// the move is too short to reach the cruise velocity
+func (h *DefaultHandler) planJerkLimitedMove() {
+	h.profileFrom = h.profilePosition
+	h.profileDistance = float32(h.profileTarget) - h.profileFrom
+	h.profileElapsed = 0
+	distance := h.profileDistance
+	if distance < 0 {
+		distance = -distance
+	}
+
+	// Find the peak velocity, the accelerations reach the max acceleration only on the longer moves
+	velocity := h.profileCruiseVelocity()
+	acceleration := h.profileAcceleration
+	rampTime := acceleration / h.profileJerk
+	if velocity*h.jerkLimitedAccelTime(velocity) > distance {
+		velocity = acceleration / 2 * (float32(math.Sqrt(float64(rampTime*rampTime+4*distance/acceleration))) - rampTime)
+		if velocity < acceleration*rampTime {
+			velocity = float32(math.Cbrt(float64(distance * distance * h.profileJerk / 4)))
+		}
+	}
+	h.profilePeakVelocity = velocity
+	h.profileAccelTime = h.jerkLimitedAccelTime(velocity)
+	h.profileJerkTime = min(rampTime, h.profileAccelTime/2)
+	h.profileCruiseTime = 0
+	if velocity > 0 {
+		h.profileCruiseTime = max(distance/velocity-h.profileAccelTime, 0)
+	}
+}
+
+// jerkLimitedRampTravel returns the distance travelled by the S-curve profile while accelerating from rest
+//
+// Parameters:
+//
+// t: The time since the acceleration started in seconds, up to the acceleration time
+//
+// Returns:
+//
+// The travelled distance in millidegrees
+func (h *DefaultHandler) jerkLimitedRampTravel(t float32) float32 {
+	// The velocity is symmetric around the middle of the acceleration
+	if t > h.profileAccelTime/2 {
+		return h.profilePeakVelocity*(t-h.profileAccelTime/2) + h.jerkLimitedRampTravel(h.profileAccelTime-t)
+	}
+	if t <= h.profileJerkTime {
+		return h.profileJerk * t * t * t / 6
+	}
+
+	// Accelerate at the max acceleration after the jerk ramp
+	acceleration := h.profileJerk * h.profileJerkTime
+	rampTravel := acceleration * h.profileJerkTime * h.profileJerkTime / 6
+	rampVelocity := acceleration * h.profileJerkTime / 2
+	t -= h.profileJerkTime
+	return rampTravel + rampVelocity*t + acceleration*t*t/2
+}
+
+// updateJerkLimitedMove advances the S-curve move in progress
+//
+// Parameters:
+//
+// elapsedMs: The time elapsed since the last update in milliseconds
+func (h *DefaultHandler) updateJerkLimitedMove(elapsedMs uint32) {
+	h.profileElapsed += float32(elapsedMs) / 1000
+	direction := float32(1)
+	if h.profileDistance < 0 {
+		direction = -1
+	}
+
+	// Accelerate, cruise and decelerate, the deceleration mirrors the acceleration
+	accelTime := h.profileAccelTime
+	cruiseEnd := accelTime + h.profileCruiseTime
+	peakVelocity := h.profilePeakVelocity
+	switch t := h.profileElapsed; {
+	case t < accelTime:
+		h.profilePosition = h.profileFrom + direction*h.jerkLimitedRampTravel(t)
+		return
+	case t < cruiseEnd:
+		h.profilePosition = h.profileFrom + direction*(peakVelocity*accelTime/2+peakVelocity*(t-accelTime))
+		return
+	case t < cruiseEnd+accelTime:
+		h.profilePosition = h.profileFrom + h.profileDistance - direction*h.jerkLimitedRampTravel(cruiseEnd+accelTime-t)
+		return
+	}
+
+	// Finish the move, or plan the next one if the target changed meanwhile
+	h.profilePosition = h.profileFrom + h.profileDistance
+	if h.profilePosition != float32(h.profileTarget) {
+		h.planJerkLimitedMove()
+		return
+	}
+	h.hasProfiledMove = false
+}
@@ -0,0 +1,39 @@
+package tinygo_servo
+
+var (
+	// nameOpenBuffer is written before the handler name in the log messages
+	nameOpenBuffer = []byte("[")
+
+	// nameCloseBuffer is written after the handler name in the log messages
+	nameCloseBuffer = []byte("]")
+)
+
+// SetName sets the identifier written at the start of every log message of the handler, so the logs of different
+// servos can be told apart
+//
+// Parameters:
+//
+// name: The identifier of the handler, it is not copied so it must not be modified afterwards. Nil removes it
+func (h *DefaultHandler) SetName(name []byte) {
+	h.name = name
+}
+
+// Name returns the identifier written at the start of every log message of the handler
+//
+// Returns:
+//
+// The identifier of the handler, or nil if it has no name
+func (h *DefaultHandler) Name() []byte {
+	return h.name
+}
+
+// addLogName adds the handler name to the log message being built, if the handler has a name
+func (h *DefaultHandler) addLogName() {
+	if len(h.name) == 0 {
+		return
+	}
+	h.logger.AddMessage(nameOpenBuffer, false)
+	h.logger.AddMessage(h.name, false)
+	h.logger.AddMessage(nameCloseBuffer, false)
+	h.logger.AddSpace()
+}
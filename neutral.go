@@ -0,0 +1,90 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetNeutralPulseWidth sets the pulse width of the center angle, for servos whose true neutral isn't midway between
+// the min and max pulse widths. The angles on each side of the center are mapped linearly between the neutral pulse
+// width and the pulse width of that end, so centering the servo, or stopping a continuous-rotation servo, outputs the
+// neutral pulse width exactly
+//
+// Parameters:
+//
+// neutralPulseWidth: The pulse width of the center angle, between the min and max pulse widths
+//
+// Returns:
+//
+// An error if the pulse width is outside the min and max pulse widths
+func (h *DefaultHandler) SetNeutralPulseWidth(neutralPulseWidth Nanoseconds) tinygoerrors.ErrorCode {
+	// Check if the neutral pulse width is within the pulse range
+	if neutralPulseWidth < h.minPulseWidth || neutralPulseWidth > h.maxPulseWidth {
+		return ErrorCodeServoInvalidNeutralPulseWidth
+	}
+	h.neutralPulseWidth = neutralPulseWidth
+	h.recalculatePulse()
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ClearNeutralPulseWidth maps the angles linearly between the min and max pulse widths again, so the center angle
+// outputs the pulse width of its geometric position
+func (h *DefaultHandler) ClearNeutralPulseWidth() {
+	h.neutralPulseWidth = 0
+	h.recalculatePulse()
+}
+
+// GetNeutralPulseWidth returns the pulse width of the center angle
+//
+// Returns:
+//
+// The neutral pulse width if set, otherwise the pulse width of the geometric position of the center angle
+func (h *DefaultHandler) GetNeutralPulseWidth() Nanoseconds {
+	if h.neutralPulseWidth != 0 {
+		return h.neutralPulseWidth
+	}
+	return h.calculatePulse(h.centerAngle)
+}
+
+// recalculatePulse recalculates the pulse of the current angle after the mapping changed, writing it if the servo is
+// awake
+func (h *DefaultHandler) recalculatePulse() {
+	h.pulse = h.calculatePulseMilliDegrees(h.GetAngleMilliDegrees())
+	if !h.isSleeping && h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+}
+
+// calculateNeutralPulseMilliDegrees calculates the pulse width for the given angle in millidegrees, mapping the angles
+// below the center between the min and neutral pulse widths, and the angles above it between the neutral and max
+// pulse widths
+//
+// Parameters:
+//
+// milliDegrees: The angle to convert in millidegrees, must be between 0 and the actuation range
+//
+// Returns:
+//
+// The pulse width for the given angle
+func (h *DefaultHandler) calculateNeutralPulseMilliDegrees(milliDegrees uint32) Nanoseconds {
+	centerMilliDegrees := uint32(h.centerAngle) * 1000
+	if milliDegrees <= centerMilliDegrees {
+		// Avoid division by zero when the center is at the lower end
+		if centerMilliDegrees == 0 {
+			return h.neutralPulseWidth
+		}
+		return h.minPulseWidth + Nanoseconds(
+			divideRounded(
+				uint64(h.neutralPulseWidth-h.minPulseWidth)*uint64(milliDegrees),
+				uint64(centerMilliDegrees),
+				h.rounding,
+			),
+		)
+	}
+	return h.neutralPulseWidth + Nanoseconds(
+		divideRounded(
+			uint64(h.maxPulseWidth-h.neutralPulseWidth)*uint64(milliDegrees-centerMilliDegrees),
+			uint64(h.actuationRange)*1000-uint64(centerMilliDegrees),
+			h.rounding,
+		),
+	)
+}
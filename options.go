@@ -0,0 +1,395 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+)
+
+type (
+	// Option configures a handler created with NewHandler
+	Option func(options *handlerOptions)
+
+	// handlerOptions are the parameters of newDefaultHandler collected by the options of NewHandler
+	handlerOptions struct {
+		afterSetAngleFunc   func(angle uint16)
+		isMovementEnabled   func() bool
+		frequency           uint16
+		minPulseWidth       Nanoseconds
+		maxPulseWidth       Nanoseconds
+		actuationRange      uint16
+		centerAngle         uint16
+		hasCenterAngle      bool
+		maxLeftAngle        uint16
+		maxRightAngle       uint16
+		hasLimits           bool
+		isDirectionInverted bool
+		neutralPulseWidth   Nanoseconds
+		logger              tinygologger.Logger
+		isDeferred          bool
+		rewriteCommands     uint32
+		rewriteMs           uint32
+		output              PulseOutput
+		selfCheck           PulseCapture
+		selfCheckTolerance  Nanoseconds
+		calibration         *CalibrationTable
+	}
+)
+
+// NewHandler creates a new instance of DefaultHandler configured by options. Without options, it drives a standard
+// 50Hz hobby servo with pulse widths from 500us to 2500us over 180 degrees, centered at the middle of its actuation
+// range and free to move over all of it
+//
+// Parameters:
+//
+// pwm: The PWM interface to control the servo
+// pin: The pin connected to the servo
+// options: The options overriding the defaults
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if any occurred during initialization
+func NewHandler(pwm PWM, pin Pin, options ...Option) (*DefaultHandler, tinygoerrors.ErrorCode) {
+	return newHandler(
+		pwm,
+		pin,
+		handlerOptions{
+			frequency:      DefaultFrequency,
+			minPulseWidth:  DefaultMinPulseWidth,
+			maxPulseWidth:  DefaultMaxPulseWidth,
+			actuationRange: StandardActuationRange,
+		},
+		options,
+	)
+}
+
+// NewOutputHandler creates a new instance of DefaultHandler configured by options that writes its pulses through the
+// given output instead of a PWM channel, with the same defaults as NewHandler. The PWM-only features, like the timer
+// ticks and the hardware polarity inversion, are not available
+//
+// Parameters:
+//
+// output: The output to write the pulses through
+// options: The options overriding the defaults
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if any occurred during initialization
+func NewOutputHandler(output PulseOutput, options ...Option) (*DefaultHandler, tinygoerrors.ErrorCode) {
+	// Check if the output is nil
+	if output == nil {
+		return nil, ErrorCodeServoNilOutput
+	}
+	return newHandler(
+		nil,
+		NoPin,
+		handlerOptions{
+			frequency:      DefaultFrequency,
+			minPulseWidth:  DefaultMinPulseWidth,
+			maxPulseWidth:  DefaultMaxPulseWidth,
+			actuationRange: StandardActuationRange,
+			output:         output,
+		},
+		options,
+	)
+}
+
+// newHandler creates a new instance of DefaultHandler from the given parameters overridden by options
+//
+// Parameters:
+//
+// pwm: The PWM interface to control the servo
+// pin: The pin connected to the servo
+// parsed: The parameters before applying the options
+// options: The options overriding the parameters
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if any occurred during initialization
+func newHandler(
+	pwm PWM,
+	pin Pin,
+	parsed handlerOptions,
+	options []Option,
+) (*DefaultHandler, tinygoerrors.ErrorCode) {
+	for _, option := range options {
+		if option != nil {
+			option(&parsed)
+		}
+	}
+
+	// Center the servo and let it move over its whole actuation range unless set
+	if !parsed.hasCenterAngle {
+		parsed.centerAngle = parsed.actuationRange / 2
+	}
+	if !parsed.hasLimits {
+		parsed.maxLeftAngle = parsed.centerAngle
+		parsed.maxRightAngle = parsed.actuationRange - min(parsed.centerAngle, parsed.actuationRange)
+	}
+
+	// Defer the initialization until the neutral pulse width, the calibration table and the self-check are set, so
+	// the first pulses are calibrated and verified
+	isDeferred := parsed.isDeferred || parsed.selfCheck != nil || parsed.calibration != nil ||
+		parsed.neutralPulseWidth != 0
+
+	handler, err := newDefaultHandler(
+		pwm,
+		pin,
+		parsed.afterSetAngleFunc,
+		parsed.isMovementEnabled,
+		parsed.frequency,
+		parsed.minPulseWidth,
+		parsed.maxPulseWidth,
+		parsed.actuationRange,
+		parsed.centerAngle,
+		parsed.maxLeftAngle,
+		parsed.maxRightAngle,
+		parsed.isDirectionInverted,
+		parsed.logger,
+		parsed.output,
+		isDeferred,
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	handler.SetRepeatedCommandRewrite(parsed.rewriteCommands, parsed.rewriteMs)
+	if parsed.neutralPulseWidth != 0 {
+		if err = handler.SetNeutralPulseWidth(parsed.neutralPulseWidth); err != tinygoerrors.ErrorCodeNil {
+			return nil, err
+		}
+	}
+	if parsed.calibration != nil {
+		if err = handler.SetCalibrationTable(parsed.calibration); err != tinygoerrors.ErrorCodeNil {
+			return nil, err
+		}
+	}
+	if parsed.selfCheck != nil {
+		handler.SetSelfCheck(parsed.selfCheck, parsed.selfCheckTolerance)
+	}
+	if isDeferred && !parsed.isDeferred {
+		if err = handler.Initialize(); err != tinygoerrors.ErrorCodeNil {
+			return nil, err
+		}
+	}
+	return handler, tinygoerrors.ErrorCodeNil
+}
+
+// WithFrequency sets the frequency of the PWM signal
+//
+// Parameters:
+//
+// frequency: The frequency of the PWM signal
+//
+// Returns:
+//
+// The option
+func WithFrequency(frequency uint16) Option {
+	return func(options *handlerOptions) {
+		options.frequency = frequency
+	}
+}
+
+// WithPulseRange sets the pulse widths of both ends of the actuation range
+//
+// Parameters:
+//
+// minPulseWidth: The minimum pulse width for the servo motor in nanoseconds
+// maxPulseWidth: The maximum pulse width for the servo motor in nanoseconds
+//
+// Returns:
+//
+// The option
+func WithPulseRange(minPulseWidth Nanoseconds, maxPulseWidth Nanoseconds) Option {
+	return func(options *handlerOptions) {
+		options.minPulseWidth = minPulseWidth
+		options.maxPulseWidth = maxPulseWidth
+	}
+}
+
+// WithActuationRange sets the actuation range of the servo motor
+//
+// Parameters:
+//
+// actuationRange: The actuation range of the servo motor in degrees, up to MaxActuationRange
+//
+// Returns:
+//
+// The option
+func WithActuationRange(actuationRange uint16) Option {
+	return func(options *handlerOptions) {
+		options.actuationRange = actuationRange
+	}
+}
+
+// WithCenterAngle sets the center angle of the servo motor
+//
+// Parameters:
+//
+// centerAngle: The center angle of the servo motor, between 0 and the actuation range
+//
+// Returns:
+//
+// The option
+func WithCenterAngle(centerAngle uint16) Option {
+	return func(options *handlerOptions) {
+		options.centerAngle = centerAngle
+		options.hasCenterAngle = true
+	}
+}
+
+// WithNeutralPulseWidth sets the pulse width of the center angle, see SetNeutralPulseWidth, for the servos whose
+// mechanical center isn't midway between the min and max pulse widths. With the min and max pulse widths, it makes a
+// three-point calibration
+//
+// Parameters:
+//
+// neutralPulseWidth: The pulse width of the center angle, between the min and max pulse widths
+//
+// Returns:
+//
+// The option
+func WithNeutralPulseWidth(neutralPulseWidth Nanoseconds) Option {
+	return func(options *handlerOptions) {
+		options.neutralPulseWidth = neutralPulseWidth
+	}
+}
+
+// WithLimits sets the maximum angles the servo motor can move from the center
+//
+// Parameters:
+//
+// maxLeftAngle: The maximum left angle from the center, clamped so the left limit is not lower than 0
+// maxRightAngle: The maximum right angle from the center, clamped so the right limit is not higher than the actuation
+// range
+//
+// Returns:
+//
+// The option
+func WithLimits(maxLeftAngle uint16, maxRightAngle uint16) Option {
+	return func(options *handlerOptions) {
+		options.maxLeftAngle = maxLeftAngle
+		options.maxRightAngle = maxRightAngle
+		options.hasLimits = true
+	}
+}
+
+// WithInvertedDirection inverts the direction of the servo motor
+//
+// Returns:
+//
+// The option
+func WithInvertedDirection() Option {
+	return func(options *handlerOptions) {
+		options.isDirectionInverted = true
+	}
+}
+
+// WithLogger sets the logger instance for logging messages
+//
+// Parameters:
+//
+// logger: The logger instance
+//
+// Returns:
+//
+// The option
+func WithLogger(logger tinygologger.Logger) Option {
+	return func(options *handlerOptions) {
+		options.logger = logger
+	}
+}
+
+// WithAfterSetAngleFunc sets a callback function to be called after setting the angle
+//
+// Parameters:
+//
+// afterSetAngleFunc: The callback function
+//
+// Returns:
+//
+// The option
+func WithAfterSetAngleFunc(afterSetAngleFunc func(angle uint16)) Option {
+	return func(options *handlerOptions) {
+		options.afterSetAngleFunc = afterSetAngleFunc
+	}
+}
+
+// WithMovementEnabledFunc sets a function to check if movement is enabled, checked in addition to EnableMovement and
+// DisableMovement
+//
+// Parameters:
+//
+// isMovementEnabled: The function to check if movement is enabled
+//
+// Returns:
+//
+// The option
+func WithMovementEnabledFunc(isMovementEnabled func() bool) Option {
+	return func(options *handlerOptions) {
+		options.isMovementEnabled = isMovementEnabled
+	}
+}
+
+// WithDeferredInitialization defers configuring the PWM peripheral until the first command or an explicit call to
+// Initialize, so handlers can be created before the clocks and peripherals are ready, e.g. in package-level
+// variables. The parameters are still validated immediately
+//
+// Returns:
+//
+// The option
+func WithDeferredInitialization() Option {
+	return func(options *handlerOptions) {
+		options.isDeferred = true
+	}
+}
+
+// WithRepeatedCommandRewrite makes the commands repeating the current angle rewrite its pulse width periodically, see
+// SetRepeatedCommandRewrite
+//
+// Parameters:
+//
+// everyCommands: The number of repeated commands between rewrites, or zero to not rewrite by count
+// everyMs: The time between rewrites in milliseconds, or zero to not rewrite by time
+//
+// Returns:
+//
+// The option
+func WithRepeatedCommandRewrite(everyCommands uint32, everyMs uint32) Option {
+	return func(options *handlerOptions) {
+		options.rewriteCommands = everyCommands
+		options.rewriteMs = everyMs
+	}
+}
+
+// WithSelfCheck makes the handler verify its output through a loopback capture when it is initialized, see
+// SetSelfCheck. A failed self-check fails the constructor, or the first command if the initialization is deferred
+//
+// Parameters:
+//
+// capture: The capture of the looped back output
+// tolerance: The largest difference in nanoseconds between the captured and the commanded pulse widths
+//
+// Returns:
+//
+// The option
+func WithSelfCheck(capture PulseCapture, tolerance Nanoseconds) Option {
+	return func(options *handlerOptions) {
+		options.selfCheck = capture
+		options.selfCheckTolerance = tolerance
+	}
+}
+
+// WithCalibrationTable maps the angles to pulse widths with a calibration table, see SetCalibrationTable, so even the
+// first pulse is calibrated
+//
+// Parameters:
+//
+// table: The calibration table
+//
+// Returns:
+//
+// The option
+func WithCalibrationTable(table *CalibrationTable) Option {
+	return func(options *handlerOptions) {
+		options.calibration = table
+	}
+}
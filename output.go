@@ -0,0 +1,40 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// pwmOutput is the PulseOutput of the handlers created from a tinygopwm.PWM peripheral and a pin, it converts
+	// the pulse widths to the timer ticks of the channel with the rounding behavior of the handler
+	pwmOutput struct {
+		handler *DefaultHandler
+	}
+)
+
+// Configure configures the PWM peripheral and acquires the channel of the pin
+//
+// Parameters:
+//
+// period: The period of the PWM signal in nanoseconds
+//
+// Returns:
+//
+// An error if the PWM peripheral could not be configured or the channel could not be acquired
+func (o *pwmOutput) Configure(period uint32) tinygoerrors.ErrorCode {
+	channel, err := configurePWM(o.handler.pwm, o.handler.pin, period)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	o.handler.channel = channel
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetPulse sets the duty cycle of the PWM channel to the given pulse width
+//
+// Parameters:
+//
+// pulse: The pulse width to output in nanoseconds
+func (o *pwmOutput) SetPulse(pulse uint32) {
+	o.handler.pwm.Set(o.handler.channel, o.handler.calculateDuty(Nanoseconds(pulse)))
+}
@@ -0,0 +1,27 @@
+package pantilt
+
+type (
+	// SearchPattern is an enum to represent the paths a Search scans.
+	SearchPattern uint8
+
+	// searchState is an enum to represent the stage of a Search.
+	searchState uint8
+)
+
+const (
+	// SearchPatternRaster scans the rows always in the same direction, flying back at the end of every row
+	SearchPatternRaster SearchPattern = iota
+
+	// SearchPatternLawnmower scans the rows alternating their direction
+	SearchPatternLawnmower
+
+	// SearchPatternSpiral scans a square spiral growing from the center of the limits
+	SearchPatternSpiral
+)
+
+const (
+	searchStateIdle searchState = iota
+	searchStateMoving
+	searchStateDwelling
+	searchStateFound
+)
@@ -0,0 +1,18 @@
+package pantilt
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodePanTiltStartNumber is the starting number for pan-tilt related error codes.
+	ErrorCodePanTiltStartNumber uint16 = 5500
+)
+
+const (
+	ErrorCodePanTiltNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodePanTiltStartNumber)
+	ErrorCodePanTiltNilHead
+	ErrorCodePanTiltUnknownSearchPattern
+	ErrorCodePanTiltZeroSearchStep
+	ErrorCodePanTiltNilFoundFunc
+)
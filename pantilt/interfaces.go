@@ -0,0 +1,16 @@
+package pantilt
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the pan-tilt heads, so the axes can be
+	// driven by any handler and the tracking tested against fakes
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		GetAngleMilliDegrees() uint32
+		LeftLimit() uint16
+		RightLimit() uint16
+	}
+)
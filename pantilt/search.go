@@ -0,0 +1,272 @@
+package pantilt
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Search scans the range of a pan-tilt head with a search pattern until a predicate reports the target was found,
+	// then stops and hands control to the tracker, completing the sensor turret workflow
+	Search struct {
+		head         *PanTilt
+		pattern      SearchPattern
+		stepDeg      uint16
+		speed        uint16
+		dwellMs      uint32
+		foundFunc    func() bool
+		tracker      *Tracker
+		state        searchState
+		dwellStartMs uint32
+		hasDwell     bool
+		column       int32
+		row          int32
+		columns      int32
+		rows         int32
+		spiralX      int32
+		spiralY      int32
+		direction    uint8
+		legLength    int32
+		legStep      int32
+		legsDone     int32
+	}
+)
+
+// NewSearch creates a new instance of Search
+//
+// Parameters:
+//
+// head: The pan-tilt head to move
+// pattern: The path to scan
+// stepDeg: The distance between two scanned positions in degrees, usually a bit less than the field of view
+// speed: The speed of the moves between positions in degrees per second, zero jumps to every position
+// dwellMs: The time spent at every position checking the predicate
+// foundFunc: The predicate that reports the target was found, checked on every update
+//
+// Returns:
+//
+// An instance of Search and an error if any parameter is invalid
+func NewSearch(
+	head *PanTilt,
+	pattern SearchPattern,
+	stepDeg uint16,
+	speed uint16,
+	dwellMs uint32,
+	foundFunc func() bool,
+) (*Search, tinygoerrors.ErrorCode) {
+	if head == nil {
+		return nil, ErrorCodePanTiltNilHead
+	}
+	if pattern > SearchPatternSpiral {
+		return nil, ErrorCodePanTiltUnknownSearchPattern
+	}
+	if stepDeg == 0 {
+		return nil, ErrorCodePanTiltZeroSearchStep
+	}
+	if foundFunc == nil {
+		return nil, ErrorCodePanTiltNilFoundFunc
+	}
+	return &Search{
+		head:      head,
+		pattern:   pattern,
+		stepDeg:   stepDeg,
+		speed:     speed,
+		dwellMs:   dwellMs,
+		foundFunc: foundFunc,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetTracker sets the tracker that takes control once the target is found, its state is reset so the first frame
+// doesn't apply a correction for the time spent searching
+//
+// Parameters:
+//
+// tracker: The tracker, or nil
+func (s *Search) SetTracker(tracker *Tracker) {
+	s.tracker = tracker
+}
+
+// Start starts the search from the first position of the pattern. The pattern is repeated until the target is found
+//
+// Returns:
+//
+// The error returned by the servos when moving to the first position, if any
+func (s *Search) Start() tinygoerrors.ErrorCode {
+	pan, tilt := s.head.Pan(), s.head.Tilt()
+	s.columns = int32(pan.RightLimit()-pan.LeftLimit())/int32(s.stepDeg) + 1
+	s.rows = int32(tilt.RightLimit()-tilt.LeftLimit())/int32(s.stepDeg) + 1
+	s.restart()
+	return s.moveToCurrent()
+}
+
+// Stop stops the search, the head keeps its current position
+func (s *Search) Stop() {
+	s.state = searchStateIdle
+	s.head.Stop()
+}
+
+// IsSearching returns whether the search is running
+//
+// Returns:
+//
+// True if the target is being searched, false otherwise
+func (s *Search) IsSearching() bool {
+	return s.state == searchStateMoving || s.state == searchStateDwelling
+}
+
+// IsFound returns whether the last search found the target
+//
+// Returns:
+//
+// True if the target was found, false otherwise
+func (s *Search) IsFound() bool {
+	return s.state == searchStateFound
+}
+
+// restart goes back to the first position of the pattern
+func (s *Search) restart() {
+	s.column = 0
+	s.row = 0
+	s.spiralX = 0
+	s.spiralY = 0
+	s.direction = 0
+	s.legLength = 1
+	s.legStep = 0
+	s.legsDone = 0
+}
+
+// position returns the angles of the current position of the pattern
+//
+// Returns:
+//
+// The pan and tilt angles in degrees, and false if the current spiral position is outside the limits
+func (s *Search) position() (uint16, uint16, bool) {
+	pan, tilt := s.head.Pan(), s.head.Tilt()
+	var column, row int32
+	switch s.pattern {
+	case SearchPatternSpiral:
+		column = s.columns/2 + s.spiralX
+		row = s.rows/2 + s.spiralY
+		if column < 0 || column >= s.columns || row < 0 || row >= s.rows {
+			return 0, 0, false
+		}
+	case SearchPatternLawnmower:
+		column = s.column
+		if s.row%2 == 1 {
+			column = s.columns - 1 - s.column
+		}
+		row = s.row
+	default:
+		column = s.column
+		row = s.row
+	}
+	return pan.LeftLimit() + uint16(column)*s.stepDeg, tilt.LeftLimit() + uint16(row)*s.stepDeg, true
+}
+
+// advance moves to the next position of the pattern, restarting it after the last one
+func (s *Search) advance() {
+	if s.pattern != SearchPatternSpiral {
+		s.column++
+		if s.column == s.columns {
+			s.column = 0
+			s.row++
+			if s.row == s.rows {
+				s.restart()
+			}
+		}
+		return
+	}
+
+	// Move along the current leg of the spiral: right, up, left and down, growing every two legs
+	switch s.direction {
+	case 0:
+		s.spiralX++
+	case 1:
+		s.spiralY++
+	case 2:
+		s.spiralX--
+	default:
+		s.spiralY--
+	}
+	s.legStep++
+	if s.legStep == s.legLength {
+		s.legStep = 0
+		s.direction = (s.direction + 1) % 4
+		s.legsDone++
+		if s.legsDone%2 == 0 {
+			s.legLength++
+		}
+	}
+
+	// Restart once the spiral has grown past both extents
+	if s.legLength > s.columns+1 && s.legLength > s.rows+1 {
+		s.restart()
+	}
+}
+
+// moveToCurrent moves the head to the current position, skipping the spiral positions outside the limits
+//
+// Returns:
+//
+// The error returned by the servos, if any
+func (s *Search) moveToCurrent() tinygoerrors.ErrorCode {
+	panAngle, tiltAngle, ok := s.position()
+	for !ok {
+		s.advance()
+		panAngle, tiltAngle, ok = s.position()
+	}
+	s.state = searchStateMoving
+	s.hasDwell = false
+	return s.head.MoveTo(panAngle, tiltAngle, s.speed)
+}
+
+// Update runs the search, it must be called periodically from the main loop. It also updates the head
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servos, if any. The search is stopped if a servo fails
+func (s *Search) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if !s.IsSearching() {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Check if the target was found
+	if s.foundFunc() {
+		s.head.Stop()
+		s.state = searchStateFound
+		if s.tracker != nil {
+			s.tracker.Reset()
+		}
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Move to the current position
+	if s.state == searchStateMoving {
+		if err := s.head.Update(nowMs); err != tinygoerrors.ErrorCodeNil {
+			s.state = searchStateIdle
+			return err
+		}
+		if s.head.IsMoving() {
+			return tinygoerrors.ErrorCodeNil
+		}
+		s.state = searchStateDwelling
+	}
+
+	// Dwell at the current position, then move to the next one
+	if !s.hasDwell {
+		s.hasDwell = true
+		s.dwellStartMs = nowMs
+	}
+	if nowMs-s.dwellStartMs < s.dwellMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+	s.advance()
+	if err := s.moveToCurrent(); err != tinygoerrors.ErrorCodeNil {
+		s.state = searchStateIdle
+		return err
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,180 @@
+package pantilt
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// trackerAxis is the state of an axis of a Tracker
+	trackerAxis struct {
+		gain        int32
+		lagMs       uint32
+		hasEstimate bool
+		estimate    int64
+		rate        int64
+	}
+
+	// Tracker points a pan-tilt head at a target seen by a camera, converting the pixel error reported by the vision
+	// code into incremental pan and tilt corrections with a gain, a dead zone and a rate limit. With the lag of the
+	// servos known, the corrections are fed forward by the motion of the target during that lag, which reduces the
+	// tracking error of moving targets without raising the gains into oscillation
+	Tracker struct {
+		head           *PanTilt
+		pan            trackerAxis
+		tilt           trackerAxis
+		deadzonePixels uint16
+		maxSpeed       uint16
+		hasTimestamp   bool
+		timestampMs    uint32
+	}
+)
+
+// NewTracker creates a new instance of Tracker
+//
+// Parameters:
+//
+// head: The pan-tilt head to point
+// panGain: The pan correction in millidegrees per pixel of horizontal error, negative if the pan servo turns the
+// camera to the left when its angle increases
+// tiltGain: The tilt correction in millidegrees per pixel of vertical error, negative if the tilt servo turns the
+// camera down when its angle increases
+//
+// Returns:
+//
+// An instance of Tracker and an error if the head is nil
+func NewTracker(head *PanTilt, panGain int32, tiltGain int32) (*Tracker, tinygoerrors.ErrorCode) {
+	if head == nil {
+		return nil, ErrorCodePanTiltNilHead
+	}
+	return &Tracker{
+		head: head,
+		pan:  trackerAxis{gain: panGain},
+		tilt: trackerAxis{gain: tiltGain},
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetDeadzone sets the pixel error ignored on each axis, so the head doesn't hunt around a centered target
+//
+// Parameters:
+//
+// pixels: The maximum error in pixels that doesn't move the head
+func (t *Tracker) SetDeadzone(pixels uint16) {
+	t.deadzonePixels = pixels
+}
+
+// SetMaxSpeed sets the maximum speed of the corrections on each axis
+//
+// Parameters:
+//
+// speed: The maximum speed in degrees per second, zero leaves the corrections unlimited
+func (t *Tracker) SetMaxSpeed(speed uint16) {
+	t.maxSpeed = speed
+}
+
+// SetFeedforward sets the lag of the servos, usually identified with the routine package StepResponse, to command
+// them ahead by the motion of the target during that time
+//
+// Parameters:
+//
+// panLagMs: The lag of the pan servo in milliseconds, zero disables its feedforward
+// tiltLagMs: The lag of the tilt servo in milliseconds, zero disables its feedforward
+func (t *Tracker) SetFeedforward(panLagMs uint32, tiltLagMs uint32) {
+	t.pan.lagMs = panLagMs
+	t.tilt.lagMs = tiltLagMs
+}
+
+// Reset forgets the time of the last error and the estimated motion of the target, e.g. when the target was lost
+func (t *Tracker) Reset() {
+	t.hasTimestamp = false
+	t.pan.hasEstimate = false
+	t.pan.rate = 0
+	t.tilt.hasEstimate = false
+	t.tilt.rate = 0
+}
+
+// correction calculates the correction of an axis
+//
+// Parameters:
+//
+// axis: The axis to correct
+// angle: The current angle of the axis in millidegrees
+// errorPixels: The pixel error of the axis
+// elapsedMs: The time since the last error
+//
+// Returns:
+//
+// The correction in millidegrees
+func (t *Tracker) correction(axis *trackerAxis, angle uint32, errorPixels int16, elapsedMs uint32) int64 {
+	proportional := int64(errorPixels) * int64(axis.gain)
+
+	// Estimate the rate of the target in millidegrees per second, smoothing it over two frames to reject the noise
+	// of the vision code
+	estimate := int64(angle) + proportional
+	if axis.hasEstimate && elapsedMs > 0 {
+		rate := (estimate - axis.estimate) * 1000 / int64(elapsedMs)
+		axis.rate = (axis.rate + rate) / 2
+	}
+	axis.estimate = estimate
+	axis.hasEstimate = true
+
+	// Ignore the errors within the dead zone
+	magnitude := int32(errorPixels)
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	correction := int64(0)
+	if magnitude > int32(t.deadzonePixels) {
+		correction = proportional
+	}
+
+	// Command ahead by the motion of the target during the lag of the servo
+	correction += axis.rate * int64(axis.lagMs) / 1000
+
+	// Limit the rate, degrees per second times milliseconds are millidegrees
+	if t.maxSpeed != 0 {
+		correction = limitMagnitude(correction, int64(t.maxSpeed)*int64(elapsedMs))
+	}
+	return correction
+}
+
+// Track applies a correction from the pixel error of the target, it must be called for every processed frame. The
+// first error after creating or resetting the tracker only records the time when a rate limit is set, because the
+// correction allowed depends on the time since the previous frame
+//
+// Parameters:
+//
+// dx: The horizontal distance in pixels from the image center to the target, positive to the right
+// dy: The vertical distance in pixels from the image center to the target, positive upwards
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The first error returned by the servos, if any
+func (t *Tracker) Track(dx int16, dy int16, nowMs uint32) tinygoerrors.ErrorCode {
+	// Tracking takes over any coordinated move
+	t.head.Stop()
+
+	// Calculate the time since the previous frame
+	elapsedMs := nowMs - t.timestampMs
+	isFirstFrame := !t.hasTimestamp
+	t.hasTimestamp = true
+	t.timestampMs = nowMs
+	if isFirstFrame {
+		elapsedMs = 0
+	}
+
+	// Calculate the corrections, skipping the first frame when the rate is limited
+	pan, tilt := t.head.Pan(), t.head.Tilt()
+	panAngle, tiltAngle := pan.GetAngleMilliDegrees(), tilt.GetAngleMilliDegrees()
+	panCorrection := t.correction(&t.pan, panAngle, dx, elapsedMs)
+	tiltCorrection := t.correction(&t.tilt, tiltAngle, dy, elapsedMs)
+	if isFirstFrame && t.maxSpeed != 0 {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Apply the corrections within the limits of the servos
+	return t.head.apply(
+		clampToLimits(pan, int64(panAngle)+panCorrection),
+		clampToLimits(tilt, int64(tiltAngle)+tiltCorrection),
+	)
+}
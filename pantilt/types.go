@@ -0,0 +1,164 @@
+package pantilt
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// PanTilt drives a pan-tilt head. Moves are slewed proportionally, the axis with the shorter travel moves slower so
+	// both axes arrive together and the pointing vector travels a near-straight path instead of an L-shaped one
+	PanTilt struct {
+		pan        Servo
+		tilt       Servo
+		isMoving   bool
+		hasStarted bool
+		startMs    uint32
+		durationMs uint32
+		panFrom    uint32
+		panTo      uint32
+		tiltFrom   uint32
+		tiltTo     uint32
+	}
+)
+
+// NewPanTilt creates a new instance of PanTilt
+//
+// Parameters:
+//
+// pan: The servo that rotates the head horizontally
+// tilt: The servo that rotates the head vertically
+//
+// Returns:
+//
+// An instance of PanTilt and an error if any servo is nil
+func NewPanTilt(pan Servo, tilt Servo) (*PanTilt, tinygoerrors.ErrorCode) {
+	if pan == nil || tilt == nil {
+		return nil, ErrorCodePanTiltNilServo
+	}
+	return &PanTilt{
+		pan:  pan,
+		tilt: tilt,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// MoveTo starts a coordinated move of both axes, driven by Update
+//
+// Parameters:
+//
+// panAngle: The target angle of the pan servo in degrees
+// tiltAngle: The target angle of the tilt servo in degrees
+// speed: The speed of the axis with the longest travel in degrees per second, zero moves both axes immediately
+//
+// Returns:
+//
+// The error returned by the servos when moving immediately, if any
+func (p *PanTilt) MoveTo(panAngle uint16, tiltAngle uint16, speed uint16) tinygoerrors.ErrorCode {
+	p.panFrom = p.pan.GetAngleMilliDegrees()
+	p.tiltFrom = p.tilt.GetAngleMilliDegrees()
+	p.panTo = uint32(panAngle) * 1000
+	p.tiltTo = uint32(tiltAngle) * 1000
+
+	// Calculate the duration of the move from the longest travel, millidegrees over degrees per second is milliseconds
+	travel := difference(p.panFrom, p.panTo)
+	if tiltTravel := difference(p.tiltFrom, p.tiltTo); tiltTravel > travel {
+		travel = tiltTravel
+	}
+	if speed == 0 || travel == 0 {
+		p.isMoving = false
+		return p.apply(p.panTo, p.tiltTo)
+	}
+	p.durationMs = travel / uint32(speed)
+	if p.durationMs == 0 {
+		p.durationMs = 1
+	}
+	p.hasStarted = false
+	p.isMoving = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// apply sets the angle of both servos
+//
+// Parameters:
+//
+// panMilliDegrees: The angle of the pan servo in millidegrees
+// tiltMilliDegrees: The angle of the tilt servo in millidegrees
+//
+// Returns:
+//
+// The first error returned by the servos, if any
+func (p *PanTilt) apply(panMilliDegrees uint32, tiltMilliDegrees uint32) tinygoerrors.ErrorCode {
+	panErr := p.pan.SetAngleMilliDegrees(panMilliDegrees)
+	tiltErr := p.tilt.SetAngleMilliDegrees(tiltMilliDegrees)
+	if panErr != tinygoerrors.ErrorCodeNil {
+		return panErr
+	}
+	return tiltErr
+}
+
+// Stop stops the move, both axes keep their current angles
+func (p *PanTilt) Stop() {
+	p.isMoving = false
+}
+
+// IsMoving returns whether a move is in progress
+//
+// Returns:
+//
+// True if the head is moving, false otherwise
+func (p *PanTilt) IsMoving() bool {
+	return p.isMoving
+}
+
+// Update advances the move, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The first error returned by the servos, if any. The move is stopped if a servo fails
+func (p *PanTilt) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if !p.isMoving {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Start the move on the first update
+	if !p.hasStarted {
+		p.hasStarted = true
+		p.startMs = nowMs
+	}
+
+	// Interpolate both axes with the same progress
+	elapsedMs := nowMs - p.startMs
+	if elapsedMs >= p.durationMs {
+		elapsedMs = p.durationMs
+		p.isMoving = false
+	}
+	err := p.apply(
+		interpolate(p.panFrom, p.panTo, elapsedMs, p.durationMs),
+		interpolate(p.tiltFrom, p.tiltTo, elapsedMs, p.durationMs),
+	)
+	if err != tinygoerrors.ErrorCodeNil {
+		p.isMoving = false
+	}
+	return err
+}
+
+// Pan returns the servo that rotates the head horizontally
+//
+// Returns:
+//
+// The pan servo
+func (p *PanTilt) Pan() Servo {
+	return p.pan
+}
+
+// Tilt returns the servo that rotates the head vertically
+//
+// Returns:
+//
+// The tilt servo
+func (p *PanTilt) Tilt() Servo {
+	return p.tilt
+}
@@ -0,0 +1,79 @@
+package pantilt
+
+// interpolate returns the position between two angles after a fraction of the move
+//
+// Parameters:
+//
+// from: The start angle in millidegrees
+// to: The end angle in millidegrees
+// elapsedMs: The time since the move started, at most its duration
+// durationMs: The duration of the move, it must not be zero
+//
+// Returns:
+//
+// The interpolated angle in millidegrees
+func interpolate(from uint32, to uint32, elapsedMs uint32, durationMs uint32) uint32 {
+	if to >= from {
+		return from + uint32(uint64(to-from)*uint64(elapsedMs)/uint64(durationMs))
+	}
+	return from - uint32(uint64(from-to)*uint64(elapsedMs)/uint64(durationMs))
+}
+
+// difference returns the absolute difference between two angles
+//
+// Parameters:
+//
+// a: The first angle
+// b: The second angle
+//
+// Returns:
+//
+// The absolute difference
+func difference(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// clampToLimits clamps an angle to the limits of a servo
+//
+// Parameters:
+//
+// servo: The servo whose limits are used
+// milliDegrees: The angle to clamp in millidegrees, it may be negative
+//
+// Returns:
+//
+// The clamped angle in millidegrees
+func clampToLimits(servo Servo, milliDegrees int64) uint32 {
+	leftLimit := int64(servo.LeftLimit()) * 1000
+	rightLimit := int64(servo.RightLimit()) * 1000
+	if milliDegrees < leftLimit {
+		return uint32(leftLimit)
+	}
+	if milliDegrees > rightLimit {
+		return uint32(rightLimit)
+	}
+	return uint32(milliDegrees)
+}
+
+// limitMagnitude limits the magnitude of a signed value
+//
+// Parameters:
+//
+// value: The value to limit
+// limit: The maximum magnitude
+//
+// Returns:
+//
+// The value clamped between -limit and limit
+func limitMagnitude(value int64, limit int64) int64 {
+	if value > limit {
+		return limit
+	}
+	if value < -limit {
+		return -limit
+	}
+	return value
+}
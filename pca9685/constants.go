@@ -0,0 +1,48 @@
+package pca9685
+
+const (
+	// DefaultAddress is the I2C address of a board with all the address pins low
+	DefaultAddress uint16 = 0x40
+
+	// MaxAddressOffset is the highest offset set with the six address pins, added to DefaultAddress
+	MaxAddressOffset = 0x3F
+
+	// ChannelsCount is the number of PWM channels of a board
+	ChannelsCount = 16
+
+	// MaxChainedBoards is the number of boards a Chain can address with the uint8 channels of the PWM interface
+	MaxChainedBoards = 16
+
+	// DefaultOscillatorFrequency is the nominal frequency of the internal oscillator in Hz, the actual one varies by
+	// a few percent between chips
+	DefaultOscillatorFrequency uint32 = 25000000
+
+	// Top is the value of a full duty cycle, the counter has 4096 steps per period
+	Top uint32 = 4096
+)
+
+const (
+	// Registers
+	registerMode1    = 0x00
+	registerMode2    = 0x01
+	registerLED0     = 0x06
+	registerPrescale = 0xFE
+
+	// MODE1 bits
+	mode1Restart       = 0x80
+	mode1AutoIncrement = 0x20
+	mode1Sleep         = 0x10
+
+	// MODE2 bits, the outputs are totem pole to drive the servo signal lines directly
+	mode2OutputDrive = 0x04
+
+	// fullBit is the bit of the high byte of the ON and OFF registers keeping the output fully on or off
+	fullBit = 0x10
+
+	// Prescaler range
+	minPrescale = 3
+	maxPrescale = 255
+
+	// oscillatorStartUpUs is the time the oscillator takes to start after leaving the sleep mode, in microseconds
+	oscillatorStartUpUs = 500
+)
@@ -0,0 +1,30 @@
+package pca9685
+
+import (
+	"errors"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodePCA9685StartNumber is the starting number for PCA9685 related error codes.
+	ErrorCodePCA9685StartNumber uint16 = 5660
+)
+
+const (
+	ErrorCodePCA9685NilBus tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodePCA9685StartNumber)
+	ErrorCodePCA9685InvalidAddress
+	ErrorCodePCA9685InvalidOscillatorFrequency
+	ErrorCodePCA9685EmptyChain
+	ErrorCodePCA9685ChainTooLong
+)
+
+var (
+	// ErrInvalidChannel is returned by Channel for the pins that are not channels of the boards, it is a Go error as
+	// required by the tinygo-pwm PWM interface
+	ErrInvalidChannel = errors.New("pca9685: invalid channel")
+
+	// ErrInvalidPeriod is returned by Configure for the periods out of the prescaler range, or different from the
+	// period the board is already running at, since all the channels of a board share its frequency
+	ErrInvalidPeriod = errors.New("pca9685: invalid period")
+)
@@ -0,0 +1,9 @@
+package pca9685
+
+type (
+	// I2C is the bus the boards are connected to. It is satisfied by the TinyGo machine.I2C peripherals and the I2C
+	// buses of the TinyGo drivers module
+	I2C interface {
+		Tx(address uint16, w []byte, r []byte) error
+	}
+)
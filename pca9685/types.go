@@ -0,0 +1,323 @@
+//go:build tinygo
+
+package pca9685
+
+import (
+	"machine"
+	"time"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Device is a PCA9685 16-channel PWM expander implementing the tinygo-pwm PWM interface, so the handlers of the
+	// package drive its channels like the PWM peripherals of the MCU. The pins given to the handlers are the channel
+	// numbers, from 0 to 15
+	Device struct {
+		bus                 I2C
+		address             uint16
+		oscillatorFrequency uint32
+		prescale            uint8
+		isConfigured        bool
+		buffer              [5]byte
+		failedWrites        uint32
+	}
+
+	// Chain drives several PCA9685 boards sharing an I2C bus, at different addresses, as a single PWM with
+	// consecutive channels: channel 16 is the first channel of the second board, and so on
+	Chain struct {
+		devices [MaxChainedBoards]Device
+		count   int
+	}
+)
+
+// NewDevice creates a new instance of Device
+//
+// Parameters:
+//
+// bus: The I2C bus the board is connected to
+// addressOffset: The offset set with the address pins of the board, from 0 to MaxAddressOffset
+//
+// Returns:
+//
+// An instance of Device and an error if the bus is nil or the offset is out of range
+func NewDevice(bus I2C, addressOffset uint8) (*Device, tinygoerrors.ErrorCode) {
+	device := &Device{}
+	if err := device.init(bus, addressOffset); err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	return device, tinygoerrors.ErrorCodeNil
+}
+
+// init initializes a device in place
+//
+// Parameters:
+//
+// bus: The I2C bus the board is connected to
+// addressOffset: The offset set with the address pins of the board
+//
+// Returns:
+//
+// An error if the bus is nil or the offset is out of range
+func (d *Device) init(bus I2C, addressOffset uint8) tinygoerrors.ErrorCode {
+	if bus == nil {
+		return ErrorCodePCA9685NilBus
+	}
+	if addressOffset > MaxAddressOffset {
+		return ErrorCodePCA9685InvalidAddress
+	}
+	*d = Device{
+		bus:                 bus,
+		address:             DefaultAddress + uint16(addressOffset),
+		oscillatorFrequency: DefaultOscillatorFrequency,
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetOscillatorFrequency sets the frequency of the internal oscillator, measured on the board, so the pulse widths are
+// accurate. It must be called before the first handler configures the board
+//
+// Parameters:
+//
+// frequency: The oscillator frequency in Hz
+//
+// Returns:
+//
+// An error if the frequency is zero
+func (d *Device) SetOscillatorFrequency(frequency uint32) tinygoerrors.ErrorCode {
+	if frequency == 0 {
+		return ErrorCodePCA9685InvalidOscillatorFrequency
+	}
+	d.oscillatorFrequency = frequency
+	return tinygoerrors.ErrorCodeNil
+}
+
+// writeRegister writes a register of the board
+//
+// Parameters:
+//
+// register: The register address
+// value: The value to write
+//
+// Returns:
+//
+// The error returned by the bus, if any
+func (d *Device) writeRegister(register byte, value byte) error {
+	d.buffer[0] = register
+	d.buffer[1] = value
+	return d.bus.Tx(d.address, d.buffer[:2], nil)
+}
+
+// Configure sets the frequency of the board from the period of the configuration. All the channels of a board share
+// its frequency, so configuring it again with the same period, as every handler does, leaves the outputs untouched
+//
+// Parameters:
+//
+// config: The PWM configuration, only the period is used
+//
+// Returns:
+//
+// ErrInvalidPeriod if the period is out of the prescaler range or different from the configured one, or the error
+// returned by the bus
+func (d *Device) Configure(config machine.PWMConfig) error {
+	// Calculate the prescaler, rounded to the nearest
+	counts := uint64(d.oscillatorFrequency) * config.Period / 1000000000
+	prescale := (counts+uint64(Top)/2)/uint64(Top) - 1
+	if config.Period == 0 || counts < uint64(Top) || prescale < minPrescale || prescale > maxPrescale {
+		return ErrInvalidPeriod
+	}
+	if d.isConfigured {
+		if uint8(prescale) != d.prescale {
+			return ErrInvalidPeriod
+		}
+		return nil
+	}
+
+	// The prescaler can only be written in sleep mode
+	if err := d.writeRegister(registerMode1, mode1Sleep|mode1AutoIncrement); err != nil {
+		return err
+	}
+	if err := d.writeRegister(registerPrescale, uint8(prescale)); err != nil {
+		return err
+	}
+	if err := d.writeRegister(registerMode2, mode2OutputDrive); err != nil {
+		return err
+	}
+	if err := d.writeRegister(registerMode1, mode1AutoIncrement); err != nil {
+		return err
+	}
+	time.Sleep(oscillatorStartUpUs * time.Microsecond)
+	if err := d.writeRegister(registerMode1, mode1Restart|mode1AutoIncrement); err != nil {
+		return err
+	}
+	d.prescale = uint8(prescale)
+	d.isConfigured = true
+	return nil
+}
+
+// Channel returns the channel of a pin, the pins are the channel numbers
+//
+// Parameters:
+//
+// pin: The channel number, from 0 to 15
+//
+// Returns:
+//
+// The channel and ErrInvalidChannel if the pin is not a channel of the board
+func (d *Device) Channel(pin machine.Pin) (uint8, error) {
+	if pin >= ChannelsCount {
+		return 0, ErrInvalidChannel
+	}
+	return uint8(pin), nil
+}
+
+// Top returns the value of a full duty cycle
+//
+// Returns:
+//
+// The counter steps per period
+func (d *Device) Top() uint32 {
+	return Top
+}
+
+// Set sets the duty cycle of a channel. The pulses start at the beginning of the period, a value of 0 keeps the
+// output low and a value of Top or higher keeps it high. The writes failed by the bus are counted, since the PWM
+// interface doesn't return errors
+//
+// Parameters:
+//
+// channel: The channel, from 0 to 15
+// value: The duty cycle, from 0 to Top
+func (d *Device) Set(channel uint8, value uint32) {
+	if channel >= ChannelsCount {
+		return
+	}
+
+	// Write the ON and OFF counts of the channel at once with the register auto-increment
+	var on, off uint16
+	switch {
+	case value == 0:
+		off = fullBit << 8
+	case value >= Top:
+		on = fullBit << 8
+	default:
+		off = uint16(value)
+	}
+	d.buffer[0] = registerLED0 + 4*channel
+	d.buffer[1] = byte(on)
+	d.buffer[2] = byte(on >> 8)
+	d.buffer[3] = byte(off)
+	d.buffer[4] = byte(off >> 8)
+	if err := d.bus.Tx(d.address, d.buffer[:], nil); err != nil {
+		d.failedWrites++
+	}
+}
+
+// FailedWrites returns the number of duty cycle writes failed by the bus
+//
+// Returns:
+//
+// The number of failed writes
+func (d *Device) FailedWrites() uint32 {
+	return d.failedWrites
+}
+
+// NewChain creates a new instance of Chain
+//
+// Parameters:
+//
+// bus: The I2C bus the boards are connected to
+// addressOffsets: The offsets set with the address pins of every board, in the order of their channels
+//
+// Returns:
+//
+// An instance of Chain and an error if the bus is nil, no board or too many boards are given or an offset is out of
+// range
+func NewChain(bus I2C, addressOffsets ...uint8) (*Chain, tinygoerrors.ErrorCode) {
+	if len(addressOffsets) == 0 {
+		return nil, ErrorCodePCA9685EmptyChain
+	}
+	if len(addressOffsets) > MaxChainedBoards {
+		return nil, ErrorCodePCA9685ChainTooLong
+	}
+	chain := &Chain{count: len(addressOffsets)}
+	for index, addressOffset := range addressOffsets {
+		if err := chain.devices[index].init(bus, addressOffset); err != tinygoerrors.ErrorCodeNil {
+			return nil, err
+		}
+	}
+	return chain, tinygoerrors.ErrorCodeNil
+}
+
+// Device returns a board of the chain, e.g. to set its oscillator frequency
+//
+// Parameters:
+//
+// index: The position of the board in the chain
+//
+// Returns:
+//
+// The board, or nil if the index is out of range
+func (c *Chain) Device(index int) *Device {
+	if index < 0 || index >= c.count {
+		return nil
+	}
+	return &c.devices[index]
+}
+
+// Configure sets the frequency of every board of the chain
+//
+// Parameters:
+//
+// config: The PWM configuration, only the period is used
+//
+// Returns:
+//
+// The first error returned by the boards, if any
+func (c *Chain) Configure(config machine.PWMConfig) error {
+	for index := 0; index < c.count; index++ {
+		if err := c.devices[index].Configure(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Channel returns the channel of a pin, the pins are the channel numbers across the chain
+//
+// Parameters:
+//
+// pin: The channel number, 16 per board
+//
+// Returns:
+//
+// The channel and ErrInvalidChannel if the pin is not a channel of the chain
+func (c *Chain) Channel(pin machine.Pin) (uint8, error) {
+	if int(pin) >= c.count*ChannelsCount {
+		return 0, ErrInvalidChannel
+	}
+	return uint8(pin), nil
+}
+
+// Top returns the value of a full duty cycle
+//
+// Returns:
+//
+// The counter steps per period
+func (c *Chain) Top() uint32 {
+	return Top
+}
+
+// Set sets the duty cycle of a channel of the chain
+//
+// Parameters:
+//
+// channel: The channel, 16 per board
+// value: The duty cycle, from 0 to Top
+func (c *Chain) Set(channel uint8, value uint32) {
+	index := int(channel) / ChannelsCount
+	if index >= c.count {
+		return
+	}
+	c.devices[index].Set(channel%ChannelsCount, value)
+}
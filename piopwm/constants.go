@@ -0,0 +1,73 @@
+package piopwm
+
+const (
+	// BlocksCount is the number of PIO blocks of the RP2040
+	BlocksCount = 2
+
+	// MaxChannels is the number of channels of a block, one per state machine
+	MaxChannels = 4
+
+	// CyclesPerTick is the number of state machine cycles of every iteration of the counting loop, the duty cycles
+	// and Top are counted in these ticks
+	CyclesPerTick = 3
+
+	// cyclesPerPeriodOverhead is the number of cycles of every period spent outside the counting loop: pulling the
+	// duty cycle and copying it and the period to the scratch registers, plus the last iteration of the loop
+	cyclesPerPeriodOverhead = 6
+)
+
+// program is the pulse generator run by every state machine, with one optional side-set pin:
+//
+//	    pull noblock    side 0  ; take the newest duty cycle, or keep the last one
+//	    mov x, osr              ; x = duty cycle
+//	    mov y, isr              ; y = period, loaded once into the ISR
+//	countloop:
+//	    jmp x!=y noset
+//	    jmp skip        side 1  ; the pin goes high once the counter reaches the duty cycle
+//	noset:
+//	    nop
+//	skip:
+//	    jmp y-- countloop
+//
+// It is loaded at the start of the instruction memory, wrapping from its last instruction to the first one
+var program = [...]uint16{
+	0x9080,
+	0xa027,
+	0xa046,
+	0x00a5,
+	0x1806,
+	0xa042,
+	0x0083,
+}
+
+const (
+	// Instructions executed on the state machines while setting them up
+	instructionSetPinDirsOutput = 0xe081
+	instructionPullBlock        = 0x80a0
+	instructionOutISR32         = 0x60c0
+	instructionJumpStart        = 0x0000
+
+	// CTRL register fields
+	ctrlSMEnableShift      = 0
+	ctrlSMRestartShift     = 4
+	ctrlClkDivRestartShift = 8
+
+	// SHIFTCTRL register fields, the reset value shifts both ways to the right
+	shiftCtrlReset   = 0x000c0000
+	shiftCtrlFJoinRX = 1 << 31
+
+	// EXECCTRL register fields
+	execCtrlSideEnable      = 1 << 30
+	execCtrlWrapTopShift    = 12
+	execCtrlWrapBottomShift = 7
+
+	// PINCTRL register fields, the side-set count includes the enable bit of the optional side-set
+	pinCtrlSideSetCountShift = 29
+	pinCtrlSetCountShift     = 26
+	pinCtrlSideSetBaseShift  = 10
+	pinCtrlSetBaseShift      = 5
+	pinCtrlSideSetCount      = 2
+
+	// CLKDIV register fields, the state machines run at the system clock
+	clkDivIntShift = 16
+)
@@ -0,0 +1,30 @@
+package piopwm
+
+import (
+	"errors"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodePIOPWMStartNumber is the starting number for PIO PWM related error codes.
+	ErrorCodePIOPWMStartNumber uint16 = 5720
+)
+
+const (
+	ErrorCodePIOPWMInvalidBlock tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodePIOPWMStartNumber)
+	ErrorCodePIOPWMBlockInUse
+)
+
+var (
+	// ErrTooManyChannels is returned by Channel once every state machine of the block is taken, it is a Go error as
+	// required by the tinygo-pwm PWM interface
+	ErrTooManyChannels = errors.New("piopwm: too many channels")
+
+	// ErrInvalidPeriod is returned by Configure for the periods too short for the program or too long for its
+	// counter, or different from the period the block is already running at, since all the channels share it
+	ErrInvalidPeriod = errors.New("piopwm: invalid period")
+
+	// ErrNotConfigured is returned by Channel before the period is configured
+	ErrNotConfigured = errors.New("piopwm: not configured")
+)
@@ -0,0 +1,209 @@
+//go:build tinygo && rp2040
+
+package piopwm
+
+import (
+	"device/rp"
+	"machine"
+	"runtime/volatile"
+	"unsafe"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// smRegisters is the layout of the registers of a state machine
+	smRegisters struct {
+		clkDiv    volatile.Register32
+		execCtrl  volatile.Register32
+		shiftCtrl volatile.Register32
+		addr      volatile.Register32
+		instr     volatile.Register32
+		pinCtrl   volatile.Register32
+	}
+
+	// PWM generates servo pulses with the state machines of a PIO block of the RP2040, implementing the tinygo-pwm
+	// PWM interface so the handlers of the package drive it like the PWM slices of the MCU. Each state machine
+	// drives one pin, counting the period down and raising the pin once the counter reaches the duty cycle, so the
+	// pulses take no CPU time at all once the duty cycles are set. It frees the PWM slices for other uses and adds up
+	// to eight more servo channels with both blocks.
+	//
+	// The state machines run at the system clock without a fractional divider, so the pulses have no jitter and a
+	// resolution of CyclesPerTick cycles, 24ns at 125MHz. The duty cycles and Top are counted in that resolution.
+	//
+	// A PWM takes over the whole block: its instruction memory holds the program and its four state machines are
+	// the channels
+	PWM struct {
+		pio          *rp.PIO0_Type
+		pinMode      machine.PinMode
+		stateMachine *[MaxChannels]smRegisters
+		txFIFO       *[MaxChannels]volatile.Register32
+		pins         [MaxChannels]machine.Pin
+		count        int
+		periodNs     uint64
+		top          uint32
+	}
+)
+
+var (
+	// isBlockInUse tracks the blocks taken by an instance of PWM
+	isBlockInUse [BlocksCount]bool
+)
+
+// NewPWM creates a new instance of PWM, loading the program into the block
+//
+// Parameters:
+//
+// block: The PIO block, 0 or 1
+//
+// Returns:
+//
+// An instance of PWM with no channels and an error if the block doesn't exist or is already in use
+func NewPWM(block uint8) (*PWM, tinygoerrors.ErrorCode) {
+	if block >= BlocksCount {
+		return nil, ErrorCodePIOPWMInvalidBlock
+	}
+	if isBlockInUse[block] {
+		return nil, ErrorCodePIOPWMBlockInUse
+	}
+	isBlockInUse[block] = true
+
+	p := &PWM{pio: rp.PIO0, pinMode: machine.PinPIO0}
+	resetMask := uint32(rp.RESETS_RESET_PIO0)
+	if block == 1 {
+		p.pio = rp.PIO1
+		p.pinMode = machine.PinPIO1
+		resetMask = rp.RESETS_RESET_PIO1
+	}
+	p.stateMachine = (*[MaxChannels]smRegisters)(unsafe.Pointer(&p.pio.SM0_CLKDIV))
+	p.txFIFO = (*[MaxChannels]volatile.Register32)(unsafe.Pointer(&p.pio.TXF0))
+
+	// Bring the block out of reset, then stop its state machines and load the program at the start of the memory
+	rp.RESETS.RESET.ClearBits(resetMask)
+	for !rp.RESETS.RESET_DONE.HasBits(resetMask) {
+	}
+	p.pio.CTRL.Set(0)
+	instructions := (*[32]volatile.Register32)(unsafe.Pointer(&p.pio.INSTR_MEM0))
+	for index, instruction := range program {
+		instructions[index].Set(uint32(instruction))
+	}
+	return p, tinygoerrors.ErrorCodeNil
+}
+
+// Configure sets the period of the pulses. All the channels share the period, so configuring it again with the same
+// period, as every handler does, is allowed
+//
+// Parameters:
+//
+// config: The PWM configuration, only the period is used
+//
+// Returns:
+//
+// ErrInvalidPeriod if the period is too short, too long or different from the configured one
+func (p *PWM) Configure(config machine.PWMConfig) error {
+	if p.periodNs != 0 {
+		if config.Period != p.periodNs {
+			return ErrInvalidPeriod
+		}
+		return nil
+	}
+
+	// Every period takes the counting loop from Top down to zero plus the overhead
+	cycles := uint64(machine.CPUFrequency()) * config.Period / 1e9
+	if cycles < cyclesPerPeriodOverhead+CyclesPerTick || cycles > uint64(^uint32(0)) {
+		return ErrInvalidPeriod
+	}
+	p.periodNs = config.Period
+	p.top = uint32((cycles - cyclesPerPeriodOverhead) / CyclesPerTick)
+	return nil
+}
+
+// Channel returns the channel of a pin, setting up a state machine to drive it the first time
+//
+// Parameters:
+//
+// pin: The GPIO pin
+//
+// Returns:
+//
+// The channel, ErrNotConfigured if the period is not set yet and ErrTooManyChannels if every state machine is taken
+func (p *PWM) Channel(pin machine.Pin) (uint8, error) {
+	for index := 0; index < p.count; index++ {
+		if p.pins[index] == pin {
+			return uint8(index), nil
+		}
+	}
+	if p.periodNs == 0 {
+		return 0, ErrNotConfigured
+	}
+	if p.count == MaxChannels {
+		return 0, ErrTooManyChannels
+	}
+	channel := uint8(p.count)
+	sm := &p.stateMachine[channel]
+
+	// Hand the pin over to the block and set up the state machine, the pin being both the side-set and the set pin
+	pin.Configure(machine.PinConfig{Mode: p.pinMode})
+	sm.clkDiv.Set(1 << clkDivIntShift)
+	sm.execCtrl.Set(
+		execCtrlSideEnable |
+			uint32(len(program)-1)<<execCtrlWrapTopShift |
+			0<<execCtrlWrapBottomShift,
+	)
+	sm.shiftCtrl.Set(shiftCtrlReset)
+	sm.pinCtrl.Set(
+		pinCtrlSideSetCount<<pinCtrlSideSetCountShift |
+			1<<pinCtrlSetCountShift |
+			uint32(pin)<<pinCtrlSideSetBaseShift |
+			uint32(pin)<<pinCtrlSetBaseShift,
+	)
+	p.pio.CTRL.SetBits(1<<(ctrlSMRestartShift+channel) | 1<<(ctrlClkDivRestartShift+channel))
+
+	// Drive the pin, load the period into the ISR and start from the beginning of the program with the pin low
+	sm.instr.Set(instructionSetPinDirsOutput)
+	p.txFIFO[channel].Set(p.top)
+	sm.instr.Set(instructionPullBlock)
+	sm.instr.Set(instructionOutISR32)
+	sm.instr.Set(instructionJumpStart)
+	p.txFIFO[channel].Set(^uint32(0))
+
+	p.pins[channel] = pin
+	p.count++
+	p.pio.CTRL.SetBits(1 << (ctrlSMEnableShift + channel))
+	return channel, nil
+}
+
+// Top returns the value of a full duty cycle
+//
+// Returns:
+//
+// The period in ticks of CyclesPerTick cycles
+func (p *PWM) Top() uint32 {
+	return p.top
+}
+
+// Set sets the duty cycle of a channel, taken by the state machine at the start of the next period
+//
+// Parameters:
+//
+// channel: The channel
+// value: The pulse width in ticks of CyclesPerTick cycles, from 0 to Top
+func (p *PWM) Set(channel uint8, value uint32) {
+	if int(channel) >= p.count {
+		return
+	}
+
+	// The counter never reaches the largest value, which keeps the pin low
+	if value == 0 {
+		value = ^uint32(0)
+	} else {
+		value = min(value, p.top)
+	}
+
+	// Flush the duty cycles not taken yet by toggling the join of the FIFOs, so the latest one is the next taken
+	sm := &p.stateMachine[channel]
+	shiftCtrl := sm.shiftCtrl.Get()
+	sm.shiftCtrl.Set(shiftCtrl ^ shiftCtrlFJoinRX)
+	sm.shiftCtrl.Set(shiftCtrl)
+	p.txFIFO[channel].Set(value)
+}
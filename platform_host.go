@@ -0,0 +1,60 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Pin is the pin connected to a servo. Host builds have no machine package, so it is a plain number, which lets
+	// the angle math and the limit logic be tested with go test
+	Pin uint8
+
+	// PWMConfig mirrors the machine package PWM configuration on host builds
+	PWMConfig struct {
+		Period uint64
+	}
+
+	// PWM mirrors the tinygo-pwm interface on host builds, so fakes can stand in for the PWM peripherals
+	PWM interface {
+		Configure(config PWMConfig) error
+		Channel(pin Pin) (channel uint8, err error)
+		Top() uint32
+		Set(channel uint8, value uint32)
+	}
+)
+
+const (
+	// NoPin is the pin of the handlers that don't write to a PWM channel
+	NoPin Pin = 0xff
+)
+
+// configurePWM configures the PWM peripheral with the given period and gets the channel of the pin
+//
+// Parameters:
+//
+// pwm: The PWM interface to configure
+// pin: The pin connected to the servo
+// period: The period of the PWM signal in nanoseconds
+//
+// Returns:
+//
+// The PWM channel of the pin and an error if the PWM could not be configured
+func configurePWM(pwm PWM, pin Pin, period uint32) (uint8, tinygoerrors.ErrorCode) {
+	// Configure the PWM
+	if err := pwm.Configure(
+		PWMConfig{
+			Period: uint64(period),
+		},
+	); err != nil {
+		return 0, ErrorCodeServoFailedToConfigurePWM
+	}
+
+	// Get the channel from the pin
+	channel, err := pwm.Channel(pin)
+	if err != nil {
+		return 0, ErrorCodeServoFailedToGetPWMChannel
+	}
+	return channel, tinygoerrors.ErrorCodeNil
+}
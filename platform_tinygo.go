@@ -0,0 +1,52 @@
+//go:build tinygo
+
+package tinygo_servo
+
+import (
+	"machine"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygopwm "github.com/ralvarezdev/tinygo-pwm"
+)
+
+type (
+	// Pin is the pin connected to a servo, the machine package pin on TinyGo builds
+	Pin = machine.Pin
+
+	// PWM is the PWM peripheral driving the servos, the tinygo-pwm interface on TinyGo builds
+	PWM = tinygopwm.PWM
+)
+
+const (
+	// NoPin is the pin of the handlers that don't write to a PWM channel
+	NoPin = machine.NoPin
+)
+
+// configurePWM configures the PWM peripheral with the given period and gets the channel of the pin
+//
+// Parameters:
+//
+// pwm: The PWM interface to configure
+// pin: The pin connected to the servo
+// period: The period of the PWM signal in nanoseconds
+//
+// Returns:
+//
+// The PWM channel of the pin and an error if the PWM could not be configured
+func configurePWM(pwm PWM, pin Pin, period uint32) (uint8, tinygoerrors.ErrorCode) {
+	// Configure the PWM
+	if err := pwm.Configure(
+		machine.PWMConfig{
+			Period: uint64(period),
+		},
+	); err != nil {
+		return 0, ErrorCodeServoFailedToConfigurePWM
+	}
+
+	// Get the channel from the pin
+	channel, err := pwm.Channel(pin)
+	if err != nil {
+		return 0, ErrorCodeServoFailedToGetPWMChannel
+	}
+	return channel, tinygoerrors.ErrorCodeNil
+}
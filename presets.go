@@ -0,0 +1,93 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// PresetSG90 is the profile of the TowerPro SG90 micro servo
+	PresetSG90 = Profile{
+		Name:           "SG90",
+		Frequency:      50,
+		MinPulseWidth:  500000,
+		MaxPulseWidth:  2400000,
+		ActuationRange: StandardActuationRange,
+	}
+
+	// PresetMG996R is the profile of the TowerPro MG996R high-torque servo
+	PresetMG996R = Profile{
+		Name:           "MG996R",
+		Frequency:      50,
+		MinPulseWidth:  500000,
+		MaxPulseWidth:  2500000,
+		ActuationRange: StandardActuationRange,
+	}
+
+	// PresetDS3218 is the profile of the 270 degrees variant of the DSServo DS3218 digital servo
+	PresetDS3218 = Profile{
+		Name:           "DS3218",
+		Frequency:      50,
+		MinPulseWidth:  500000,
+		MaxPulseWidth:  2500000,
+		ActuationRange: WideActuationRange,
+	}
+
+	// PresetDS3225 is the profile of the 270 degrees variant of the DSServo DS3225 waterproof digital servo
+	PresetDS3225 = Profile{
+		Name:           "DS3225",
+		Frequency:      50,
+		MinPulseWidth:  500000,
+		MaxPulseWidth:  2500000,
+		ActuationRange: WideActuationRange,
+	}
+
+	// PresetHS422 is the profile of the Hitec HS-422 standard servo
+	PresetHS422 = Profile{
+		Name:           "HS422",
+		Frequency:      50,
+		MinPulseWidth:  553000,
+		MaxPulseWidth:  2425000,
+		ActuationRange: StandardActuationRange,
+	}
+
+	// Presets is the registry of the built-in profiles, to be searched with FindProfile
+	Presets = [...]Profile{
+		PresetSG90,
+		PresetMG996R,
+		PresetDS3218,
+		PresetDS3225,
+		PresetHS422,
+	}
+)
+
+// NewFromPreset creates a new instance of DefaultHandler for a servo model, centered at the middle of its actuation
+// range and free to move over all of it unless overridden
+//
+// Parameters:
+//
+// pwm: The PWM interface to control the servo
+// pin: The pin connected to the servo
+// preset: The profile of the servo model, one of the presets or a generated profile
+// overrides: The options overriding the parameters of the profile or the defaults
+//
+// Returns:
+//
+// An instance of DefaultHandler and an error if any occurred during initialization
+func NewFromPreset(
+	pwm PWM,
+	pin Pin,
+	preset Profile,
+	overrides ...Option,
+) (*DefaultHandler, tinygoerrors.ErrorCode) {
+	return newHandler(
+		pwm,
+		pin,
+		handlerOptions{
+			frequency:      preset.Frequency,
+			minPulseWidth:  preset.MinPulseWidth,
+			maxPulseWidth:  preset.MaxPulseWidth,
+			actuationRange: preset.ActuationRange,
+		},
+		overrides,
+	)
+}
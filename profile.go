@@ -0,0 +1,32 @@
+package tinygo_servo
+
+type (
+	// Profile holds the electrical and mechanical parameters of a servo model. Profile tables can be generated from a
+	// CSV file with the servoprofiles tool, they are declared as arrays of values so TinyGo keeps them in flash
+	Profile struct {
+		Name           string
+		Frequency      uint16
+		MinPulseWidth  Nanoseconds
+		MaxPulseWidth  Nanoseconds
+		ActuationRange uint16
+	}
+)
+
+// FindProfile looks up a profile by its name
+//
+// Parameters:
+//
+// profiles: The profile table to search
+// name: The name of the servo model
+//
+// Returns:
+//
+// The profile and true if it was found, or an empty profile and false otherwise
+func FindProfile(profiles []Profile, name string) (Profile, bool) {
+	for index := range profiles {
+		if profiles[index].Name == name {
+			return profiles[index], true
+		}
+	}
+	return Profile{}, false
+}
@@ -0,0 +1,111 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+)
+
+var (
+	// pulseSink prevents the compiler from optimizing away the benchmarked calculations
+	pulseSink uint64
+)
+
+// floatPulse calculates the pulse width of an angle of the default pulse range with floating-point math, the baseline
+// the integer math of the handlers is compared to
+func floatPulse(milliDegrees uint32) Nanoseconds {
+	minPulseWidth, maxPulseWidth := float64(DefaultMinPulseWidth), float64(DefaultMaxPulseWidth)
+	return Nanoseconds(minPulseWidth + (maxPulseWidth-minPulseWidth)*float64(milliDegrees)/
+		(float64(StandardActuationRange)*1000))
+}
+
+// newBenchmarkHandler creates a handler over the default pulse range on a fake PWM peripheral, released when the
+// benchmark ends
+func newBenchmarkHandler(b *testing.B, options ...Option) *DefaultHandler {
+	b.Helper()
+	handler, err := NewHandler(newFakePWM(), 0, options...)
+	if err != 0 {
+		b.Fatalf("NewHandler: %d", err)
+	}
+	b.Cleanup(handler.Release)
+	return handler
+}
+
+// newFullCalibrationTable creates a calibration table with every point it can hold, evenly spread over the standard
+// actuation range, so its interpolation searches the longest
+func newFullCalibrationTable(tb testing.TB) *CalibrationTable {
+	tb.Helper()
+	var points [MaxCalibrationPoints]CalibrationPoint
+	for index := range points {
+		points[index] = CalibrationPoint{
+			AngleMilliDegrees: uint32(index) * uint32(StandardActuationRange) * 1000 / (MaxCalibrationPoints - 1),
+			PulseWidth:        DefaultMinPulseWidth + Nanoseconds(index)*100000 + Nanoseconds(index%2)*10000,
+		}
+	}
+	table, err := NewCalibrationTable(points[:])
+	if err != 0 {
+		tb.Fatalf("NewCalibrationTable: %d", err)
+	}
+	return table
+}
+
+// TestCalculatePulseMatchesFloat checks the integer pulse math stays within a nanosecond of the floating-point one
+// over the whole actuation range
+func TestCalculatePulseMatchesFloat(t *testing.T) {
+	handler, err := NewHandler(newFakePWM(), 0)
+	if err != 0 {
+		t.Fatalf("NewHandler: %d", err)
+	}
+	releaseAll(t, handler)
+	for milliDegrees := uint32(0); milliDegrees <= uint32(StandardActuationRange)*1000; milliDegrees += 7 {
+		pulse, expected := handler.calculatePulseMilliDegrees(milliDegrees), floatPulse(milliDegrees)
+		if pulse > expected+1 || expected > pulse+1 {
+			t.Fatalf("pulse at %d millidegrees = %dns, want %dns", milliDegrees, pulse, expected)
+		}
+	}
+}
+
+// BenchmarkFloatPulse measures the floating-point baseline of the pulse math
+func BenchmarkFloatPulse(b *testing.B) {
+	for index := 0; index < b.N; index++ {
+		pulseSink += uint64(floatPulse(uint32(index) % 180001))
+	}
+}
+
+// BenchmarkCalculatePulseMilliDegrees measures the linear pulse math of the handlers
+func BenchmarkCalculatePulseMilliDegrees(b *testing.B) {
+	handler := newBenchmarkHandler(b)
+	b.ResetTimer()
+	for index := 0; index < b.N; index++ {
+		pulseSink += uint64(handler.calculatePulseMilliDegrees(uint32(index) % 180001))
+	}
+}
+
+// BenchmarkCalculatePulseMilliDegreesNeutral measures the pulse math of the handlers with a neutral pulse width,
+// mapping each side of the center to its own segment
+func BenchmarkCalculatePulseMilliDegreesNeutral(b *testing.B) {
+	handler := newBenchmarkHandler(b, WithNeutralPulseWidth(1450000))
+	b.ResetTimer()
+	for index := 0; index < b.N; index++ {
+		pulseSink += uint64(handler.calculatePulseMilliDegrees(uint32(index) % 180001))
+	}
+}
+
+// BenchmarkCalculatePulseMilliDegreesCalibrated measures the pulse math of the handlers interpolating a full
+// calibration table
+func BenchmarkCalculatePulseMilliDegreesCalibrated(b *testing.B) {
+	handler := newBenchmarkHandler(b, WithCalibrationTable(newFullCalibrationTable(b)))
+	b.ResetTimer()
+	for index := 0; index < b.N; index++ {
+		pulseSink += uint64(handler.calculatePulseMilliDegrees(uint32(index) % 180001))
+	}
+}
+
+// BenchmarkCalculateDuty measures the conversion of the pulse widths into PWM counter values
+func BenchmarkCalculateDuty(b *testing.B) {
+	handler := newBenchmarkHandler(b)
+	b.ResetTimer()
+	for index := 0; index < b.N; index++ {
+		pulseSink += uint64(handler.calculateDuty(DefaultMinPulseWidth + Nanoseconds(index%2000001)))
+	}
+}
@@ -0,0 +1,160 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// RecordedAngle is a commanded angle of a recording, stamped with the time since the recording started
+	RecordedAngle struct {
+		OffsetMs     uint32
+		MilliDegrees uint32
+	}
+)
+
+// StartRecording records the commanded angles into a buffer, e.g. for teach workflows where a pot or a joystick
+// drives the servo once and the motion is replayed later. The current angle is recorded first, so the replays start
+// from the same pose, and the angles are stamped with the time of the last Update. The angles of the angle, pulse
+// width and damped target commands are recorded, not the steps the speed caps and the timed, profiled, damped or
+// blended moves take towards them, since the replay takes them again. The recording stops by itself once the buffer
+// is full
+//
+// Parameters:
+//
+// buffer: The buffer the angles are recorded into, owned by the handler until the recording is stopped
+//
+// Returns:
+//
+// An error if the buffer is empty
+func (h *DefaultHandler) StartRecording(buffer []RecordedAngle) tinygoerrors.ErrorCode {
+	if len(buffer) == 0 {
+		return h.reportError(ErrorCodeServoEmptyRecording)
+	}
+	h.isReplaying = false
+	h.recording = buffer
+	h.recording[0] = RecordedAngle{MilliDegrees: h.GetAngleMilliDegrees()}
+	h.recordingCount = 1
+	h.recordingStartMs = h.lastUpdateMs
+	h.isRecording = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// StopRecording stops the recording in progress
+//
+// Returns:
+//
+// The number of recorded angles
+func (h *DefaultHandler) StopRecording() int {
+	h.isRecording = false
+	return h.recordingCount
+}
+
+// IsRecording checks if a recording is in progress
+//
+// Returns:
+//
+// True if the commanded angles are being recorded, false otherwise
+func (h *DefaultHandler) IsRecording() bool {
+	return h.isRecording
+}
+
+// recordAngle appends a commanded angle to the recording in progress
+//
+// Parameters:
+//
+// milliDegrees: The commanded angle in millidegrees
+func (h *DefaultHandler) recordAngle(milliDegrees uint32) {
+	if !h.isRecording {
+		return
+	}
+	h.recording[h.recordingCount] = RecordedAngle{
+		OffsetMs:     h.lastUpdateMs - h.recordingStartMs,
+		MilliDegrees: milliDegrees,
+	}
+	h.recordingCount++
+	if h.recordingCount == len(h.recording) {
+		h.isRecording = false
+	}
+}
+
+// Replay replays the last recording, driven by Update, with the same timing it was recorded with. The angles are
+// commanded like any other angle, so the speed caps still apply
+//
+// Parameters:
+//
+// loop: True to restart the replay from the first angle once it ends, until StopReplay, StopMove or another angle
+// command
+//
+// Returns:
+//
+// An error if nothing was recorded, the command is discarded or the servo motor could not be initialized
+func (h *DefaultHandler) Replay(loop bool) tinygoerrors.ErrorCode {
+	h.isRecording = false
+	if h.recordingCount == 0 {
+		return h.reportError(ErrorCodeServoEmptyRecording)
+	}
+
+	// Configure the PWM on the first command if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the command must be discarded because movement is disabled
+	if !h.IsMovementEnabled() && h.disabledCommandPolicy == DisabledCommandPolicyDiscard {
+		return h.reportError(ErrorCodeServoMovementDisabled)
+	}
+
+	// Start the replay at the last update, replacing any other move
+	h.StopMove()
+	h.replayIndex = 0
+	h.replayStartMs = h.lastUpdateMs
+	h.isReplayLooped = loop
+	h.isReplaying = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// StopReplay stops the replay in progress, holding the current angle
+func (h *DefaultHandler) StopReplay() {
+	h.isReplaying = false
+}
+
+// IsReplaying checks if a replay is in progress
+//
+// Returns:
+//
+// True if a replay is in progress, false otherwise
+func (h *DefaultHandler) IsReplaying() bool {
+	return h.isReplaying
+}
+
+// updateReplay advances the replay in progress, commanding the newest angle that is due
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateReplay(nowMs uint32) {
+	if !h.isReplaying {
+		return
+	}
+
+	// Skip to the newest angle that is due
+	elapsedMs := nowMs - h.replayStartMs
+	index := h.replayIndex
+	for index < h.recordingCount && h.recording[index].OffsetMs <= elapsedMs {
+		index++
+	}
+	if index > h.replayIndex {
+		h.replayIndex = index
+		_ = h.commandAngle(h.recording[index-1].MilliDegrees)
+	}
+
+	// End or restart the replay after the last angle
+	if h.replayIndex == h.recordingCount {
+		if !h.isReplayLooped {
+			h.isReplaying = false
+			return
+		}
+		h.replayIndex = 0
+		h.replayStartMs = nowMs
+	}
+}
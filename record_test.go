@@ -0,0 +1,52 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestRecordingStoresCommandedAngles checks a recording stores the commanded angles once, not every step of the moves
+// ramping towards them, so a capped move doesn't fill the buffer
+func TestRecordingStoresCommandedAngles(t *testing.T) {
+	handler, err := NewOutputHandler(servotest.NewOutput())
+	if err != 0 {
+		t.Fatalf("NewOutputHandler: %d", err)
+	}
+	releaseAll(t, handler)
+	if err = handler.SetMaxSpeed(30); err != 0 {
+		t.Fatalf("SetMaxSpeed: %d", err)
+	}
+
+	var buffer [8]RecordedAngle
+	if err = handler.StartRecording(buffer[:]); err != 0 {
+		t.Fatalf("StartRecording: %d", err)
+	}
+	if err = handler.SetAngle(150); err != 0 {
+		t.Fatalf("SetAngle: %d", err)
+	}
+	for nowMs := uint32(1); nowMs <= 2500; nowMs++ {
+		handler.Update(nowMs)
+	}
+	if err = handler.SetAngle(120); err != 0 {
+		t.Fatalf("SetAngle: %d", err)
+	}
+	if err = handler.MoveTo(60, 500); err != 0 {
+		t.Fatalf("MoveTo: %d", err)
+	}
+	for nowMs := uint32(2501); nowMs <= 5000; nowMs++ {
+		handler.Update(nowMs)
+	}
+
+	if count := handler.StopRecording(); count != 3 {
+		t.Fatalf("%d angles recorded, want 3", count)
+	}
+	expected := [3]RecordedAngle{{0, 90000}, {0, 150000}, {2500, 120000}}
+	for index, angle := range expected {
+		if buffer[index] != angle {
+			t.Errorf("recorded angle %d = %+v, want %+v", index, buffer[index], angle)
+		}
+	}
+}
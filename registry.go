@@ -0,0 +1,127 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// firstRegisteredHandler and lastRegisteredHandler are the ends of the list of the initialized handlers, whatever
+	// their output, tracked to detect PWM period conflicts and to suspend or park every servo at once. The list is
+	// linked through the handlers, so any number of them can be initialized, e.g. the 256 channels of a full PCA9685
+	// chain
+	firstRegisteredHandler *DefaultHandler
+	lastRegisteredHandler  *DefaultHandler
+
+	// pwmPeriodConflictPrefix is the prefix message for PWM period conflicts, followed by the name of the owner
+	pwmPeriodConflictPrefix = []byte("Servo PWM period conflicts with the period set by servo:")
+
+	// unnamedOwnerBuffer is logged as the owner name when the conflicting handler has no name
+	unnamedOwnerBuffer = []byte("unnamed")
+
+	// releaseMessage is the message logged when the handler is released
+	releaseMessage = []byte("Servo released")
+)
+
+// registerHandler adds an initialized handler to the registry
+//
+// Parameters:
+//
+// h: The handler to register
+//
+// Returns:
+//
+// An error if another handler uses the same PWM peripheral with a different period
+func registerHandler(h *DefaultHandler) tinygoerrors.ErrorCode {
+	// Check if another handler would have its period reprogrammed, custom outputs don't share a peripheral
+	if owner := FindPWMOwner(h.pwm); owner != nil && owner != h && owner.period != h.period {
+		if h.logger != nil {
+			name := owner.Name()
+			if len(name) == 0 {
+				name = unnamedOwnerBuffer
+			}
+			h.addLogName()
+			h.logger.AddMessage(pwmPeriodConflictPrefix, false)
+			h.logger.AddSpace()
+			h.logger.AddMessage(name, true)
+			h.logger.Error()
+		}
+		return ErrorCodeServoPWMPeriodConflict
+	}
+
+	// Append the handler, so the first handler of a peripheral stays its owner
+	h.nextRegistered = nil
+	if lastRegisteredHandler == nil {
+		firstRegisteredHandler = h
+	} else {
+		lastRegisteredHandler.nextRegistered = h
+	}
+	lastRegisteredHandler = h
+	return tinygoerrors.ErrorCodeNil
+}
+
+// unregisterHandler removes a handler from the registry
+//
+// Parameters:
+//
+// h: The handler to unregister
+func unregisterHandler(h *DefaultHandler) {
+	var previous *DefaultHandler
+	for handler := firstRegisteredHandler; handler != nil; handler = handler.nextRegistered {
+		if handler != h {
+			previous = handler
+			continue
+		}
+
+		// Unlink the handler, keeping the order of the others
+		if previous == nil {
+			firstRegisteredHandler = h.nextRegistered
+		} else {
+			previous.nextRegistered = h.nextRegistered
+		}
+		if lastRegisteredHandler == h {
+			lastRegisteredHandler = previous
+		}
+		h.nextRegistered = nil
+		return
+	}
+}
+
+// FindPWMOwner returns the first initialized handler using a PWM peripheral, whose period every other handler on
+// the peripheral must match. PWM implementations must be comparable, like the pointers of the machine package
+//
+// Parameters:
+//
+// pwm: The PWM peripheral to look up
+//
+// Returns:
+//
+// The handler owning the peripheral period, or nil if no initialized handler uses it
+func FindPWMOwner(pwm PWM) *DefaultHandler {
+	if pwm == nil {
+		return nil
+	}
+	for handler := firstRegisteredHandler; handler != nil; handler = handler.nextRegistered {
+		if handler.pwm == pwm {
+			return handler
+		}
+	}
+	return nil
+}
+
+// Release stops the pulses and removes the handler from the PWM ownership registry, so the peripheral can be used
+// with a different period. The handler is initialized again by the next command
+func (h *DefaultHandler) Release() {
+	// Check if the handler is initialized
+	if !h.isInitialized {
+		return
+	}
+	h.stopPulses()
+	h.isInitialized = false
+	unregisterHandler(h)
+
+	// Log the release if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(releaseMessage)
+	}
+}
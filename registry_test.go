@@ -0,0 +1,67 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+)
+
+// releaseAll releases the handlers at the end of a test, so the registry is empty for the next one
+func releaseAll(t *testing.T, handlers ...*DefaultHandler) {
+	t.Cleanup(func() {
+		for _, handler := range handlers {
+			handler.Release()
+		}
+	})
+}
+
+// TestRegistryHasNoCapacityLimit initializes the 256 channels of a full PCA9685 chain and more on a single
+// peripheral, none of them conflicting
+func TestRegistryHasNoCapacityLimit(t *testing.T) {
+	pwm := newFakePWM()
+	handlers := make([]*DefaultHandler, 0, 300)
+	for index := 0; index < cap(handlers); index++ {
+		handler, err := NewHandler(pwm, Pin(index%256))
+		if err != 0 {
+			t.Fatalf("handler %d: %d", index, err)
+		}
+		handlers = append(handlers, handler)
+	}
+	releaseAll(t, handlers...)
+	if owner := FindPWMOwner(pwm); owner != handlers[0] {
+		t.Fatalf("owner is not the first handler")
+	}
+}
+
+// TestRegistryDetectsPeriodConflicts checks a handler can't reprogram the period of a peripheral owned by another
+// one, and that the ownership passes to the next handler once the owner is released
+func TestRegistryDetectsPeriodConflicts(t *testing.T) {
+	pwm := newFakePWM()
+	first, err := NewHandler(pwm, 0)
+	if err != 0 {
+		t.Fatalf("first: %d", err)
+	}
+	second, err := NewHandler(pwm, 1)
+	if err != 0 {
+		t.Fatalf("second: %d", err)
+	}
+	releaseAll(t, first, second)
+
+	if _, err = NewHandler(pwm, 2, WithFrequency(333)); err != ErrorCodeServoPWMPeriodConflict {
+		t.Fatalf("conflicting handler: %d, expected %d", err, ErrorCodeServoPWMPeriodConflict)
+	}
+	other, err := NewHandler(newFakePWM(), 0, WithFrequency(333))
+	if err != 0 {
+		t.Fatalf("handler on another peripheral: %d", err)
+	}
+	releaseAll(t, other)
+
+	first.Release()
+	if owner := FindPWMOwner(pwm); owner != second {
+		t.Fatalf("owner is not the second handler after releasing the first")
+	}
+	second.Release()
+	if owner := FindPWMOwner(pwm); owner != nil {
+		t.Fatalf("peripheral still owned after releasing every handler")
+	}
+}
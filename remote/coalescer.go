@@ -0,0 +1,139 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// coalescerChannel is the newest command waiting to be dispatched to a channel
+	coalescerChannel struct {
+		command        Command
+		hasCommand     bool
+		lastDispatchMs uint32
+		hasDispatched  bool
+	}
+
+	// Coalescer adapts the rate of remote commands to the rate the servos can follow. Bursts are coalesced per channel
+	// keeping only the newest command, and every channel is dispatched at most once per interval, so a flood of
+	// commands from a slider never queues up lag
+	Coalescer struct {
+		dispatcher        *Dispatcher
+		minIntervalMs     uint32
+		channels          [MaxChannels]coalescerChannel
+		coalescedCommands uint32
+	}
+)
+
+// NewCoalescer creates a new instance of Coalescer
+//
+// Parameters:
+//
+// dispatcher: The dispatcher the coalesced commands are sent to
+// minIntervalMs: The minimum time between two commands dispatched to the same channel, zero dispatches the newest
+// command on every update
+//
+// Returns:
+//
+// An instance of Coalescer and an error if the dispatcher is nil
+func NewCoalescer(dispatcher *Dispatcher, minIntervalMs uint32) (*Coalescer, tinygoerrors.ErrorCode) {
+	if dispatcher == nil {
+		return nil, ErrorCodeRemoteNilDispatcher
+	}
+	return &Coalescer{
+		dispatcher:    dispatcher,
+		minIntervalMs: minIntervalMs,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// Push adds a received command, replacing the pending command of its channel if it is newer
+//
+// Parameters:
+//
+// command: The received command
+//
+// Returns:
+//
+// An error if the channel is out of range or the command is older than the pending one
+func (c *Coalescer) Push(command Command) tinygoerrors.ErrorCode {
+	if int(command.Channel) >= MaxChannels {
+		return ErrorCodeRemoteUnknownChannel
+	}
+	channel := &c.channels[command.Channel]
+
+	// Replace the pending command, links may reorder packets so an older one never replaces a newer one
+	if channel.hasCommand {
+		if !isSequenceNewer(command.Sequence, channel.command.Sequence) {
+			return ErrorCodeRemoteReplayedCommand
+		}
+		c.coalescedCommands++
+	}
+	channel.command = command
+	channel.hasCommand = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// PushFrame decodes an angle command frame and pushes it
+//
+// Parameters:
+//
+// frame: The received frame
+//
+// Returns:
+//
+// An error if the frame is not an angle command or the command was rejected
+func (c *Coalescer) PushFrame(frame *Frame) tinygoerrors.ErrorCode {
+	command, err := DecodeCommand(frame)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	return c.Push(command)
+}
+
+// Update dispatches the pending commands of the channels whose interval has elapsed, it must be called periodically
+// from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The first error returned by the dispatcher, the remaining channels are still dispatched
+func (c *Coalescer) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	firstErr := tinygoerrors.ErrorCodeNil
+	for index := range c.channels {
+		channel := &c.channels[index]
+		if !channel.hasCommand {
+			continue
+		}
+		if channel.hasDispatched && nowMs-channel.lastDispatchMs < c.minIntervalMs {
+			continue
+		}
+
+		// Dispatch the newest command
+		channel.hasCommand = false
+		channel.lastDispatchMs = nowMs
+		channel.hasDispatched = true
+		if err := c.dispatcher.Dispatch(channel.command); err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Reset discards the pending commands
+func (c *Coalescer) Reset() {
+	for index := range c.channels {
+		c.channels[index] = coalescerChannel{}
+	}
+}
+
+// CoalescedCommands returns the number of intermediate commands dropped because a newer one arrived before they were
+// dispatched
+//
+// Returns:
+//
+// The number of coalesced commands
+func (c *Coalescer) CoalescedCommands() uint32 {
+	return c.coalescedCommands
+}
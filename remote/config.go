@@ -0,0 +1,537 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/internal/crc16"
+)
+
+type (
+	// ConfigEntry is the configuration of a channel pushed to a node: its absolute limits, its trim, its speed cap
+	// and the angle it is moved to by ApplyFailsafe
+	ConfigEntry struct {
+		Channel         uint8
+		LeftLimitAngle  uint16
+		RightLimitAngle uint16
+		Trim            int16
+		MaxSpeed        uint16
+		FailsafeAngle   uint16
+	}
+
+	// ConfigAck is the response to a configuration commit, reporting the version and checksum the node committed and
+	// whether the configuration was applied
+	ConfigAck struct {
+		Version   uint16
+		Checksum  uint16
+		ErrorCode tinygoerrors.ErrorCode
+	}
+
+	// Config is a configuration pushed from a central controller to a node in one transaction. It is sent as a begin
+	// frame with the version and the number of entries, one entry frame per channel and a commit frame with the
+	// version and a CRC-16 of the version and the entries, which the node acknowledges once it applied the whole
+	// configuration or none of it
+	Config struct {
+		version uint16
+		entries [MaxChannels]ConfigEntry
+		count   int
+	}
+
+	// configChannel is a servo a ConfigReceiver configures
+	configChannel struct {
+		servo         ConfigServo
+		failsafeAngle uint16
+		hasFailsafe   bool
+	}
+
+	// ConfigReceiver stages the configuration pushed to a node and applies it on commit. The entries are applied only
+	// if all of them arrived and match the checksum of the commit, and the channels already configured are restored
+	// if a servo rejects its entry, so the nodes of a fleet never run a partial configuration
+	ConfigReceiver struct {
+		channels      [MaxChannels]configChannel
+		staged        [MaxChannels]ConfigEntry
+		stagedCount   int
+		expectedCount int
+		stagedVersion uint16
+		isStaging     bool
+		stagingErr    tinygoerrors.ErrorCode
+		version       uint16
+		checksum      uint16
+		hasConfig     bool
+	}
+)
+
+// NewConfig creates a new instance of Config
+//
+// Parameters:
+//
+// version: The version of the configuration, reported back by the nodes that applied it
+//
+// Returns:
+//
+// An instance of Config with no entries
+func NewConfig(version uint16) *Config {
+	return &Config{version: version}
+}
+
+// Add adds the configuration of a channel, replacing its previous entry if any
+//
+// Parameters:
+//
+// entry: The configuration of the channel
+//
+// Returns:
+//
+// An error if the channel is out of range or the failsafe angle is outside the limits
+func (c *Config) Add(entry ConfigEntry) tinygoerrors.ErrorCode {
+	if err := validateConfigEntry(entry); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	for index := 0; index < c.count; index++ {
+		if c.entries[index].Channel == entry.Channel {
+			c.entries[index] = entry
+			return tinygoerrors.ErrorCodeNil
+		}
+	}
+	c.entries[c.count] = entry
+	c.count++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Version returns the version of the configuration
+//
+// Returns:
+//
+// The version of the configuration
+func (c *Config) Version() uint16 {
+	return c.version
+}
+
+// Checksum returns the CRC-16 of the version and the entries, sent in the commit frame
+//
+// Returns:
+//
+// The checksum of the configuration
+func (c *Config) Checksum() uint16 {
+	return configChecksum(c.version, c.entries[:c.count])
+}
+
+// FramesCount returns the number of frames the configuration is sent with
+//
+// Returns:
+//
+// The number of frames, the begin and commit frames included
+func (c *Config) FramesCount() int {
+	return c.count + 2
+}
+
+// EncodeFrame writes a frame of the configuration, so the frames can be streamed one at a time
+//
+// Parameters:
+//
+// index: The index of the frame, zero for the begin frame and FramesCount minus one for the commit frame
+// frame: The frame the configuration is written to
+//
+// Returns:
+//
+// An error if the index is out of range
+func (c *Config) EncodeFrame(index int, frame *Frame) tinygoerrors.ErrorCode {
+	switch {
+	case index == 0:
+		frame.Type = FrameTypeConfigBegin
+		frame.Length = ConfigBeginPayloadSize
+		encodeUint16(c.version, frame.Payload[0:])
+		frame.Payload[2] = uint8(c.count)
+	case index > 0 && index <= c.count:
+		encodeConfigEntry(c.entries[index-1], frame)
+	case index == c.count+1:
+		frame.Type = FrameTypeConfigCommit
+		frame.Length = ConfigCommitPayloadSize
+		encodeUint16(c.version, frame.Payload[0:])
+		encodeUint16(c.Checksum(), frame.Payload[2:])
+	default:
+		return ErrorCodeRemoteConfigTooLarge
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// CheckAck checks the acknowledgment of a node against the configuration
+//
+// Parameters:
+//
+// frame: The received acknowledgment frame
+//
+// Returns:
+//
+// An error if the frame is not a configuration acknowledgment, the node committed another version or checksum, or
+// the error the node reported
+func (c *Config) CheckAck(frame *Frame) tinygoerrors.ErrorCode {
+	ack, err := DecodeConfigAck(frame)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if ack.ErrorCode != tinygoerrors.ErrorCodeNil {
+		return ack.ErrorCode
+	}
+	if ack.Version != c.version || ack.Checksum != c.Checksum() {
+		return ErrorCodeRemoteConfigMismatch
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// EncodeConfigAck writes a configuration acknowledgment into a frame
+//
+// Parameters:
+//
+// ack: The acknowledgment to encode
+// frame: The frame the acknowledgment is written to
+func EncodeConfigAck(ack ConfigAck, frame *Frame) {
+	frame.Type = FrameTypeConfigAck
+	frame.Length = ConfigAckPayloadSize
+	encodeUint16(ack.Version, frame.Payload[0:])
+	encodeUint16(ack.Checksum, frame.Payload[2:])
+	encodeUint16(uint16(ack.ErrorCode), frame.Payload[4:])
+}
+
+// DecodeConfigAck reads a configuration acknowledgment from a frame
+//
+// Parameters:
+//
+// frame: The frame to decode
+//
+// Returns:
+//
+// The decoded acknowledgment and an error if the frame is not a well-formed configuration acknowledgment
+func DecodeConfigAck(frame *Frame) (ConfigAck, tinygoerrors.ErrorCode) {
+	if frame.Type != FrameTypeConfigAck {
+		return ConfigAck{}, ErrorCodeRemoteUnexpectedFrameType
+	}
+	if frame.Length != ConfigAckPayloadSize {
+		return ConfigAck{}, ErrorCodeRemoteInvalidFrameLength
+	}
+	return ConfigAck{
+		Version:   decodeUint16(frame.Payload[0:]),
+		Checksum:  decodeUint16(frame.Payload[2:]),
+		ErrorCode: tinygoerrors.ErrorCode(decodeUint16(frame.Payload[4:])),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// NewConfigReceiver creates a new instance of ConfigReceiver
+//
+// Returns:
+//
+// An instance of ConfigReceiver with no channels
+func NewConfigReceiver() *ConfigReceiver {
+	return &ConfigReceiver{}
+}
+
+// SetChannel sets the servo configured through a channel
+//
+// Parameters:
+//
+// channel: The channel index
+// servo: The servo configured through the channel
+//
+// Returns:
+//
+// An error if the channel index is out of range or the servo is nil
+func (r *ConfigReceiver) SetChannel(channel uint8, servo ConfigServo) tinygoerrors.ErrorCode {
+	if int(channel) >= MaxChannels {
+		return ErrorCodeRemoteUnknownChannel
+	}
+	if servo == nil {
+		return ErrorCodeRemoteNilServo
+	}
+	r.channels[channel] = configChannel{servo: servo}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// HandleFrame stages a configuration frame, applying the staged configuration on the commit frame
+//
+// Parameters:
+//
+// frame: The received frame
+// response: The frame the acknowledgment is written to, on commit frames
+//
+// Returns:
+//
+// True if an acknowledgment was written into the response, and the error of the frame or of the commit, if any
+func (r *ConfigReceiver) HandleFrame(frame *Frame, response *Frame) (bool, tinygoerrors.ErrorCode) {
+	switch frame.Type {
+	case FrameTypeConfigBegin:
+		return false, r.begin(frame)
+	case FrameTypeConfigEntry:
+		return false, r.stage(frame)
+	case FrameTypeConfigCommit:
+		if frame.Length != ConfigCommitPayloadSize {
+			return false, ErrorCodeRemoteInvalidFrameLength
+		}
+		ack := ConfigAck{
+			Version:  decodeUint16(frame.Payload[0:]),
+			Checksum: decodeUint16(frame.Payload[2:]),
+		}
+		ack.ErrorCode = r.commit(ack.Version, ack.Checksum)
+		EncodeConfigAck(ack, response)
+		return true, ack.ErrorCode
+	default:
+		return false, ErrorCodeRemoteUnexpectedFrameType
+	}
+}
+
+// begin starts staging a configuration, discarding the one being staged
+//
+// Parameters:
+//
+// frame: The begin frame
+//
+// Returns:
+//
+// An error if the frame is malformed or announces more entries than channels
+func (r *ConfigReceiver) begin(frame *Frame) tinygoerrors.ErrorCode {
+	r.isStaging = false
+	if frame.Length != ConfigBeginPayloadSize {
+		return ErrorCodeRemoteInvalidFrameLength
+	}
+	if int(frame.Payload[2]) > MaxChannels {
+		return ErrorCodeRemoteConfigTooLarge
+	}
+	r.stagedVersion = decodeUint16(frame.Payload[0:])
+	r.expectedCount = int(frame.Payload[2])
+	r.stagedCount = 0
+	r.stagingErr = tinygoerrors.ErrorCodeNil
+	r.isStaging = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// stage stages a configuration entry. The errors are also kept to fail the commit, so the controller learns about
+// them from the acknowledgment
+//
+// Parameters:
+//
+// frame: The entry frame
+//
+// Returns:
+//
+// An error if no configuration is being staged, the frame is malformed or there are more entries than announced
+func (r *ConfigReceiver) stage(frame *Frame) tinygoerrors.ErrorCode {
+	if !r.isStaging {
+		return ErrorCodeRemoteConfigNotStarted
+	}
+	err := tinygoerrors.ErrorCodeNil
+	if frame.Length != ConfigEntryPayloadSize {
+		err = ErrorCodeRemoteInvalidFrameLength
+	} else if r.stagedCount == r.expectedCount {
+		err = ErrorCodeRemoteConfigTooLarge
+	}
+	if err != tinygoerrors.ErrorCodeNil {
+		if r.stagingErr == tinygoerrors.ErrorCodeNil {
+			r.stagingErr = err
+		}
+		return err
+	}
+	r.staged[r.stagedCount] = decodeConfigEntry(frame)
+	r.stagedCount++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// commit applies the staged configuration. A retransmitted commit of the applied configuration is acknowledged
+// without applying it again, so a lost acknowledgment doesn't make the controller push it twice
+//
+// Parameters:
+//
+// version: The version of the commit frame
+// checksum: The checksum of the commit frame
+//
+// Returns:
+//
+// An error if the configuration is incomplete, doesn't match the commit or was rejected by a servo
+func (r *ConfigReceiver) commit(version uint16, checksum uint16) tinygoerrors.ErrorCode {
+	if !r.isStaging {
+		if r.hasConfig && r.version == version && r.checksum == checksum {
+			return tinygoerrors.ErrorCodeNil
+		}
+		return ErrorCodeRemoteConfigNotStarted
+	}
+	r.isStaging = false
+
+	// Check the staged configuration
+	if r.stagingErr != tinygoerrors.ErrorCodeNil {
+		return r.stagingErr
+	}
+	if r.stagedCount != r.expectedCount {
+		return ErrorCodeRemoteConfigIncomplete
+	}
+	entries := r.staged[:r.stagedCount]
+	if version != r.stagedVersion || checksum != configChecksum(version, entries) {
+		return ErrorCodeRemoteConfigMismatch
+	}
+	for _, entry := range entries {
+		if err := validateConfigEntry(entry); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		if r.channels[entry.Channel].servo == nil {
+			return ErrorCodeRemoteUnknownChannel
+		}
+	}
+
+	// Apply the entries, keeping the previous configuration of the channels to restore it if a servo fails
+	var previous [MaxChannels]ConfigEntry
+	for index, entry := range entries {
+		servo := r.channels[entry.Channel].servo
+		previous[index] = ConfigEntry{
+			LeftLimitAngle:  servo.LeftLimit(),
+			RightLimitAngle: servo.RightLimit(),
+			Trim:            servo.GetTrim(),
+			MaxSpeed:        servo.GetMaxSpeed(),
+		}
+		if err := applyConfigEntry(servo, entry); err != tinygoerrors.ErrorCodeNil {
+			for restored := index; restored >= 0; restored-- {
+				applyConfigEntry(r.channels[entries[restored].Channel].servo, previous[restored])
+			}
+			return err
+		}
+	}
+
+	// Keep the failsafe angles and the committed version
+	for _, entry := range entries {
+		r.channels[entry.Channel].failsafeAngle = entry.FailsafeAngle
+		r.channels[entry.Channel].hasFailsafe = true
+	}
+	r.version = version
+	r.checksum = checksum
+	r.hasConfig = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Version returns the version and the checksum of the last applied configuration
+//
+// Returns:
+//
+// The version, the checksum and true if a configuration was applied
+func (r *ConfigReceiver) Version() (uint16, uint16, bool) {
+	return r.version, r.checksum, r.hasConfig
+}
+
+// ApplyFailsafe moves every configured channel to its failsafe angle, e.g. when the link to the controller is lost
+//
+// Returns:
+//
+// The first error returned by a servo, if any. The remaining channels are moved anyway
+func (r *ConfigReceiver) ApplyFailsafe() tinygoerrors.ErrorCode {
+	firstErr := tinygoerrors.ErrorCodeNil
+	for index := range r.channels {
+		channel := &r.channels[index]
+		if channel.servo == nil || !channel.hasFailsafe {
+			continue
+		}
+		if err := channel.servo.SetAngle(channel.failsafeAngle); err != tinygoerrors.ErrorCodeNil &&
+			firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// validateConfigEntry checks the channel, the limits and the failsafe angle of a configuration entry
+//
+// Parameters:
+//
+// entry: The entry to check
+//
+// Returns:
+//
+// An error if the channel is out of range, the limits are inverted or the failsafe angle is outside them
+func validateConfigEntry(entry ConfigEntry) tinygoerrors.ErrorCode {
+	if int(entry.Channel) >= MaxChannels {
+		return ErrorCodeRemoteUnknownChannel
+	}
+	if entry.LeftLimitAngle > entry.RightLimitAngle {
+		return ErrorCodeRemoteInvalidChannelBounds
+	}
+	if entry.FailsafeAngle < entry.LeftLimitAngle || entry.FailsafeAngle > entry.RightLimitAngle {
+		return ErrorCodeRemoteAngleOutOfBounds
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// applyConfigEntry applies the limits, the trim and the speed cap of a configuration entry to a servo. The limits
+// are first widened to cover both the current and the new ones, so the trimmed center is always within them
+//
+// Parameters:
+//
+// servo: The servo to configure
+// entry: The configuration to apply
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func applyConfigEntry(servo ConfigServo, entry ConfigEntry) tinygoerrors.ErrorCode {
+	leftLimitAngle := min(servo.LeftLimit(), entry.LeftLimitAngle)
+	rightLimitAngle := max(servo.RightLimit(), entry.RightLimitAngle)
+	if err := servo.SetLimits(leftLimitAngle, rightLimitAngle); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if err := servo.SetTrim(entry.Trim); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if err := servo.SetLimits(entry.LeftLimitAngle, entry.RightLimitAngle); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	return servo.SetMaxSpeed(entry.MaxSpeed)
+}
+
+// encodeConfigEntry writes a configuration entry into a frame
+//
+// Parameters:
+//
+// entry: The entry to encode
+// frame: The frame the entry is written to
+func encodeConfigEntry(entry ConfigEntry, frame *Frame) {
+	frame.Type = FrameTypeConfigEntry
+	frame.Length = ConfigEntryPayloadSize
+	frame.Payload[0] = entry.Channel
+	encodeUint16(entry.LeftLimitAngle, frame.Payload[1:])
+	encodeUint16(entry.RightLimitAngle, frame.Payload[3:])
+	encodeUint16(uint16(entry.Trim), frame.Payload[5:])
+	encodeUint16(entry.MaxSpeed, frame.Payload[7:])
+	encodeUint16(entry.FailsafeAngle, frame.Payload[9:])
+}
+
+// decodeConfigEntry reads a configuration entry from a frame of the right length
+//
+// Parameters:
+//
+// frame: The frame to decode
+//
+// Returns:
+//
+// The decoded entry
+func decodeConfigEntry(frame *Frame) ConfigEntry {
+	return ConfigEntry{
+		Channel:         frame.Payload[0],
+		LeftLimitAngle:  decodeUint16(frame.Payload[1:]),
+		RightLimitAngle: decodeUint16(frame.Payload[3:]),
+		Trim:            int16(decodeUint16(frame.Payload[5:])),
+		MaxSpeed:        decodeUint16(frame.Payload[7:]),
+		FailsafeAngle:   decodeUint16(frame.Payload[9:]),
+	}
+}
+
+// configChecksum calculates the CRC-16 of a configuration, over the version and the encoded entries
+//
+// Parameters:
+//
+// version: The version of the configuration
+// entries: The entries of the configuration
+//
+// Returns:
+//
+// The checksum
+func configChecksum(version uint16, entries []ConfigEntry) uint16 {
+	var frame Frame
+	encodeUint16(version, frame.Payload[0:])
+	checksum := crc16.Checksum(crc16.Initial, frame.Payload[:2])
+	for _, entry := range entries {
+		encodeConfigEntry(entry, &frame)
+		checksum = crc16.Checksum(checksum, frame.Payload[:ConfigEntryPayloadSize])
+	}
+	return checksum
+}
@@ -0,0 +1,38 @@
+package remote
+
+const (
+	// JitterBufferSize is the number of setpoints a JitterBuffer holds
+	JitterBufferSize = 32
+
+	// MaxChannels is the number of channels a Dispatcher can route commands to
+	MaxChannels = 16
+
+	// FrameSync is the byte every frame starts with
+	FrameSync byte = 0xA5
+
+	// MaxFramePayloadSize is the maximum number of payload bytes in a frame
+	MaxFramePayloadSize = 32
+
+	// FrameOverheadSize is the number of bytes of a frame besides its payload: sync, type, length and checksum
+	FrameOverheadSize = 4
+
+	// AngleCommandPayloadSize is the payload size of the angle command frames: channel, sequence and angle
+	AngleCommandPayloadSize = 5
+
+	// AckPayloadSize is the payload size of the acknowledgment frames: channel, sequence, error code and angle
+	AckPayloadSize = 7
+
+	// ConfigBeginPayloadSize is the payload size of the configuration begin frames: version and number of entries
+	ConfigBeginPayloadSize = 3
+
+	// ConfigEntryPayloadSize is the payload size of the configuration entry frames: channel, left and right limits,
+	// trim, maximum speed and failsafe angle
+	ConfigEntryPayloadSize = 11
+
+	// ConfigCommitPayloadSize is the payload size of the configuration commit frames: version and checksum
+	ConfigCommitPayloadSize = 4
+
+	// ConfigAckPayloadSize is the payload size of the configuration acknowledgment frames: version, checksum and error
+	// code
+	ConfigAckPayloadSize = 6
+)
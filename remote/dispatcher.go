@@ -0,0 +1,224 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// dispatcherChannel is a servo the Dispatcher routes commands to
+	dispatcherChannel struct {
+		servo        Servo
+		minAngle     uint16
+		maxAngle     uint16
+		lastSequence uint16
+		hasSequence  bool
+	}
+
+	// Dispatcher validates remote angle commands and routes them to the servo of their channel. Every channel has its
+	// own bounds and sequence tracking, so a corrupted or replayed packet can't command an out-of-bounds angle or
+	// reapply a stale command
+	Dispatcher struct {
+		channels         [MaxChannels]dispatcherChannel
+		rejectedCommands uint32
+	}
+)
+
+// NewDispatcher creates a new instance of Dispatcher
+//
+// Returns:
+//
+// An instance of Dispatcher with no channels
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// SetChannel sets the servo and the bounds of a channel
+//
+// Parameters:
+//
+// channel: The channel index
+// servo: The servo commanded through the channel
+// minAngle: The lowest absolute angle the channel accepts
+// maxAngle: The highest absolute angle the channel accepts
+//
+// Returns:
+//
+// An error if the channel index is out of range, the servo is nil or the bounds are inverted
+func (d *Dispatcher) SetChannel(channel uint8, servo Servo, minAngle uint16, maxAngle uint16) tinygoerrors.ErrorCode {
+	if int(channel) >= MaxChannels {
+		return ErrorCodeRemoteUnknownChannel
+	}
+	if servo == nil {
+		return ErrorCodeRemoteNilServo
+	}
+	if minAngle > maxAngle {
+		return ErrorCodeRemoteInvalidChannelBounds
+	}
+	d.channels[channel] = dispatcherChannel{
+		servo:    servo,
+		minAngle: minAngle,
+		maxAngle: maxAngle,
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ResetSequence forgets the last sequence number of a channel, so the next command is accepted whatever its sequence
+// number, e.g. when the sender restarts
+//
+// Parameters:
+//
+// channel: The channel index
+func (d *Dispatcher) ResetSequence(channel uint8) {
+	if int(channel) < MaxChannels {
+		d.channels[channel].hasSequence = false
+	}
+}
+
+// validate checks a command against the bounds and the sequence number of its channel
+//
+// Parameters:
+//
+// command: The command to check
+//
+// Returns:
+//
+// The channel of the command and an error if the command must be rejected
+func (d *Dispatcher) validate(command Command) (*dispatcherChannel, tinygoerrors.ErrorCode) {
+	// Check if the channel exists
+	if int(command.Channel) >= MaxChannels || d.channels[command.Channel].servo == nil {
+		return nil, ErrorCodeRemoteUnknownChannel
+	}
+	channel := &d.channels[command.Channel]
+
+	// Check if the command is newer than the last accepted one
+	if channel.hasSequence && !isSequenceNewer(command.Sequence, channel.lastSequence) {
+		return nil, ErrorCodeRemoteReplayedCommand
+	}
+
+	// Check if the angle is within the bounds of the channel
+	if command.Angle < channel.minAngle || command.Angle > channel.maxAngle {
+		return nil, ErrorCodeRemoteAngleOutOfBounds
+	}
+	return channel, tinygoerrors.ErrorCodeNil
+}
+
+// Dispatch validates a command and applies it to the servo of its channel
+//
+// Parameters:
+//
+// command: The command to apply
+//
+// Returns:
+//
+// An error if the command was rejected or the servo failed
+func (d *Dispatcher) Dispatch(command Command) tinygoerrors.ErrorCode {
+	channel, err := d.validate(command)
+	if err != tinygoerrors.ErrorCodeNil {
+		d.rejectedCommands++
+		return err
+	}
+
+	// Accept the sequence number before applying, so a failing servo can't make the command replayable
+	channel.lastSequence = command.Sequence
+	channel.hasSequence = true
+	return channel.servo.SetAngle(command.Angle)
+}
+
+// DispatchFrame decodes an angle command frame and dispatches it
+//
+// Parameters:
+//
+// frame: The received frame
+//
+// Returns:
+//
+// An error if the frame is not an angle command, the command was rejected or the servo failed
+func (d *Dispatcher) DispatchFrame(frame *Frame) tinygoerrors.ErrorCode {
+	command, err := DecodeCommand(frame)
+	if err != tinygoerrors.ErrorCodeNil {
+		d.rejectedCommands++
+		return err
+	}
+	return d.Dispatch(command)
+}
+
+// RejectedCommands returns the number of commands rejected because of their channel, sequence number or bounds
+//
+// Returns:
+//
+// The number of rejected commands
+func (d *Dispatcher) RejectedCommands() uint32 {
+	return d.rejectedCommands
+}
+
+// isRetransmission checks if a command is a retransmission of the last accepted command of its channel
+//
+// Parameters:
+//
+// command: The command to check
+//
+// Returns:
+//
+// True if the channel already accepted a command with the same sequence number, false otherwise
+func (d *Dispatcher) isRetransmission(command Command) bool {
+	if int(command.Channel) >= MaxChannels {
+		return false
+	}
+	channel := &d.channels[command.Channel]
+	return channel.servo != nil && channel.hasSequence && channel.lastSequence == command.Sequence
+}
+
+// DispatchWithAck dispatches a command and builds its acknowledgment. A retransmission of the last accepted command of
+// a channel is acknowledged as applied without applying it again, so a lost acknowledgment doesn't make the sender
+// give up on a command that was delivered
+//
+// Parameters:
+//
+// command: The command to apply
+// ack: The acknowledgment to fill
+//
+// Returns:
+//
+// The error reported in the acknowledgment, if any
+func (d *Dispatcher) DispatchWithAck(command Command, ack *Ack) tinygoerrors.ErrorCode {
+	ack.Channel = command.Channel
+	ack.Sequence = command.Sequence
+	ack.Angle = 0
+
+	// Acknowledge the retransmissions of the last accepted command
+	err := tinygoerrors.ErrorCodeNil
+	if !d.isRetransmission(command) {
+		err = d.Dispatch(command)
+	}
+	ack.ErrorCode = err
+
+	// Report the resulting angle of the channel
+	if int(command.Channel) < MaxChannels && d.channels[command.Channel].servo != nil {
+		ack.Angle = d.channels[command.Channel].servo.GetAngle()
+	}
+	return err
+}
+
+// DispatchFrameWithAck decodes an angle command frame, dispatches it and writes its acknowledgment into a frame
+//
+// Parameters:
+//
+// frame: The received frame
+// response: The frame the acknowledgment is written to
+//
+// Returns:
+//
+// The error reported in the acknowledgment, if any
+func (d *Dispatcher) DispatchFrameWithAck(frame *Frame, response *Frame) tinygoerrors.ErrorCode {
+	var ack Ack
+	command, err := DecodeCommand(frame)
+	if err != tinygoerrors.ErrorCodeNil {
+		d.rejectedCommands++
+		ack.ErrorCode = err
+		EncodeAck(ack, response)
+		return err
+	}
+	err = d.DispatchWithAck(command, &ack)
+	EncodeAck(ack, response)
+	return err
+}
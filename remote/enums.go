@@ -0,0 +1,27 @@
+package remote
+
+type (
+	// FrameType is an enum to represent the kinds of frames of the remote protocol.
+	FrameType uint8
+
+	// decoderState is an enum to represent the stage of the frame being decoded.
+	decoderState uint8
+)
+
+const (
+	FrameTypeNil FrameType = iota
+	FrameTypeAngleCommand
+	FrameTypeAck
+	FrameTypeConfigBegin
+	FrameTypeConfigEntry
+	FrameTypeConfigCommit
+	FrameTypeConfigAck
+)
+
+const (
+	decoderStateSync decoderState = iota
+	decoderStateType
+	decoderStateLength
+	decoderStatePayload
+	decoderStateChecksum
+)
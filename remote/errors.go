@@ -0,0 +1,28 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeRemoteStartNumber is the starting number for remote command related error codes.
+	ErrorCodeRemoteStartNumber uint16 = 5420
+)
+
+const (
+	ErrorCodeRemoteNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeRemoteStartNumber)
+	ErrorCodeRemoteLateSetpoint
+	ErrorCodeRemoteUnknownChannel
+	ErrorCodeRemoteInvalidChannelBounds
+	ErrorCodeRemoteReplayedCommand
+	ErrorCodeRemoteAngleOutOfBounds
+	ErrorCodeRemoteInvalidFrameLength
+	ErrorCodeRemoteInvalidFrameChecksum
+	ErrorCodeRemoteUnexpectedFrameType
+	ErrorCodeRemoteBufferTooSmall
+	ErrorCodeRemoteNilDispatcher
+	ErrorCodeRemoteConfigNotStarted
+	ErrorCodeRemoteConfigTooLarge
+	ErrorCodeRemoteConfigIncomplete
+	ErrorCodeRemoteConfigMismatch
+)
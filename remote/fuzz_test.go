@@ -0,0 +1,82 @@
+//go:build !tinygo
+
+package remote
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/internal/fuzzservos"
+)
+
+// FuzzRemoteDecoder feeds arbitrary byte streams to a node, the decoded angle commands being dispatched and the
+// configuration frames handled, and checks no frame drives a servo outside its limits nor beyond its pulse range
+func FuzzRemoteDecoder(f *testing.F) {
+	var (
+		frame  Frame
+		buffer [MaxFramePayloadSize + FrameOverheadSize]byte
+		stream []byte
+	)
+	EncodeCommand(Command{Channel: 1, Sequence: 7, Angle: 170}, &frame)
+	size, _ := EncodeFrame(&frame, buffer[:])
+	f.Add(append([]byte{}, buffer[:size]...))
+
+	config := NewConfig(3)
+	for _, entry := range []ConfigEntry{
+		{Channel: 0, LeftLimitAngle: 10, RightLimitAngle: 170, Trim: 5, MaxSpeed: 120, FailsafeAngle: 90},
+		{Channel: 2, LeftLimitAngle: 0, RightLimitAngle: 270, Trim: -20, FailsafeAngle: 270},
+	} {
+		if err := config.Add(entry); err != tinygoerrors.ErrorCodeNil {
+			f.Fatalf("config entry: %d", err)
+		}
+	}
+	for index := 0; index < config.FramesCount(); index++ {
+		if err := config.EncodeFrame(index, &frame); err != tinygoerrors.ErrorCodeNil {
+			f.Fatalf("config frame %d: %d", index, err)
+		}
+		size, _ = EncodeFrame(&frame, buffer[:])
+		stream = append(stream, buffer[:size]...)
+	}
+	f.Add(stream)
+	f.Add([]byte{FrameSync, FrameSync, byte(FrameTypeAngleCommand), 0xFF, 0x00})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		dispatcher := NewDispatcher()
+		receiver := NewConfigReceiver()
+		servos := fuzzservos.New(t)
+		for channel, handler := range servos.Handlers {
+			if err := dispatcher.SetChannel(
+				uint8(channel),
+				handler,
+				handler.LeftLimit(),
+				handler.RightLimit(),
+			); err != tinygoerrors.ErrorCodeNil {
+				t.Fatalf("dispatcher channel %d: %d", channel, err)
+			}
+			if err := receiver.SetChannel(uint8(channel), handler); err != tinygoerrors.ErrorCodeNil {
+				t.Fatalf("receiver channel %d: %d", channel, err)
+			}
+		}
+
+		// Route the decoded frames as a node would, checking the servos after every byte
+		var (
+			decoder  Decoder
+			response Frame
+		)
+		for index, value := range data {
+			isComplete, _ := decoder.Feed(value)
+			if isComplete {
+				switch decoder.Frame().Type {
+				case FrameTypeAngleCommand:
+					_ = dispatcher.DispatchFrameWithAck(decoder.Frame(), &response)
+				default:
+					_, _ = receiver.HandleFrame(decoder.Frame(), &response)
+				}
+			}
+			servos.Update(t, uint32(index))
+		}
+		_ = receiver.ApplyFailsafe()
+
+		servos.CheckPulses(t)
+	})
+}
@@ -0,0 +1,26 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo Handler interface commanded by the remote command layer, so a channel
+	// can be bound to any handler and the protocols fuzzed against fakes
+	Servo interface {
+		SetAngle(angle uint16) tinygoerrors.ErrorCode
+		GetAngle() uint16
+	}
+
+	// ConfigServo is the subset of the tinygo-servo Handler interface configured by the remote configuration pushes
+	ConfigServo interface {
+		Servo
+		SetLimits(leftLimitAngle uint16, rightLimitAngle uint16) tinygoerrors.ErrorCode
+		LeftLimit() uint16
+		RightLimit() uint16
+		SetTrim(offsetDegrees int16) tinygoerrors.ErrorCode
+		GetTrim() int16
+		SetMaxSpeed(degreesPerSecond uint16) tinygoerrors.ErrorCode
+		GetMaxSpeed() uint16
+	}
+)
@@ -0,0 +1,208 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Frame is a frame of the remote protocol. On the wire it is laid out as the sync byte, the type, the payload
+	// length, the payload and a CRC-8 of the type, length and payload, so the same frames can be streamed over a UART
+	// or written as BLE characteristic values
+	Frame struct {
+		Type    FrameType
+		Length  uint8
+		Payload [MaxFramePayloadSize]byte
+	}
+
+	// Command is an angle command for a channel. The sequence number increases with every command sent to the
+	// channel, so replayed or reordered commands can be rejected
+	Command struct {
+		Channel  uint8
+		Sequence uint16
+		Angle    uint16
+	}
+
+	// Ack is the response to a command, reporting whether it was applied and the resulting angle of the channel, so
+	// the sender can retransmit the commands lost on unreliable links
+	Ack struct {
+		Channel   uint8
+		Sequence  uint16
+		ErrorCode tinygoerrors.ErrorCode
+		Angle     uint16
+	}
+
+	// Decoder reassembles frames from a byte stream, resynchronizing on the sync byte after malformed frames
+	Decoder struct {
+		state    decoderState
+		frame    Frame
+		index    uint8
+		checksum byte
+	}
+)
+
+// EncodeFrame writes a frame in its wire format
+//
+// Parameters:
+//
+// frame: The frame to encode
+// buffer: The buffer the frame is written to
+//
+// Returns:
+//
+// The number of bytes written and an error if the payload is too long or the buffer too small
+func EncodeFrame(frame *Frame, buffer []byte) (int, tinygoerrors.ErrorCode) {
+	// Check the frame and buffer sizes
+	if frame.Length > MaxFramePayloadSize {
+		return 0, ErrorCodeRemoteInvalidFrameLength
+	}
+	size := int(frame.Length) + FrameOverheadSize
+	if len(buffer) < size {
+		return 0, ErrorCodeRemoteBufferTooSmall
+	}
+
+	// Write the frame
+	buffer[0] = FrameSync
+	buffer[1] = byte(frame.Type)
+	buffer[2] = frame.Length
+	copy(buffer[3:], frame.Payload[:frame.Length])
+	buffer[size-1] = crc8(buffer[1 : size-1])
+	return size, tinygoerrors.ErrorCodeNil
+}
+
+// Feed adds a received byte to the frame being decoded
+//
+// Parameters:
+//
+// data: The received byte
+//
+// Returns:
+//
+// True when a complete frame is available through Frame, and an error if a malformed frame was discarded
+func (d *Decoder) Feed(data byte) (bool, tinygoerrors.ErrorCode) {
+	switch d.state {
+	case decoderStateSync:
+		if data == FrameSync {
+			d.state = decoderStateType
+		}
+	case decoderStateType:
+		d.frame.Type = FrameType(data)
+		d.checksum = crc8Update(0, data)
+		d.state = decoderStateLength
+	case decoderStateLength:
+		if data > MaxFramePayloadSize {
+			d.state = decoderStateSync
+			return false, ErrorCodeRemoteInvalidFrameLength
+		}
+		d.frame.Length = data
+		d.checksum = crc8Update(d.checksum, data)
+		d.index = 0
+		d.state = decoderStatePayload
+		if data == 0 {
+			d.state = decoderStateChecksum
+		}
+	case decoderStatePayload:
+		d.frame.Payload[d.index] = data
+		d.checksum = crc8Update(d.checksum, data)
+		d.index++
+		if d.index == d.frame.Length {
+			d.state = decoderStateChecksum
+		}
+	case decoderStateChecksum:
+		d.state = decoderStateSync
+		if data != d.checksum {
+			return false, ErrorCodeRemoteInvalidFrameChecksum
+		}
+		return true, tinygoerrors.ErrorCodeNil
+	}
+	return false, tinygoerrors.ErrorCodeNil
+}
+
+// Frame returns the last complete frame, it is overwritten by the next call to Feed
+//
+// Returns:
+//
+// The last decoded frame
+func (d *Decoder) Frame() *Frame {
+	return &d.frame
+}
+
+// Reset discards the frame being decoded
+func (d *Decoder) Reset() {
+	d.state = decoderStateSync
+}
+
+// EncodeCommand writes an angle command into a frame
+//
+// Parameters:
+//
+// command: The command to encode
+// frame: The frame the command is written to
+func EncodeCommand(command Command, frame *Frame) {
+	frame.Type = FrameTypeAngleCommand
+	frame.Length = AngleCommandPayloadSize
+	frame.Payload[0] = command.Channel
+	encodeUint16(command.Sequence, frame.Payload[1:])
+	encodeUint16(command.Angle, frame.Payload[3:])
+}
+
+// DecodeCommand reads an angle command from a frame
+//
+// Parameters:
+//
+// frame: The frame to decode
+//
+// Returns:
+//
+// The decoded command and an error if the frame is not a well-formed angle command
+func DecodeCommand(frame *Frame) (Command, tinygoerrors.ErrorCode) {
+	if frame.Type != FrameTypeAngleCommand {
+		return Command{}, ErrorCodeRemoteUnexpectedFrameType
+	}
+	if frame.Length != AngleCommandPayloadSize {
+		return Command{}, ErrorCodeRemoteInvalidFrameLength
+	}
+	return Command{
+		Channel:  frame.Payload[0],
+		Sequence: decodeUint16(frame.Payload[1:]),
+		Angle:    decodeUint16(frame.Payload[3:]),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// EncodeAck writes an acknowledgment into a frame
+//
+// Parameters:
+//
+// ack: The acknowledgment to encode
+// frame: The frame the acknowledgment is written to
+func EncodeAck(ack Ack, frame *Frame) {
+	frame.Type = FrameTypeAck
+	frame.Length = AckPayloadSize
+	frame.Payload[0] = ack.Channel
+	encodeUint16(ack.Sequence, frame.Payload[1:])
+	encodeUint16(uint16(ack.ErrorCode), frame.Payload[3:])
+	encodeUint16(ack.Angle, frame.Payload[5:])
+}
+
+// DecodeAck reads an acknowledgment from a frame
+//
+// Parameters:
+//
+// frame: The frame to decode
+//
+// Returns:
+//
+// The decoded acknowledgment and an error if the frame is not a well-formed acknowledgment
+func DecodeAck(frame *Frame) (Ack, tinygoerrors.ErrorCode) {
+	if frame.Type != FrameTypeAck {
+		return Ack{}, ErrorCodeRemoteUnexpectedFrameType
+	}
+	if frame.Length != AckPayloadSize {
+		return Ack{}, ErrorCodeRemoteInvalidFrameLength
+	}
+	return Ack{
+		Channel:   frame.Payload[0],
+		Sequence:  decodeUint16(frame.Payload[1:]),
+		ErrorCode: tinygoerrors.ErrorCode(decodeUint16(frame.Payload[3:])),
+		Angle:     decodeUint16(frame.Payload[5:]),
+	}, tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,178 @@
+package remote
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Setpoint is an angle command stamped with the time the sender issued it
+	Setpoint struct {
+		TimestampMs uint32
+		Angle       uint16
+	}
+
+	// JitterBuffer delays timestamped setpoints by a fixed playback delay and applies them at the cadence they were
+	// sent with, so streamed trajectories don't stutter with the jitter of serial, BLE or WiFi links
+	JitterBuffer struct {
+		servo               Servo
+		delayMs             uint32
+		setpoints           [JitterBufferSize]Setpoint
+		head                int
+		count               int
+		hasOffset           bool
+		offsetMs            uint32
+		hasLastApplied      bool
+		lastAppliedMs       uint32
+		lateSetpoints       uint32
+		overflowedSetpoints uint32
+	}
+)
+
+// NewJitterBuffer creates a new instance of JitterBuffer
+//
+// Parameters:
+//
+// servo: The servo the setpoints are applied to
+// delayMs: The playback delay in milliseconds, it must cover the worst expected link jitter
+//
+// Returns:
+//
+// An instance of JitterBuffer and an error if the servo is nil
+func NewJitterBuffer(servo Servo, delayMs uint32) (*JitterBuffer, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeRemoteNilServo
+	}
+	return &JitterBuffer{
+		servo:   servo,
+		delayMs: delayMs,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// playbackTimeMs maps a sender timestamp to the local time it must be applied at
+//
+// Parameters:
+//
+// timestampMs: The sender timestamp
+//
+// Returns:
+//
+// The local playback time in milliseconds
+func (j *JitterBuffer) playbackTimeMs(timestampMs uint32) uint32 {
+	return timestampMs + j.offsetMs + j.delayMs
+}
+
+// Push adds a setpoint received from the link. The clock offset between the sender and the device is tracked as the
+// lowest latency seen, so the playback delay is measured from the fastest delivery
+//
+// Parameters:
+//
+// setpoint: The received setpoint
+// nowMs: The local time the setpoint was received at, it may wrap around
+//
+// Returns:
+//
+// An error if the setpoint is older than the last applied one, in which case it is discarded
+func (j *JitterBuffer) Push(setpoint Setpoint, nowMs uint32) tinygoerrors.ErrorCode {
+	// Track the clock offset, a lower offset means this setpoint was delivered faster than the previous ones
+	offsetMs := nowMs - setpoint.TimestampMs
+	if !j.hasOffset || int32(offsetMs-j.offsetMs) < 0 {
+		j.offsetMs = offsetMs
+		j.hasOffset = true
+	}
+
+	// Discard the setpoints older than the last applied one
+	if j.hasLastApplied && int32(setpoint.TimestampMs-j.lastAppliedMs) <= 0 {
+		j.lateSetpoints++
+		return ErrorCodeRemoteLateSetpoint
+	}
+
+	// Drop the oldest setpoint if the buffer is full
+	if j.count == JitterBufferSize {
+		j.head = (j.head + 1) % JitterBufferSize
+		j.count--
+		j.overflowedSetpoints++
+	}
+
+	// Insert the setpoint keeping the buffer sorted by timestamp, links may reorder packets
+	index := j.count
+	for index > 0 {
+		previous := j.setpoints[(j.head+index-1)%JitterBufferSize]
+		if int32(setpoint.TimestampMs-previous.TimestampMs) >= 0 {
+			break
+		}
+		j.setpoints[(j.head+index)%JitterBufferSize] = previous
+		index--
+	}
+	j.setpoints[(j.head+index)%JitterBufferSize] = setpoint
+	j.count++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Update applies the setpoints whose playback time has come, it must be called periodically from the main loop. When
+// several setpoints are due at once only the newest one is applied
+//
+// Parameters:
+//
+// nowMs: The current local time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (j *JitterBuffer) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	// Find the newest due setpoint
+	found := false
+	var due Setpoint
+	for j.count > 0 {
+		setpoint := j.setpoints[j.head]
+		if int32(nowMs-j.playbackTimeMs(setpoint.TimestampMs)) < 0 {
+			break
+		}
+		due = setpoint
+		found = true
+		j.head = (j.head + 1) % JitterBufferSize
+		j.count--
+	}
+	if !found {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Apply it
+	j.lastAppliedMs = due.TimestampMs
+	j.hasLastApplied = true
+	return j.servo.SetAngle(due.Angle)
+}
+
+// Reset discards the buffered setpoints and the tracked clock offset, e.g. when the sender reconnects
+func (j *JitterBuffer) Reset() {
+	j.head = 0
+	j.count = 0
+	j.hasOffset = false
+	j.hasLastApplied = false
+}
+
+// Buffered returns the number of setpoints waiting for their playback time
+//
+// Returns:
+//
+// The number of buffered setpoints
+func (j *JitterBuffer) Buffered() int {
+	return j.count
+}
+
+// LateSetpoints returns the number of setpoints discarded because they were older than the last applied one
+//
+// Returns:
+//
+// The number of late setpoints
+func (j *JitterBuffer) LateSetpoints() uint32 {
+	return j.lateSetpoints
+}
+
+// OverflowedSetpoints returns the number of setpoints dropped because the buffer was full
+//
+// Returns:
+//
+// The number of overflowed setpoints
+func (j *JitterBuffer) OverflowedSetpoints() uint32 {
+	return j.overflowedSetpoints
+}
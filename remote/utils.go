@@ -0,0 +1,78 @@
+package remote
+
+// crc8Update adds a byte to a CRC-8 checksum, using the 0x07 polynomial
+//
+// Parameters:
+//
+// checksum: The checksum of the previous bytes
+// data: The byte to add
+//
+// Returns:
+//
+// The updated checksum
+func crc8Update(checksum byte, data byte) byte {
+	checksum ^= data
+	for bit := 0; bit < 8; bit++ {
+		if checksum&0x80 != 0 {
+			checksum = checksum<<1 ^ 0x07
+		} else {
+			checksum <<= 1
+		}
+	}
+	return checksum
+}
+
+// crc8 calculates the CRC-8 checksum of a byte slice, using the 0x07 polynomial
+//
+// Parameters:
+//
+// data: The bytes to checksum
+//
+// Returns:
+//
+// The checksum
+func crc8(data []byte) byte {
+	var checksum byte
+	for _, value := range data {
+		checksum = crc8Update(checksum, value)
+	}
+	return checksum
+}
+
+// decodeUint16 decodes a little-endian uint16
+//
+// Parameters:
+//
+// data: The encoded bytes, at least two
+//
+// Returns:
+//
+// The decoded value
+func decodeUint16(data []byte) uint16 {
+	return uint16(data[0]) | uint16(data[1])<<8
+}
+
+// encodeUint16 encodes a little-endian uint16
+//
+// Parameters:
+//
+// value: The value to encode
+// data: The encoded bytes, at least two
+func encodeUint16(value uint16, data []byte) {
+	data[0] = byte(value)
+	data[1] = byte(value >> 8)
+}
+
+// isSequenceNewer checks if a sequence number is newer than another one, handling the wrap around
+//
+// Parameters:
+//
+// sequence: The sequence number to check
+// last: The last accepted sequence number
+//
+// Returns:
+//
+// True if the sequence number is ahead of the last one by less than half the sequence space, false otherwise
+func isSequenceNewer(sequence uint16, last uint16) bool {
+	return int16(sequence-last) > 0
+}
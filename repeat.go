@@ -0,0 +1,40 @@
+package tinygo_servo
+
+// ForceRefresh writes the current pulse width to the PWM again, e.g. after the servo was physically disturbed or power
+// cycled, since commanding the current angle again doesn't rewrite it. While the pulses can't be written it does
+// nothing, the current pulse width is written once they can
+func (h *DefaultHandler) ForceRefresh() {
+	if !h.isSleeping && h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+	h.repeatedCommands = 0
+	h.rewriteTimestampMs = h.lastUpdateMs
+}
+
+// SetRepeatedCommandRewrite makes the commands repeating the current angle rewrite its pulse width every number of
+// repeated commands or every period, whichever comes first, instead of always being skipped. It helps recovering servos
+// whose controller lost the position, while keeping the PWM writes of the usual repeated commands low
+//
+// Parameters:
+//
+// everyCommands: The number of repeated commands between rewrites, or zero to not rewrite by count
+// everyMs: The time between rewrites in milliseconds, measured with the time of the last Update, or zero to not
+// rewrite by time
+func (h *DefaultHandler) SetRepeatedCommandRewrite(everyCommands uint32, everyMs uint32) {
+	h.rewriteEveryCommands = everyCommands
+	h.rewriteEveryMs = everyMs
+	h.repeatedCommands = 0
+	h.rewriteTimestampMs = h.lastUpdateMs
+}
+
+// handleRepeatedCommand counts a command repeating the current angle, rewriting its pulse width when it is due
+func (h *DefaultHandler) handleRepeatedCommand() {
+	if h.rewriteEveryCommands == 0 && h.rewriteEveryMs == 0 {
+		return
+	}
+	h.repeatedCommands++
+	if (h.rewriteEveryCommands != 0 && h.repeatedCommands >= h.rewriteEveryCommands) ||
+		(h.rewriteEveryMs != 0 && h.lastUpdateMs-h.rewriteTimestampMs >= h.rewriteEveryMs) {
+		h.ForceRefresh()
+	}
+}
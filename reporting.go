@@ -0,0 +1,178 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+)
+
+type (
+	// ErrorGroupEntry is the number of times a component raised an error code
+	ErrorGroupEntry struct {
+		ComponentID uint16
+		ErrorCode   tinygoerrors.ErrorCode
+		Count       uint32
+	}
+
+	// ErrorGroup is an ErrorReporter that aggregates the errors of several handlers by component and error code
+	ErrorGroup struct {
+		entries      [MaxErrorGroupEntries]ErrorGroupEntry
+		entriesCount int
+		droppedCount uint32
+	}
+)
+
+var (
+	// errorGroupEntryPrefix is the prefix for the log message of every entry of an error group
+	errorGroupEntryPrefix = []byte("Servo component:")
+
+	// errorGroupErrorCodePrefix is the prefix for the error code of an error group entry
+	errorGroupErrorCodePrefix = []byte("error code:")
+
+	// errorGroupCountPrefix is the prefix for the count of an error group entry
+	errorGroupCountPrefix = []byte("count:")
+
+	// errorGroupDroppedPrefix is the prefix for the log message of the errors dropped by a full error group
+	errorGroupDroppedPrefix = []byte("Servo errors dropped:")
+)
+
+// SetErrorReporter sets the reporter that receives the errors raised by the handler while initializing and setting
+// angles, attributed to a component ID
+//
+// Parameters:
+//
+// reporter: The reporter, or nil to stop reporting
+// componentID: The ID identifying the handler in the reports, e.g. the joint index
+func (h *DefaultHandler) SetErrorReporter(reporter ErrorReporter, componentID uint16) {
+	h.errorReporter = reporter
+	h.componentID = componentID
+}
+
+// ComponentID returns the ID identifying the handler in the error reports
+//
+// Returns:
+//
+// The component ID
+func (h *DefaultHandler) ComponentID() uint16 {
+	return h.componentID
+}
+
+// reportError sends an error to the error reporter, if any
+//
+// Parameters:
+//
+// errCode: The error to report
+//
+// Returns:
+//
+// The same error, so it can be returned in place
+func (h *DefaultHandler) reportError(errCode tinygoerrors.ErrorCode) tinygoerrors.ErrorCode {
+	if h.errorReporter != nil && errCode != tinygoerrors.ErrorCodeNil {
+		h.errorReporter.ReportError(h.componentID, errCode)
+	}
+	return errCode
+}
+
+// NewErrorGroup creates a new instance of ErrorGroup
+//
+// Returns:
+//
+// An empty instance of ErrorGroup
+func NewErrorGroup() *ErrorGroup {
+	return &ErrorGroup{}
+}
+
+// ReportError counts an error raised by a component
+//
+// Parameters:
+//
+// componentID: The ID of the component that raised the error
+// errCode: The error code
+func (g *ErrorGroup) ReportError(componentID uint16, errCode tinygoerrors.ErrorCode) {
+	// Count the error in its entry
+	for index := 0; index < g.entriesCount; index++ {
+		entry := &g.entries[index]
+		if entry.ComponentID == componentID && entry.ErrorCode == errCode {
+			entry.Count++
+			return
+		}
+	}
+
+	// Add a new entry, or drop the error if the group is full
+	if g.entriesCount == MaxErrorGroupEntries {
+		g.droppedCount++
+		return
+	}
+	g.entries[g.entriesCount] = ErrorGroupEntry{
+		ComponentID: componentID,
+		ErrorCode:   errCode,
+		Count:       1,
+	}
+	g.entriesCount++
+}
+
+// Entries returns the counted errors
+//
+// Returns:
+//
+// The entries of the group, in the order they were first reported. The slice is overwritten by the next reports
+func (g *ErrorGroup) Entries() []ErrorGroupEntry {
+	return g.entries[:g.entriesCount]
+}
+
+// Count returns the number of times a component raised any error
+//
+// Parameters:
+//
+// componentID: The ID of the component
+//
+// Returns:
+//
+// The number of errors of the component
+func (g *ErrorGroup) Count(componentID uint16) uint32 {
+	var count uint32
+	for index := 0; index < g.entriesCount; index++ {
+		if g.entries[index].ComponentID == componentID {
+			count += g.entries[index].Count
+		}
+	}
+	return count
+}
+
+// Dropped returns the number of errors not counted because the group was full
+//
+// Returns:
+//
+// The number of dropped errors
+func (g *ErrorGroup) Dropped() uint32 {
+	return g.droppedCount
+}
+
+// Clear removes the counted errors
+func (g *ErrorGroup) Clear() {
+	g.entriesCount = 0
+	g.droppedCount = 0
+}
+
+// Log writes an error message for every entry of the group
+//
+// Parameters:
+//
+// logger: The logger the entries are written to
+func (g *ErrorGroup) Log(logger tinygologger.Logger) {
+	if logger == nil {
+		return
+	}
+	for index := 0; index < g.entriesCount; index++ {
+		entry := &g.entries[index]
+		logger.AddMessageWithUint16(errorGroupEntryPrefix, entry.ComponentID, true, false, false)
+		logger.AddSpace()
+		logger.AddMessageWithErrorCode(errorGroupErrorCodePrefix, entry.ErrorCode, true, false)
+		logger.AddSpace()
+		logger.AddMessageWithUint32(errorGroupCountPrefix, entry.Count, true, true, false)
+		logger.Error()
+	}
+	if g.droppedCount > 0 {
+		logger.AddMessageWithUint32(errorGroupDroppedPrefix, g.droppedCount, true, true, false)
+		logger.Error()
+	}
+}
@@ -0,0 +1,23 @@
+package tinygo_servo
+
+// SetResponseModel stores the identified response of the servo motor, e.g. measured with the routine package
+// StepResponse, so the speed-limited moves never command faster than the servo can track and the trackers can
+// command ahead by its lag
+//
+// Parameters:
+//
+// lagMs: The time in milliseconds the servo motor takes to start moving after a command
+// maxSpeed: The speed the servo motor moves at in degrees per second, or zero if unknown
+func (h *DefaultHandler) SetResponseModel(lagMs uint32, maxSpeed uint16) {
+	h.responseLagMs = lagMs
+	h.responseMaxSpeed = maxSpeed
+}
+
+// GetResponseModel returns the stored response of the servo motor
+//
+// Returns:
+//
+// The lag in milliseconds and the speed in degrees per second, zero if unknown
+func (h *DefaultHandler) GetResponseModel() (uint32, uint16) {
+	return h.responseLagMs, h.responseMaxSpeed
+}
@@ -0,0 +1,189 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Bisector finds the angle where a detection callback toggles, e.g. where an IR beam becomes aligned, by
+	// bisecting a range of the servo. Every probe moves the servo, waits for it to settle and samples the callback
+	Bisector struct {
+		servo                  Servo
+		detectFunc             func() bool
+		settleMs               uint32
+		resolutionMilliDegrees uint32
+		state                  bisectorState
+		lowMilliDegrees        uint32
+		highMilliDegrees       uint32
+		probeMilliDegrees      uint32
+		lowDetection           bool
+		hasSettleStart         bool
+		settleStartMs          uint32
+		result                 uint32
+		resultErr              tinygoerrors.ErrorCode
+	}
+)
+
+// NewBisector creates a new instance of Bisector
+//
+// Parameters:
+//
+// servo: The servo moved to probe the callback
+// detectFunc: The detection callback
+// settleMs: The time in milliseconds to wait after every move before sampling the callback
+// resolutionMilliDegrees: The width of the range the search stops at, in millidegrees
+//
+// Returns:
+//
+// An instance of Bisector and an error if the servo or the callback is nil
+func NewBisector(
+	servo Servo,
+	detectFunc func() bool,
+	settleMs uint32,
+	resolutionMilliDegrees uint32,
+) (*Bisector, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeRoutineNilServo
+	}
+	if detectFunc == nil {
+		return nil, ErrorCodeRoutineNilDetectFunc
+	}
+	if resolutionMilliDegrees == 0 {
+		resolutionMilliDegrees = 1
+	}
+	return &Bisector{
+		servo:                  servo,
+		detectFunc:             detectFunc,
+		settleMs:               settleMs,
+		resolutionMilliDegrees: resolutionMilliDegrees,
+		resultErr:              ErrorCodeRoutineNotFinished,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// Start starts the search between two angles, the callback must report a different value at each of them
+//
+// Parameters:
+//
+// fromMilliDegrees: One end of the range in millidegrees
+// toMilliDegrees: The other end of the range in millidegrees
+//
+// Returns:
+//
+// An error if the range is empty or the servo can't be moved to its first end
+func (b *Bisector) Start(fromMilliDegrees uint32, toMilliDegrees uint32) tinygoerrors.ErrorCode {
+	if fromMilliDegrees == toMilliDegrees {
+		return ErrorCodeRoutineInvalidRange
+	}
+	b.lowMilliDegrees = fromMilliDegrees
+	b.highMilliDegrees = toMilliDegrees
+	b.resultErr = ErrorCodeRoutineNotFinished
+	b.state = bisectorStateSamplingFrom
+	return b.probe(fromMilliDegrees)
+}
+
+// Stop stops the search, the result is not available
+func (b *Bisector) Stop() {
+	b.state = bisectorStateIdle
+}
+
+// IsRunning returns whether the search is running
+//
+// Returns:
+//
+// True if the search is running, false otherwise
+func (b *Bisector) IsRunning() bool {
+	return b.state != bisectorStateIdle && b.state != bisectorStateFinished
+}
+
+// Result returns the angle found by the last search
+//
+// Returns:
+//
+// The angle in millidegrees on the side of the toggle where the callback reports the same value as at the end the
+// search was started to, and an error if the search hasn't finished or the callback didn't toggle within the range
+func (b *Bisector) Result() (uint32, tinygoerrors.ErrorCode) {
+	return b.result, b.resultErr
+}
+
+// probe moves the servo to an angle to sample the callback once it has settled
+//
+// Parameters:
+//
+// milliDegrees: The angle to probe
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (b *Bisector) probe(milliDegrees uint32) tinygoerrors.ErrorCode {
+	b.probeMilliDegrees = milliDegrees
+	b.hasSettleStart = false
+	if err := b.servo.SetAngleMilliDegrees(milliDegrees); err != tinygoerrors.ErrorCodeNil {
+		b.state = bisectorStateIdle
+		return err
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// finish ends the search
+//
+// Parameters:
+//
+// result: The angle found
+// err: The error of the search, if any
+func (b *Bisector) finish(result uint32, err tinygoerrors.ErrorCode) {
+	b.result = result
+	b.resultErr = err
+	b.state = bisectorStateFinished
+}
+
+// Update runs the search, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any. The search is stopped if a move fails
+func (b *Bisector) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if !b.IsRunning() {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Wait for the servo to settle
+	if !b.hasSettleStart {
+		b.hasSettleStart = true
+		b.settleStartMs = nowMs
+	}
+	if nowMs-b.settleStartMs < b.settleMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+	detection := b.detectFunc()
+
+	switch b.state {
+	case bisectorStateSamplingFrom:
+		b.lowDetection = detection
+		b.state = bisectorStateSamplingTo
+		return b.probe(b.highMilliDegrees)
+	case bisectorStateSamplingTo:
+		if detection == b.lowDetection {
+			b.finish(0, ErrorCodeRoutineNoTransition)
+			return tinygoerrors.ErrorCodeNil
+		}
+		b.state = bisectorStateBisecting
+	default:
+		// Keep the half of the range where the callback toggles
+		if detection == b.lowDetection {
+			b.lowMilliDegrees = b.probeMilliDegrees
+		} else {
+			b.highMilliDegrees = b.probeMilliDegrees
+		}
+	}
+
+	// Finish once the range is narrow enough, otherwise probe its middle
+	if angleDifferenceMilliDegrees(b.lowMilliDegrees, b.highMilliDegrees) <= b.resolutionMilliDegrees {
+		b.finish(b.highMilliDegrees, tinygoerrors.ErrorCodeNil)
+		return tinygoerrors.ErrorCodeNil
+	}
+	return b.probe(midpointMilliDegrees(b.lowMilliDegrees, b.highMilliDegrees))
+}
@@ -0,0 +1,69 @@
+package routine
+
+type (
+	// SoakPattern is an enum to represent the moves commanded by a SoakTest.
+	SoakPattern uint8
+
+	// bisectorState is an enum to represent the stage of a Bisector.
+	bisectorState uint8
+
+	// stepResponseState is an enum to represent the stage of a StepResponse.
+	stepResponseState uint8
+
+	// homingState is an enum to represent the stage of a StallHoming.
+	homingState uint8
+
+	// EnvelopeFlag is a bit mask to represent the mechanical problems found in a region by an EnvelopeRecorder.
+	EnvelopeFlag uint8
+
+	// envelopeState is an enum to represent the stage of an EnvelopeRecorder.
+	envelopeState uint8
+)
+
+const (
+	// SoakPatternAlternate alternates between both ends of the range
+	SoakPatternAlternate SoakPattern = iota
+
+	// SoakPatternRandom moves to pseudo-random angles within the range
+	SoakPatternRandom
+)
+
+const (
+	bisectorStateIdle bisectorState = iota
+	bisectorStateSamplingFrom
+	bisectorStateSamplingTo
+	bisectorStateBisecting
+	bisectorStateFinished
+)
+
+const (
+	stepResponseStateIdle stepResponseState = iota
+	stepResponseStatePositioning
+	stepResponseStateStepping
+	stepResponseStateFinished
+)
+
+const (
+	homingStateIdle homingState = iota
+	homingStateAdvancing
+	homingStateFinished
+)
+
+const (
+	// EnvelopeFlagHighLoad marks the regions where the load is well above the mean load of the sweep
+	EnvelopeFlagHighLoad EnvelopeFlag = 1 << iota
+
+	// EnvelopeFlagAsymmetric marks the regions where the load differs between both directions of travel
+	EnvelopeFlagAsymmetric
+
+	// EnvelopeFlagBinding marks the regions where the measured angle lags the commanded one
+	EnvelopeFlagBinding
+)
+
+const (
+	envelopeStateIdle envelopeState = iota
+	envelopeStatePositioning
+	envelopeStateIncreasing
+	envelopeStateDecreasing
+	envelopeStateFinished
+)
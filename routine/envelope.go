@@ -0,0 +1,404 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+)
+
+const (
+	// EnvelopeBins is the number of regions the range of an EnvelopeRecorder is split into
+	EnvelopeBins = 16
+
+	// DefaultEnvelopeHighLoadPercent is the load of a region, as a percentage of the mean load, above which it is
+	// flagged as a high load region
+	DefaultEnvelopeHighLoadPercent = 150
+
+	// DefaultEnvelopeAsymmetryPercent is the load difference between both directions, as a percentage of the mean
+	// load, above which a region is flagged as asymmetric
+	DefaultEnvelopeAsymmetryPercent = 30
+
+	// DefaultEnvelopeBindingMilliDegrees is the lag of the measured angle behind the commanded one above which a
+	// region is flagged as binding
+	DefaultEnvelopeBindingMilliDegrees = 3000
+)
+
+type (
+	// EnvelopeBin is a region of the range swept by an EnvelopeRecorder
+	EnvelopeBin struct {
+		FromMilliDegrees   uint32
+		ToMilliDegrees     uint32
+		IncreasingLoad     uint32
+		DecreasingLoad     uint32
+		PeakLoad           uint32
+		MaxLagMilliDegrees uint32
+		Flags              EnvelopeFlag
+	}
+
+	// EnvelopeReport is the movement envelope of a mechanism: the load and lag measured over every region of its
+	// range in both directions of travel, and the regions flagged with mechanical problems
+	EnvelopeReport struct {
+		Bins               [EnvelopeBins]EnvelopeBin
+		MeanLoad           uint32
+		PeakLoad           uint32
+		MaxLagMilliDegrees uint32
+		Flags              EnvelopeFlag
+	}
+
+	// EnvelopeRecorder is a commissioning routine that sweeps a mechanism slowly over its whole allowed range, in
+	// both directions, while sampling the load of the servo, e.g. its current, and its measured angle. The samples
+	// are aggregated per region into an envelope report flagging the regions of high load, the regions where the load
+	// differs between both directions and the regions where the servo lags the command, so the mechanical problems
+	// are found before the assembly is closed up
+	EnvelopeRecorder struct {
+		servo               Servo
+		minMilliDegrees     uint32
+		maxMilliDegrees     uint32
+		stepMilliDegrees    uint32
+		stepIntervalMs      uint32
+		loadFunc            func() (uint32, bool)
+		feedbackFunc        func() (uint32, bool)
+		highLoadPercent     uint32
+		asymmetryPercent    uint32
+		bindingMilliDegrees uint32
+		state               envelopeState
+		hasStepTimestamp    bool
+		stepTimestampMs     uint32
+		loadSums            [2][EnvelopeBins]uint32
+		loadSamples         [2][EnvelopeBins]uint32
+		report              EnvelopeReport
+		resultErr           tinygoerrors.ErrorCode
+	}
+)
+
+var (
+	envelopeReportPrefix    = []byte("Envelope report:")
+	envelopeMeanLoadPrefix  = []byte("Mean load:")
+	envelopePeakLoadPrefix  = []byte("Peak load:")
+	envelopeMaxLagPrefix    = []byte("Max lag (mdeg):")
+	envelopeHighLoadPrefix  = []byte("High load from (mdeg):")
+	envelopeAsymmetryPrefix = []byte("Asymmetric load from (mdeg):")
+	envelopeBindingPrefix   = []byte("Binding from (mdeg):")
+)
+
+// NewEnvelopeRecorder creates a new instance of EnvelopeRecorder
+//
+// Parameters:
+//
+// servo: The servo driving the mechanism
+// minAngle: The lowest angle of the sweep, usually the left limit of the servo
+// maxAngle: The highest angle of the sweep, usually the right limit of the servo
+// stepMilliDegrees: The angle the servo advances every step in millidegrees
+// stepIntervalMs: The time between two steps in milliseconds, the samples are taken at its end, once the servo settled
+//
+// Returns:
+//
+// An instance of EnvelopeRecorder and an error if any parameter is invalid
+func NewEnvelopeRecorder(
+	servo Servo,
+	minAngle uint16,
+	maxAngle uint16,
+	stepMilliDegrees uint32,
+	stepIntervalMs uint32,
+) (*EnvelopeRecorder, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeRoutineNilServo
+	}
+	if minAngle >= maxAngle {
+		return nil, ErrorCodeRoutineInvalidRange
+	}
+	if stepMilliDegrees == 0 {
+		return nil, ErrorCodeRoutineZeroSteps
+	}
+	if stepIntervalMs == 0 {
+		return nil, ErrorCodeRoutineZeroInterval
+	}
+	return &EnvelopeRecorder{
+		servo:               servo,
+		minMilliDegrees:     uint32(minAngle) * 1000,
+		maxMilliDegrees:     uint32(maxAngle) * 1000,
+		stepMilliDegrees:    stepMilliDegrees,
+		stepIntervalMs:      stepIntervalMs,
+		highLoadPercent:     DefaultEnvelopeHighLoadPercent,
+		asymmetryPercent:    DefaultEnvelopeAsymmetryPercent,
+		bindingMilliDegrees: DefaultEnvelopeBindingMilliDegrees,
+		resultErr:           ErrorCodeRoutineNotFinished,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetLoadHook sets the function that reads the load of the servo, sampled at every step
+//
+// Parameters:
+//
+// loadFunc: The function that returns the load, in any unit like the current in milliamperes, and whether the reading
+// is valid, nil disables the load regions
+func (e *EnvelopeRecorder) SetLoadHook(loadFunc func() (uint32, bool)) {
+	e.loadFunc = loadFunc
+}
+
+// SetFeedbackHook sets the function that reads the actual angle of the servo, sampled at every step
+//
+// Parameters:
+//
+// feedbackFunc: The function that returns the measured angle in millidegrees and whether the reading is valid, nil
+// disables the binding regions
+func (e *EnvelopeRecorder) SetFeedbackHook(feedbackFunc func() (uint32, bool)) {
+	e.feedbackFunc = feedbackFunc
+}
+
+// SetThresholds sets the thresholds the regions are flagged with
+//
+// Parameters:
+//
+// highLoadPercent: The load of a region, as a percentage of the mean load, above which it has a high load
+// asymmetryPercent: The load difference between both directions, as a percentage of the mean load, above which a
+// region is asymmetric
+// bindingMilliDegrees: The lag of the measured angle behind the commanded one above which a region is binding
+func (e *EnvelopeRecorder) SetThresholds(highLoadPercent uint32, asymmetryPercent uint32, bindingMilliDegrees uint32) {
+	e.highLoadPercent = highLoadPercent
+	e.asymmetryPercent = asymmetryPercent
+	e.bindingMilliDegrees = bindingMilliDegrees
+}
+
+// Start clears the samples and starts stepping the servo from its current angle to the lowest angle, where the sweep
+// starts
+//
+// Returns:
+//
+// An error if neither the load nor the feedback hook is set
+func (e *EnvelopeRecorder) Start() tinygoerrors.ErrorCode {
+	if e.loadFunc == nil && e.feedbackFunc == nil {
+		return ErrorCodeRoutineNilEnvelopeHooks
+	}
+	e.loadSums = [2][EnvelopeBins]uint32{}
+	e.loadSamples = [2][EnvelopeBins]uint32{}
+	e.report = EnvelopeReport{}
+	e.hasStepTimestamp = false
+	e.resultErr = ErrorCodeRoutineNotFinished
+	e.state = envelopeStatePositioning
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Stop stops the sweep, the report is not available
+func (e *EnvelopeRecorder) Stop() {
+	e.state = envelopeStateIdle
+}
+
+// IsRunning returns whether the sweep is running
+//
+// Returns:
+//
+// True if the sweep is running, false otherwise
+func (e *EnvelopeRecorder) IsRunning() bool {
+	return e.state == envelopeStatePositioning ||
+		e.state == envelopeStateIncreasing ||
+		e.state == envelopeStateDecreasing
+}
+
+// Result returns the envelope report of the last sweep
+//
+// Returns:
+//
+// The report and an error if the sweep hasn't finished
+func (e *EnvelopeRecorder) Result() (*EnvelopeReport, tinygoerrors.ErrorCode) {
+	return &e.report, e.resultErr
+}
+
+// binIndex returns the region an angle belongs to
+//
+// Parameters:
+//
+// milliDegrees: The angle in millidegrees, within the range of the sweep
+//
+// Returns:
+//
+// The index of the region
+func (e *EnvelopeRecorder) binIndex(milliDegrees uint32) int {
+	span := uint64(e.maxMilliDegrees - e.minMilliDegrees)
+	index := int(uint64(milliDegrees-e.minMilliDegrees) * EnvelopeBins / span)
+	return min(index, EnvelopeBins-1)
+}
+
+// sample reads the hooks at the commanded angle and adds the readings to its region
+//
+// Parameters:
+//
+// commanded: The commanded angle in millidegrees
+// direction: The direction of travel, 0 increasing and 1 decreasing
+func (e *EnvelopeRecorder) sample(commanded uint32, direction int) {
+	bin := &e.report.Bins[e.binIndex(commanded)]
+	if e.loadFunc != nil {
+		if load, ok := e.loadFunc(); ok {
+			index := e.binIndex(commanded)
+			e.loadSums[direction][index] += load
+			e.loadSamples[direction][index]++
+			bin.PeakLoad = max(bin.PeakLoad, load)
+		}
+	}
+	if e.feedbackFunc != nil {
+		if measured, ok := e.feedbackFunc(); ok {
+			bin.MaxLagMilliDegrees = max(bin.MaxLagMilliDegrees, angleDifferenceMilliDegrees(measured, commanded))
+		}
+	}
+}
+
+// step commands the next step towards an angle
+//
+// Parameters:
+//
+// current: The commanded angle in millidegrees
+// target: The angle to move towards in millidegrees
+//
+// Returns:
+//
+// The error returned by the servo, if any. The sweep is stopped if a move fails
+func (e *EnvelopeRecorder) step(current uint32, target uint32) tinygoerrors.ErrorCode {
+	next := target
+	if angleDifferenceMilliDegrees(current, target) > e.stepMilliDegrees {
+		if target > current {
+			next = current + e.stepMilliDegrees
+		} else {
+			next = current - e.stepMilliDegrees
+		}
+	}
+	if err := e.servo.SetAngleMilliDegrees(next); err != tinygoerrors.ErrorCodeNil {
+		e.state = envelopeStateIdle
+		return err
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Update runs the sweep, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any. The sweep is stopped if a move fails
+func (e *EnvelopeRecorder) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if !e.IsRunning() {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Take a step once the interval elapsed
+	if e.hasStepTimestamp && nowMs-e.stepTimestampMs < e.stepIntervalMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+	e.hasStepTimestamp = true
+	e.stepTimestampMs = nowMs
+
+	current := e.servo.GetAngleMilliDegrees()
+	switch e.state {
+	case envelopeStatePositioning:
+		if current != e.minMilliDegrees {
+			return e.step(current, e.minMilliDegrees)
+		}
+		e.state = envelopeStateIncreasing
+		fallthrough
+	case envelopeStateIncreasing:
+		e.sample(current, 0)
+		if current != e.maxMilliDegrees {
+			return e.step(current, e.maxMilliDegrees)
+		}
+		e.state = envelopeStateDecreasing
+	case envelopeStateDecreasing:
+		e.sample(current, 1)
+		if current != e.minMilliDegrees {
+			return e.step(current, e.minMilliDegrees)
+		}
+		e.analyze()
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// analyze aggregates the samples into the report and flags the regions
+func (e *EnvelopeRecorder) analyze() {
+	// Calculate the mean load of the whole sweep
+	var loadSum, loadSamples uint64
+	for direction := range e.loadSums {
+		for index := range e.loadSums[direction] {
+			loadSum += uint64(e.loadSums[direction][index])
+			loadSamples += uint64(e.loadSamples[direction][index])
+		}
+	}
+	if loadSamples != 0 {
+		e.report.MeanLoad = uint32(loadSum / loadSamples)
+	}
+	meanLoad := uint64(e.report.MeanLoad)
+
+	// Aggregate every region and flag it against the thresholds
+	span := e.maxMilliDegrees - e.minMilliDegrees
+	for index := range e.report.Bins {
+		bin := &e.report.Bins[index]
+		bin.FromMilliDegrees = e.minMilliDegrees + uint32(uint64(span)*uint64(index)/EnvelopeBins)
+		bin.ToMilliDegrees = e.minMilliDegrees + uint32(uint64(span)*uint64(index+1)/EnvelopeBins)
+
+		increasingSamples := e.loadSamples[0][index]
+		decreasingSamples := e.loadSamples[1][index]
+		if increasingSamples != 0 {
+			bin.IncreasingLoad = e.loadSums[0][index] / increasingSamples
+		}
+		if decreasingSamples != 0 {
+			bin.DecreasingLoad = e.loadSums[1][index] / decreasingSamples
+		}
+		if samples := increasingSamples + decreasingSamples; samples != 0 && meanLoad != 0 {
+			load := uint64(e.loadSums[0][index]+e.loadSums[1][index]) / uint64(samples)
+			if load*100 > meanLoad*uint64(e.highLoadPercent) {
+				bin.Flags |= EnvelopeFlagHighLoad
+			}
+		}
+		if increasingSamples != 0 && decreasingSamples != 0 && meanLoad != 0 {
+			difference := angleDifferenceMilliDegrees(bin.IncreasingLoad, bin.DecreasingLoad)
+			if uint64(difference)*100 > meanLoad*uint64(e.asymmetryPercent) {
+				bin.Flags |= EnvelopeFlagAsymmetric
+			}
+		}
+		if e.feedbackFunc != nil && bin.MaxLagMilliDegrees > e.bindingMilliDegrees {
+			bin.Flags |= EnvelopeFlagBinding
+		}
+
+		e.report.PeakLoad = max(e.report.PeakLoad, bin.PeakLoad)
+		e.report.MaxLagMilliDegrees = max(e.report.MaxLagMilliDegrees, bin.MaxLagMilliDegrees)
+		e.report.Flags |= bin.Flags
+	}
+	e.resultErr = tinygoerrors.ErrorCodeNil
+	e.state = envelopeStateFinished
+}
+
+// Report logs the envelope report of the last sweep and the regions flagged, e.g. over the serial console
+//
+// Parameters:
+//
+// logger: The logger the report is written to
+//
+// Returns:
+//
+// An error if the logger is nil or the sweep hasn't finished
+func (e *EnvelopeRecorder) Report(logger tinygologger.Logger) tinygoerrors.ErrorCode {
+	if logger == nil {
+		return ErrorCodeRoutineNilLogger
+	}
+	if e.resultErr != tinygoerrors.ErrorCodeNil {
+		return e.resultErr
+	}
+
+	logger.AddMessage(envelopeReportPrefix, true)
+	logger.AddMessageWithUint32(envelopeMeanLoadPrefix, e.report.MeanLoad, true, true, false)
+	logger.AddMessageWithUint32(envelopePeakLoadPrefix, e.report.PeakLoad, true, true, false)
+	logger.AddMessageWithUint32(envelopeMaxLagPrefix, e.report.MaxLagMilliDegrees, true, true, false)
+	for index := range e.report.Bins {
+		bin := &e.report.Bins[index]
+		if bin.Flags&EnvelopeFlagHighLoad != 0 {
+			logger.AddMessageWithUint32(envelopeHighLoadPrefix, bin.FromMilliDegrees, true, true, false)
+		}
+		if bin.Flags&EnvelopeFlagAsymmetric != 0 {
+			logger.AddMessageWithUint32(envelopeAsymmetryPrefix, bin.FromMilliDegrees, true, true, false)
+		}
+		if bin.Flags&EnvelopeFlagBinding != 0 {
+			logger.AddMessageWithUint32(envelopeBindingPrefix, bin.FromMilliDegrees, true, true, false)
+		}
+	}
+	logger.Info()
+	return tinygoerrors.ErrorCodeNil
+}
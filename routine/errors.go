@@ -0,0 +1,27 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeRoutineStartNumber is the starting number for routine-related error codes.
+	ErrorCodeRoutineStartNumber uint16 = 5440
+)
+
+const (
+	ErrorCodeRoutineNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeRoutineStartNumber)
+	ErrorCodeRoutineInvalidRange
+	ErrorCodeRoutineZeroInterval
+	ErrorCodeRoutineUnknownSoakPattern
+	ErrorCodeRoutineNilLogger
+	ErrorCodeRoutineNilDetectFunc
+	ErrorCodeRoutineNoTransition
+	ErrorCodeRoutineNotFinished
+	ErrorCodeRoutineNilFeedbackFunc
+	ErrorCodeRoutineZeroSteps
+	ErrorCodeRoutineNoResponse
+	ErrorCodeRoutineNilStallFunc
+	ErrorCodeRoutineNoStall
+	ErrorCodeRoutineNilEnvelopeHooks
+)
@@ -0,0 +1,222 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// StallHoming finds the hard stop of a mechanism without a limit switch by advancing the servo slowly until it
+	// stalls against it, then backs off and reports the backed off angle as the soft limit. The stall is detected by a
+	// callback, e.g. the current drawn by the servo exceeding a threshold or its feedback angle lagging the command,
+	// and must persist for a while so a passing bump isn't taken as the hard stop
+	StallHoming struct {
+		servo                 Servo
+		stallFunc             func() bool
+		stepMilliDegrees      uint32
+		stepIntervalMs        uint32
+		stallMs               uint32
+		backOffMilliDegrees   uint32
+		doneFunc              func(softLimitMilliDegrees uint32)
+		state                 homingState
+		targetMilliDegrees    uint32
+		isIncreasing          bool
+		hasStepTimestamp      bool
+		stepTimestampMs       uint32
+		hasStallStart         bool
+		stallStartMs          uint32
+		hardStopMilliDegrees  uint32
+		softLimitMilliDegrees uint32
+		resultErr             tinygoerrors.ErrorCode
+	}
+)
+
+// NewStallHoming creates a new instance of StallHoming
+//
+// Parameters:
+//
+// servo: The servo driving the mechanism
+// stallFunc: The callback reporting whether the servo is stalled
+// stepMilliDegrees: The angle the servo advances every step in millidegrees, small enough not to force the hard stop
+// stepIntervalMs: The time between two steps in milliseconds
+// stallMs: The time in milliseconds the stall must persist to be taken as the hard stop
+// backOffMilliDegrees: The angle in millidegrees the servo backs off from the hard stop, where the soft limit is set
+//
+// Returns:
+//
+// An instance of StallHoming and an error if the servo or the callback is nil, or the step or its interval is zero
+func NewStallHoming(
+	servo Servo,
+	stallFunc func() bool,
+	stepMilliDegrees uint32,
+	stepIntervalMs uint32,
+	stallMs uint32,
+	backOffMilliDegrees uint32,
+) (*StallHoming, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeRoutineNilServo
+	}
+	if stallFunc == nil {
+		return nil, ErrorCodeRoutineNilStallFunc
+	}
+	if stepMilliDegrees == 0 {
+		return nil, ErrorCodeRoutineZeroSteps
+	}
+	if stepIntervalMs == 0 {
+		return nil, ErrorCodeRoutineZeroInterval
+	}
+	return &StallHoming{
+		servo:               servo,
+		stallFunc:           stallFunc,
+		stepMilliDegrees:    stepMilliDegrees,
+		stepIntervalMs:      stepIntervalMs,
+		stallMs:             stallMs,
+		backOffMilliDegrees: backOffMilliDegrees,
+		resultErr:           ErrorCodeRoutineNotFinished,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetDoneFunc sets a function called with the soft limit once the homing succeeds, e.g. to set the limits of the
+// servo
+//
+// Parameters:
+//
+// doneFunc: The function called with the soft limit in millidegrees, or nil
+func (s *StallHoming) SetDoneFunc(doneFunc func(softLimitMilliDegrees uint32)) {
+	s.doneFunc = doneFunc
+}
+
+// Start starts advancing the servo from its current angle towards an angle beyond which the hard stop can't be
+//
+// Parameters:
+//
+// targetMilliDegrees: The farthest angle the servo is advanced to in millidegrees, the homing fails if it is reached
+// without a stall
+//
+// Returns:
+//
+// An error if the servo is already at the target angle
+func (s *StallHoming) Start(targetMilliDegrees uint32) tinygoerrors.ErrorCode {
+	current := s.servo.GetAngleMilliDegrees()
+	if current == targetMilliDegrees {
+		return ErrorCodeRoutineInvalidRange
+	}
+	s.targetMilliDegrees = targetMilliDegrees
+	s.isIncreasing = targetMilliDegrees > current
+	s.hasStepTimestamp = false
+	s.hasStallStart = false
+	s.resultErr = ErrorCodeRoutineNotFinished
+	s.state = homingStateAdvancing
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Stop stops the homing, the result is not available
+func (s *StallHoming) Stop() {
+	s.state = homingStateIdle
+}
+
+// IsRunning returns whether the homing is running
+//
+// Returns:
+//
+// True if the homing is running, false otherwise
+func (s *StallHoming) IsRunning() bool {
+	return s.state == homingStateAdvancing
+}
+
+// Result returns the angles found by the last homing
+//
+// Returns:
+//
+// The hard stop and the soft limit in millidegrees, and an error if the homing hasn't finished or the servo didn't
+// stall before the target angle
+func (s *StallHoming) Result() (uint32, uint32, tinygoerrors.ErrorCode) {
+	return s.hardStopMilliDegrees, s.softLimitMilliDegrees, s.resultErr
+}
+
+// finish ends the homing
+//
+// Parameters:
+//
+// err: The error of the homing, if any
+func (s *StallHoming) finish(err tinygoerrors.ErrorCode) {
+	s.resultErr = err
+	s.state = homingStateFinished
+}
+
+// Update runs the homing, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any. The homing is stopped if a move fails
+func (s *StallHoming) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	if s.state != homingStateAdvancing {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Hold the angle while the servo is stalled, until the stall persists long enough
+	if s.stallFunc() {
+		if !s.hasStallStart {
+			s.hasStallStart = true
+			s.stallStartMs = nowMs
+		}
+		if nowMs-s.stallStartMs < s.stallMs {
+			return tinygoerrors.ErrorCodeNil
+		}
+		return s.backOff()
+	}
+	s.hasStallStart = false
+
+	// Advance one step once the interval elapsed
+	if s.hasStepTimestamp && nowMs-s.stepTimestampMs < s.stepIntervalMs {
+		return tinygoerrors.ErrorCodeNil
+	}
+	s.hasStepTimestamp = true
+	s.stepTimestampMs = nowMs
+
+	current := s.servo.GetAngleMilliDegrees()
+	if current == s.targetMilliDegrees {
+		s.finish(ErrorCodeRoutineNoStall)
+		return tinygoerrors.ErrorCodeNil
+	}
+	next := s.targetMilliDegrees
+	if angleDifferenceMilliDegrees(current, s.targetMilliDegrees) > s.stepMilliDegrees {
+		if s.isIncreasing {
+			next = current + s.stepMilliDegrees
+		} else {
+			next = current - s.stepMilliDegrees
+		}
+	}
+	if err := s.servo.SetAngleMilliDegrees(next); err != tinygoerrors.ErrorCodeNil {
+		s.state = homingStateIdle
+		return err
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// backOff takes the current angle as the hard stop and backs off from it to the soft limit
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (s *StallHoming) backOff() tinygoerrors.ErrorCode {
+	s.hardStopMilliDegrees = s.servo.GetAngleMilliDegrees()
+	softLimit := s.hardStopMilliDegrees + s.backOffMilliDegrees
+	if s.isIncreasing {
+		softLimit = s.hardStopMilliDegrees - min(s.backOffMilliDegrees, s.hardStopMilliDegrees)
+	}
+	if err := s.servo.SetAngleMilliDegrees(softLimit); err != tinygoerrors.ErrorCodeNil {
+		s.state = homingStateIdle
+		return err
+	}
+	s.softLimitMilliDegrees = softLimit
+	s.finish(tinygoerrors.ErrorCodeNil)
+
+	if s.doneFunc != nil {
+		s.doneFunc(softLimit)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
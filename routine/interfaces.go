@@ -0,0 +1,16 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo Handler interface used by the routines, which only need to command
+	// angles and read them back, so fakes can replace the handlers in the tests
+	Servo interface {
+		SetAngle(angle uint16) tinygoerrors.ErrorCode
+		GetAngle() uint16
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		GetAngleMilliDegrees() uint32
+	}
+)
@@ -0,0 +1,308 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygologger "github.com/ralvarezdev/tinygo-logger"
+)
+
+type (
+	// SoakStats is a snapshot of the statistics collected by a SoakTest
+	SoakStats struct {
+		ElapsedMs        uint32
+		Moves            uint32
+		MissedUpdates    uint32
+		StallEvents      uint32
+		FailedMoves      uint32
+		HasTemperature   bool
+		FirstTemperature int16
+		LastTemperature  int16
+		MinTemperature   int16
+		MaxTemperature   int16
+	}
+
+	// SoakTest is an endurance routine that keeps a servo moving for a given duration while collecting statistics, to
+	// qualify servos and mounts before deployment
+	SoakTest struct {
+		servo             Servo
+		minAngle          uint16
+		maxAngle          uint16
+		intervalMs        uint32
+		durationMs        uint32
+		pattern           SoakPattern
+		seed              uint32
+		random            uint32
+		feedbackFunc      func() (uint16, bool)
+		stallToleranceDeg uint16
+		temperatureFunc   func() (int16, bool)
+		isRunning         bool
+		hasStarted        bool
+		startMs           uint32
+		lastMoveMs        uint32
+		commandedAngle    uint16
+		hasCommanded      bool
+		stats             SoakStats
+	}
+)
+
+var (
+	soakReportPrefix           = []byte("Soak test report:")
+	soakElapsedMsPrefix        = []byte("Elapsed (ms):")
+	soakMovesPrefix            = []byte("Moves:")
+	soakMissedUpdatesPrefix    = []byte("Missed updates:")
+	soakStallEventsPrefix      = []byte("Stall events:")
+	soakFailedMovesPrefix      = []byte("Failed moves:")
+	soakTemperatureDeltaPrefix = []byte("Temperature rise:")
+	soakTemperatureDropPrefix  = []byte("Temperature drop:")
+)
+
+// NewSoakTest creates a new instance of SoakTest
+//
+// Parameters:
+//
+// servo: The servo under test
+// minAngle: The lowest angle commanded
+// maxAngle: The highest angle commanded
+// intervalMs: The time between two moves in milliseconds, it must let the servo reach the commanded angle
+// durationMs: The duration of the test in milliseconds
+// pattern: The moves commanded
+// seed: The seed of the pseudo-random moves, the same seed repeats the same sequence of moves
+//
+// Returns:
+//
+// An instance of SoakTest and an error if any parameter is invalid
+func NewSoakTest(
+	servo Servo,
+	minAngle uint16,
+	maxAngle uint16,
+	intervalMs uint32,
+	durationMs uint32,
+	pattern SoakPattern,
+	seed uint32,
+) (*SoakTest, tinygoerrors.ErrorCode) {
+	// Check the parameters
+	if servo == nil {
+		return nil, ErrorCodeRoutineNilServo
+	}
+	if minAngle >= maxAngle {
+		return nil, ErrorCodeRoutineInvalidRange
+	}
+	if intervalMs == 0 {
+		return nil, ErrorCodeRoutineZeroInterval
+	}
+	if pattern != SoakPatternAlternate && pattern != SoakPatternRandom {
+		return nil, ErrorCodeRoutineUnknownSoakPattern
+	}
+
+	// The xorshift generator gets stuck on a zero state
+	if seed == 0 {
+		seed = 1
+	}
+
+	return &SoakTest{
+		servo:      servo,
+		minAngle:   minAngle,
+		maxAngle:   maxAngle,
+		intervalMs: intervalMs,
+		durationMs: durationMs,
+		pattern:    pattern,
+		seed:       seed,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetFeedbackHook sets the function that reads the actual angle of the servo. When set, a move is counted as a stall
+// event if the servo is still farther than the tolerance from the commanded angle when the next move is due
+//
+// Parameters:
+//
+// feedbackFunc: The function that returns the measured angle and whether the reading is valid, nil disables the stall
+// detection
+// toleranceDeg: The maximum difference in degrees between the commanded and the measured angle
+func (s *SoakTest) SetFeedbackHook(feedbackFunc func() (uint16, bool), toleranceDeg uint16) {
+	s.feedbackFunc = feedbackFunc
+	s.stallToleranceDeg = toleranceDeg
+}
+
+// SetTemperatureHook sets the function that reads the temperature of the servo or its driver, sampled on every move
+//
+// Parameters:
+//
+// temperatureFunc: The function that returns the temperature, in any unit, and whether the reading is valid, nil
+// disables the temperature tracking
+func (s *SoakTest) SetTemperatureHook(temperatureFunc func() (int16, bool)) {
+	s.temperatureFunc = temperatureFunc
+}
+
+// Start resets the statistics and starts the test on the next update
+func (s *SoakTest) Start() {
+	s.stats = SoakStats{}
+	s.random = s.seed
+	s.hasStarted = false
+	s.hasCommanded = false
+	s.isRunning = true
+}
+
+// Stop stops the test, the statistics are kept
+func (s *SoakTest) Stop() {
+	s.isRunning = false
+}
+
+// IsRunning returns whether the test is running
+//
+// Returns:
+//
+// True if the test is running, false otherwise
+func (s *SoakTest) IsRunning() bool {
+	return s.isRunning
+}
+
+// Stats returns a snapshot of the collected statistics
+//
+// Returns:
+//
+// The statistics collected since the test was started
+func (s *SoakTest) Stats() SoakStats {
+	return s.stats
+}
+
+// nextAngle returns the next angle to command
+//
+// Returns:
+//
+// The next angle
+func (s *SoakTest) nextAngle() uint16 {
+	if s.pattern == SoakPatternRandom {
+		s.random = nextRandom(s.random)
+		return s.minAngle + uint16(s.random%uint32(s.maxAngle-s.minAngle+1))
+	}
+	if s.hasCommanded && s.commandedAngle == s.minAngle {
+		return s.maxAngle
+	}
+	return s.minAngle
+}
+
+// sampleTemperature reads the temperature hook and updates the temperature trend
+func (s *SoakTest) sampleTemperature() {
+	if s.temperatureFunc == nil {
+		return
+	}
+	temperature, ok := s.temperatureFunc()
+	if !ok {
+		return
+	}
+	if !s.stats.HasTemperature {
+		s.stats.HasTemperature = true
+		s.stats.FirstTemperature = temperature
+		s.stats.MinTemperature = temperature
+		s.stats.MaxTemperature = temperature
+	}
+	s.stats.LastTemperature = temperature
+	if temperature < s.stats.MinTemperature {
+		s.stats.MinTemperature = temperature
+	}
+	if temperature > s.stats.MaxTemperature {
+		s.stats.MaxTemperature = temperature
+	}
+}
+
+// checkStall compares the measured angle against the last commanded one
+func (s *SoakTest) checkStall() {
+	if s.feedbackFunc == nil || !s.hasCommanded {
+		return
+	}
+	angle, ok := s.feedbackFunc()
+	if ok && angleDifference(angle, s.commandedAngle) > s.stallToleranceDeg {
+		s.stats.StallEvents++
+	}
+}
+
+// Update runs the test, it must be called periodically from the main loop. Moves that were due but skipped because
+// the main loop was late are counted as missed updates
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (s *SoakTest) Update(nowMs uint32) {
+	if !s.isRunning {
+		return
+	}
+
+	// Start the test on the first update
+	if !s.hasStarted {
+		s.hasStarted = true
+		s.startMs = nowMs
+		s.lastMoveMs = nowMs - s.intervalMs
+	}
+
+	// Check if the test has finished
+	s.stats.ElapsedMs = nowMs - s.startMs
+	if s.stats.ElapsedMs >= s.durationMs {
+		s.isRunning = false
+		return
+	}
+
+	// Check if the next move is due
+	sinceMoveMs := nowMs - s.lastMoveMs
+	if sinceMoveMs < s.intervalMs {
+		return
+	}
+	if missed := sinceMoveMs/s.intervalMs - 1; missed > 0 {
+		s.stats.MissedUpdates += missed
+	}
+	s.lastMoveMs = nowMs
+
+	// Collect the statistics of the previous move
+	s.checkStall()
+	s.sampleTemperature()
+
+	// Command the next move
+	angle := s.nextAngle()
+	s.commandedAngle = angle
+	s.hasCommanded = true
+	s.stats.Moves++
+	if err := s.servo.SetAngle(angle); err != tinygoerrors.ErrorCodeNil {
+		s.stats.FailedMoves++
+	}
+}
+
+// Report logs the collected statistics, e.g. over the serial console
+//
+// Parameters:
+//
+// logger: The logger the report is written to
+//
+// Returns:
+//
+// An error if the logger is nil
+func (s *SoakTest) Report(logger tinygologger.Logger) tinygoerrors.ErrorCode {
+	if logger == nil {
+		return ErrorCodeRoutineNilLogger
+	}
+
+	logger.AddMessage(soakReportPrefix, true)
+	logger.AddMessageWithUint32(soakElapsedMsPrefix, s.stats.ElapsedMs, true, true, false)
+	logger.AddMessageWithUint32(soakMovesPrefix, s.stats.Moves, true, true, false)
+	logger.AddMessageWithUint32(soakMissedUpdatesPrefix, s.stats.MissedUpdates, true, true, false)
+	logger.AddMessageWithUint32(soakStallEventsPrefix, s.stats.StallEvents, true, true, false)
+	logger.AddMessageWithUint32(soakFailedMovesPrefix, s.stats.FailedMoves, true, true, false)
+	if s.stats.HasTemperature {
+		if s.stats.LastTemperature >= s.stats.FirstTemperature {
+			logger.AddMessageWithUint16(
+				soakTemperatureDeltaPrefix,
+				uint16(s.stats.LastTemperature-s.stats.FirstTemperature),
+				true,
+				true,
+				false,
+			)
+		} else {
+			logger.AddMessageWithUint16(
+				soakTemperatureDropPrefix,
+				uint16(s.stats.FirstTemperature-s.stats.LastTemperature),
+				true,
+				true,
+				false,
+			)
+		}
+	}
+	logger.Info()
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,244 @@
+package routine
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// ResponseModel is the identified response of a servo: the lag before it starts moving after a command and the
+	// speed it moves at
+	ResponseModel struct {
+		LagMs    uint32
+		MaxSpeed uint16
+	}
+
+	// StepResponse identifies the response model of a servo with position feedback by commanding steps between two
+	// angles and timing the feedback. The lag is measured until the feedback covers 10% of the step and the speed
+	// between 10% and 90% of it
+	StepResponse struct {
+		servo            Servo
+		feedbackFunc     func() (uint32, bool)
+		fromMilliDegrees uint32
+		toMilliDegrees   uint32
+		steps            uint8
+		timeoutMs        uint32
+		state            stepResponseState
+		completedSteps   uint8
+		measuredSteps    uint32
+		hasStepStart     bool
+		stepStartMs      uint32
+		stepFrom         uint32
+		stepTo           uint32
+		hasTenPercent    bool
+		tenPercentMs     uint32
+		lagSumMs         uint32
+		speedSum         uint32
+	}
+)
+
+// NewStepResponse creates a new instance of StepResponse
+//
+// Parameters:
+//
+// servo: The servo to identify
+// feedbackFunc: The function that returns the measured angle in millidegrees and whether the reading is valid
+// fromAngle: One end of the steps in degrees
+// toAngle: The other end of the steps in degrees
+// steps: The number of steps commanded, alternating their direction, the model averages them
+// timeoutMs: The maximum time in milliseconds a step is given to reach 90% of its travel, also waited before the first
+// step for the servo to reach the first end
+//
+// Returns:
+//
+// An instance of StepResponse and an error if any parameter is invalid
+func NewStepResponse(
+	servo Servo,
+	feedbackFunc func() (uint32, bool),
+	fromAngle uint16,
+	toAngle uint16,
+	steps uint8,
+	timeoutMs uint32,
+) (*StepResponse, tinygoerrors.ErrorCode) {
+	if servo == nil {
+		return nil, ErrorCodeRoutineNilServo
+	}
+	if feedbackFunc == nil {
+		return nil, ErrorCodeRoutineNilFeedbackFunc
+	}
+	if fromAngle == toAngle {
+		return nil, ErrorCodeRoutineInvalidRange
+	}
+	if steps == 0 {
+		return nil, ErrorCodeRoutineZeroSteps
+	}
+	if timeoutMs == 0 {
+		return nil, ErrorCodeRoutineZeroInterval
+	}
+	return &StepResponse{
+		servo:            servo,
+		feedbackFunc:     feedbackFunc,
+		fromMilliDegrees: uint32(fromAngle) * 1000,
+		toMilliDegrees:   uint32(toAngle) * 1000,
+		steps:            steps,
+		timeoutMs:        timeoutMs,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// Start moves the servo to the first end and starts the identification
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (s *StepResponse) Start() tinygoerrors.ErrorCode {
+	s.completedSteps = 0
+	s.measuredSteps = 0
+	s.lagSumMs = 0
+	s.speedSum = 0
+	s.hasStepStart = false
+	s.state = stepResponseStatePositioning
+	if err := s.servo.SetAngleMilliDegrees(s.fromMilliDegrees); err != tinygoerrors.ErrorCodeNil {
+		s.state = stepResponseStateIdle
+		return err
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// IsRunning returns whether the identification is running
+//
+// Returns:
+//
+// True if the identification is running, false otherwise
+func (s *StepResponse) IsRunning() bool {
+	return s.state == stepResponseStatePositioning || s.state == stepResponseStateStepping
+}
+
+// Model returns the identified response model
+//
+// Returns:
+//
+// The model averaged over the measured steps, and an error if the identification hasn't finished or no step reached
+// 90% of its travel within the timeout
+func (s *StepResponse) Model() (ResponseModel, tinygoerrors.ErrorCode) {
+	if s.state != stepResponseStateFinished {
+		return ResponseModel{}, ErrorCodeRoutineNotFinished
+	}
+	if s.measuredSteps == 0 {
+		return ResponseModel{}, ErrorCodeRoutineNoResponse
+	}
+	return ResponseModel{
+		LagMs:    s.lagSumMs / s.measuredSteps,
+		MaxSpeed: uint16(s.speedSum / s.measuredSteps),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// startStep commands the next step
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (s *StepResponse) startStep(nowMs uint32) tinygoerrors.ErrorCode {
+	// Alternate the direction of the steps
+	s.stepFrom, s.stepTo = s.fromMilliDegrees, s.toMilliDegrees
+	if s.completedSteps%2 == 1 {
+		s.stepFrom, s.stepTo = s.toMilliDegrees, s.fromMilliDegrees
+	}
+	s.state = stepResponseStateStepping
+	s.hasStepStart = true
+	s.stepStartMs = nowMs
+	s.hasTenPercent = false
+	if err := s.servo.SetAngleMilliDegrees(s.stepTo); err != tinygoerrors.ErrorCodeNil {
+		s.state = stepResponseStateIdle
+		return err
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// endStep finishes the current step, starting the next one if any
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+//
+// Returns:
+//
+// The error returned by the servo, if any
+func (s *StepResponse) endStep(nowMs uint32) tinygoerrors.ErrorCode {
+	s.completedSteps++
+	if s.completedSteps == s.steps {
+		s.state = stepResponseStateFinished
+		return tinygoerrors.ErrorCodeNil
+	}
+	return s.startStep(nowMs)
+}
+
+// Update runs the identification, it must be called periodically from the main loop and as often as possible, since
+// the feedback is sampled once per update
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by the servo, if any. The identification is stopped if a move fails
+func (s *StepResponse) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	switch s.state {
+	case stepResponseStatePositioning:
+		// Wait for the servo to reach the first end
+		if !s.hasStepStart {
+			s.hasStepStart = true
+			s.stepStartMs = nowMs
+		}
+		if nowMs-s.stepStartMs < s.timeoutMs {
+			return tinygoerrors.ErrorCodeNil
+		}
+		return s.startStep(nowMs)
+	case stepResponseStateStepping:
+	default:
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Give up on the step after the timeout
+	elapsedMs := nowMs - s.stepStartMs
+	if elapsedMs >= s.timeoutMs {
+		return s.endStep(nowMs)
+	}
+
+	// Sample the feedback
+	angle, ok := s.feedbackFunc()
+	if !ok {
+		return tinygoerrors.ErrorCodeNil
+	}
+	travel := angleDifferenceMilliDegrees(s.stepFrom, s.stepTo)
+	covered := uint32(0)
+	if (s.stepTo > s.stepFrom) == (angle > s.stepFrom) {
+		covered = angleDifferenceMilliDegrees(s.stepFrom, angle)
+	}
+
+	// Record the lag once the servo covered 10% of the step
+	if !s.hasTenPercent {
+		if covered*10 < travel {
+			return tinygoerrors.ErrorCodeNil
+		}
+		s.hasTenPercent = true
+		s.tenPercentMs = elapsedMs
+	}
+
+	// Record the speed once the servo covered 90% of the step, 80% of the travel in millidegrees over milliseconds
+	// is in degrees per second
+	if covered*10 < travel*9 {
+		return tinygoerrors.ErrorCodeNil
+	}
+	durationMs := elapsedMs - s.tenPercentMs
+	if durationMs == 0 {
+		durationMs = 1
+	}
+	s.lagSumMs += s.tenPercentMs
+	s.speedSum += travel * 8 / 10 / durationMs
+	s.measuredSteps++
+	return s.endStep(nowMs)
+}
@@ -0,0 +1,68 @@
+package routine
+
+// nextRandom advances a xorshift32 pseudo-random generator
+//
+// Parameters:
+//
+// state: The current generator state, it must not be zero
+//
+// Returns:
+//
+// The next generator state
+func nextRandom(state uint32) uint32 {
+	state ^= state << 13
+	state ^= state >> 17
+	state ^= state << 5
+	return state
+}
+
+// angleDifference returns the absolute difference between two angles
+//
+// Parameters:
+//
+// a: The first angle
+// b: The second angle
+//
+// Returns:
+//
+// The absolute difference
+func angleDifference(a uint16, b uint16) uint16 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// angleDifferenceMilliDegrees returns the absolute difference between two angles in millidegrees
+//
+// Parameters:
+//
+// a: The first angle
+// b: The second angle
+//
+// Returns:
+//
+// The absolute difference
+func angleDifferenceMilliDegrees(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// midpointMilliDegrees returns the angle halfway between two angles in millidegrees, without overflowing
+//
+// Parameters:
+//
+// a: The first angle
+// b: The second angle
+//
+// Returns:
+//
+// The midpoint
+func midpointMilliDegrees(a uint32, b uint32) uint32 {
+	if a > b {
+		return b + (a-b)/2
+	}
+	return a + (b-a)/2
+}
@@ -0,0 +1,300 @@
+package script
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// keywordMove is the statement that sets the absolute angle of a servo: move <servo> <angle>
+	keywordMove = []byte("move")
+
+	// keywordMoveRelative is the statement that sets the angle of a servo relative to its center: rel <servo> <angle>
+	keywordMoveRelative = []byte("rel")
+
+	// keywordCenter is the statement that centers a servo: center <servo>
+	keywordCenter = []byte("center")
+
+	// keywordWait is the statement that pauses the script: wait <milliseconds>
+	keywordWait = []byte("wait")
+
+	// keywordLoop is the statement that starts a repeated block, zero repeats it forever: loop <count>
+	keywordLoop = []byte("loop")
+
+	// keywordParallel is the statement that starts a parallel block: par
+	keywordParallel = []byte("par")
+
+	// keywordEnd is the statement that ends the innermost block: end
+	keywordEnd = []byte("end")
+
+	// keywordPose is the statement that sets every servo to a pose: pose <index>
+	keywordPose = []byte("pose")
+
+	// keywordHalt is the statement that stops the script: halt
+	keywordHalt = []byte("halt")
+)
+
+type (
+	// compiler is the state of a script compilation
+	compiler struct {
+		program     []byte
+		size        int
+		blocks      [MaxNestingDepth]Opcode
+		blocksCount int
+	}
+)
+
+// Compile compiles a script into bytecode. Every line holds a single statement, and everything after a '#' is
+// a comment:
+//
+//	move <servo> <angle>   sets the absolute angle of a servo
+//	rel <servo> <angle>    sets the angle of a servo relative to its center
+//	center <servo>         centers a servo
+//	pose <index>           sets every servo to the angles of a pose
+//	wait <milliseconds>    pauses the script
+//	loop <count>           starts a block repeated count times, zero repeats it forever
+//	par                    starts a block whose statements run at the same time
+//	end                    ends the innermost block
+//	halt                   stops the script
+//
+// Parameters:
+//
+// source: The script source
+// program: The buffer the bytecode is written to
+//
+// Returns:
+//
+// The size of the bytecode, the line number where compilation failed and an error if the script is malformed or
+// doesn't fit in the buffer
+func Compile(source []byte, program []byte) (int, int, tinygoerrors.ErrorCode) {
+	c := compiler{program: program}
+
+	line := 1
+	for start := 0; start <= len(source); line++ {
+		// Find the end of the line
+		end := start
+		for end < len(source) && source[end] != '\n' {
+			end++
+		}
+
+		// Compile the statement of the line
+		if err := c.compileLine(source[start:end]); err != tinygoerrors.ErrorCodeNil {
+			return 0, line, err
+		}
+		start = end + 1
+	}
+
+	// Check if every block was closed
+	if c.blocksCount != 0 {
+		return 0, line - 1, ErrorCodeScriptUnbalancedBlock
+	}
+	return c.size, 0, tinygoerrors.ErrorCodeNil
+}
+
+// compileLine compiles a single line of the script
+//
+// Parameters:
+//
+// line: The line without its terminator
+//
+// Returns:
+//
+// An error if the statement is malformed
+func (c *compiler) compileLine(line []byte) tinygoerrors.ErrorCode {
+	// Strip the comment
+	for index, character := range line {
+		if character == '#' {
+			line = line[:index]
+			break
+		}
+	}
+
+	// Split the statement into its fields
+	var fields [3][]byte
+	fieldsCount := 0
+	for index := 0; index < len(line); {
+		// Skip the whitespace
+		if isWhitespace(line[index]) {
+			index++
+			continue
+		}
+
+		// Read the field
+		start := index
+		for index < len(line) && !isWhitespace(line[index]) {
+			index++
+		}
+		if fieldsCount == len(fields) {
+			return ErrorCodeScriptInvalidArgument
+		}
+		fields[fieldsCount] = line[start:index]
+		fieldsCount++
+	}
+
+	// Check if the line is empty
+	if fieldsCount == 0 {
+		return tinygoerrors.ErrorCodeNil
+	}
+	keyword := fields[0]
+	arguments := fields[1:fieldsCount]
+
+	switch {
+	case equals(keyword, keywordMove):
+		return c.compileServoValue(OpcodeMove, arguments, 0, 0xffff)
+	case equals(keyword, keywordMoveRelative):
+		return c.compileServoValue(OpcodeMoveRelative, arguments, -0x8000, 0x7fff)
+	case equals(keyword, keywordCenter):
+		return c.compileByte(OpcodeCenter, arguments)
+	case equals(keyword, keywordPose):
+		return c.compileByte(OpcodePose, arguments)
+	case equals(keyword, keywordWait):
+		if len(arguments) != 1 {
+			return ErrorCodeScriptInvalidArgument
+		}
+		durationMs, ok := parseInt(arguments[0], 0, 0xffff)
+		if !ok {
+			return ErrorCodeScriptInvalidArgument
+		}
+		instruction, err := c.reserve(OpcodeWait)
+		if err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		encodeUint16(uint16(durationMs), instruction[1:])
+		return tinygoerrors.ErrorCodeNil
+	case equals(keyword, keywordLoop):
+		if err := c.openBlock(OpcodeLoop); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		return c.compileByte(OpcodeLoop, arguments)
+	case equals(keyword, keywordParallel):
+		if len(arguments) != 0 {
+			return ErrorCodeScriptInvalidArgument
+		}
+		if err := c.openBlock(OpcodeParallel); err != tinygoerrors.ErrorCodeNil {
+			return err
+		}
+		_, err := c.reserve(OpcodeParallel)
+		return err
+	case equals(keyword, keywordEnd):
+		if len(arguments) != 0 {
+			return ErrorCodeScriptInvalidArgument
+		}
+		if c.blocksCount == 0 {
+			return ErrorCodeScriptUnbalancedBlock
+		}
+		c.blocksCount--
+		opcode := OpcodeEndLoop
+		if c.blocks[c.blocksCount] == OpcodeParallel {
+			opcode = OpcodeEndParallel
+		}
+		_, err := c.reserve(opcode)
+		return err
+	case equals(keyword, keywordHalt):
+		if len(arguments) != 0 {
+			return ErrorCodeScriptInvalidArgument
+		}
+		_, err := c.reserve(OpcodeHalt)
+		return err
+	default:
+		return ErrorCodeScriptUnknownStatement
+	}
+}
+
+// openBlock tracks the start of a block
+//
+// Parameters:
+//
+// opcode: The opcode starting the block
+//
+// Returns:
+//
+// An error if the block is nested too deep or inside a parallel block
+func (c *compiler) openBlock(opcode Opcode) tinygoerrors.ErrorCode {
+	if c.blocksCount > 0 && c.blocks[c.blocksCount-1] == OpcodeParallel {
+		return ErrorCodeScriptNestedParallel
+	}
+	if c.blocksCount == MaxNestingDepth {
+		return ErrorCodeScriptNestingTooDeep
+	}
+	c.blocks[c.blocksCount] = opcode
+	c.blocksCount++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// reserve appends an instruction to the program
+//
+// Parameters:
+//
+// opcode: The opcode of the instruction
+//
+// Returns:
+//
+// The bytes of the instruction, with the opcode already written, and an error if the program buffer is full
+func (c *compiler) reserve(opcode Opcode) ([]byte, tinygoerrors.ErrorCode) {
+	size := opcode.Size()
+	if c.size+size > len(c.program) {
+		return nil, ErrorCodeScriptProgramTooLarge
+	}
+	instruction := c.program[c.size : c.size+size]
+	instruction[0] = byte(opcode)
+	c.size += size
+	return instruction, tinygoerrors.ErrorCodeNil
+}
+
+// compileByte compiles an instruction with a single byte operand
+//
+// Parameters:
+//
+// opcode: The opcode of the instruction
+// arguments: The arguments of the statement
+//
+// Returns:
+//
+// An error if the statement is malformed
+func (c *compiler) compileByte(opcode Opcode, arguments [][]byte) tinygoerrors.ErrorCode {
+	if len(arguments) != 1 {
+		return ErrorCodeScriptInvalidArgument
+	}
+	value, ok := parseInt(arguments[0], 0, 0xff)
+	if !ok {
+		return ErrorCodeScriptInvalidArgument
+	}
+	instruction, err := c.reserve(opcode)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	instruction[1] = byte(value)
+	return tinygoerrors.ErrorCodeNil
+}
+
+// compileServoValue compiles an instruction with a servo index and a 16-bit value as operands
+//
+// Parameters:
+//
+// opcode: The opcode of the instruction
+// arguments: The arguments of the statement
+// minValue: The minimum allowed value
+// maxValue: The maximum allowed value
+//
+// Returns:
+//
+// An error if the statement is malformed
+func (c *compiler) compileServoValue(opcode Opcode, arguments [][]byte, minValue int32, maxValue int32) tinygoerrors.ErrorCode {
+	if len(arguments) != 2 {
+		return ErrorCodeScriptInvalidArgument
+	}
+	servo, ok := parseInt(arguments[0], 0, 0xff)
+	if !ok {
+		return ErrorCodeScriptInvalidServoIndex
+	}
+	value, ok := parseInt(arguments[1], minValue, maxValue)
+	if !ok {
+		return ErrorCodeScriptInvalidArgument
+	}
+	instruction, err := c.reserve(opcode)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	instruction[1] = byte(servo)
+	encodeUint16(uint16(value), instruction[2:])
+	return tinygoerrors.ErrorCodeNil
+}
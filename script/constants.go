@@ -0,0 +1,10 @@
+package script
+
+const (
+	// MaxNestingDepth is the maximum number of nested blocks in a script
+	MaxNestingDepth = 8
+
+	// MaxInstructionsPerUpdate is the maximum number of instructions executed by a single Update call, so loops without
+	// waits can't stall the main loop
+	MaxInstructionsPerUpdate = 64
+)
@@ -0,0 +1,59 @@
+package script
+
+type (
+	// Opcode is an enum to represent the instructions of the servo script bytecode.
+	Opcode uint8
+)
+
+const (
+	// OpcodeHalt stops the script. It has no operands
+	OpcodeHalt Opcode = iota
+
+	// OpcodeMove sets the absolute angle of a servo. Operands: servo index (uint8), angle (uint16)
+	OpcodeMove
+
+	// OpcodeMoveRelative sets the angle of a servo relative to its center. Operands: servo index (uint8), angle (int16)
+	OpcodeMoveRelative
+
+	// OpcodeCenter centers a servo. Operands: servo index (uint8)
+	OpcodeCenter
+
+	// OpcodeWait pauses the script. Operands: duration in milliseconds (uint16)
+	OpcodeWait
+
+	// OpcodeLoop starts a block repeated a number of times, zero repeats it forever. Operands: count (uint8)
+	OpcodeLoop
+
+	// OpcodeEndLoop ends the innermost loop block. It has no operands
+	OpcodeEndLoop
+
+	// OpcodeParallel starts a block whose instructions run at the same time, so it lasts as long as its longest wait.
+	// It has no operands
+	OpcodeParallel
+
+	// OpcodeEndParallel ends the parallel block. It has no operands
+	OpcodeEndParallel
+
+	// OpcodePose sets every servo to the angles of a pose. Operands: pose index (uint8)
+	OpcodePose
+)
+
+// Size returns the size of the instruction in bytes, including the opcode
+//
+// Returns:
+//
+// The size of the instruction, or zero if the opcode is unknown
+func (o Opcode) Size() int {
+	switch o {
+	case OpcodeHalt, OpcodeEndLoop, OpcodeParallel, OpcodeEndParallel:
+		return 1
+	case OpcodeCenter, OpcodeLoop, OpcodePose:
+		return 2
+	case OpcodeWait:
+		return 3
+	case OpcodeMove, OpcodeMoveRelative:
+		return 4
+	default:
+		return 0
+	}
+}
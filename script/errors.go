@@ -0,0 +1,26 @@
+package script
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeScriptStartNumber is the starting number for script-related error codes.
+	ErrorCodeScriptStartNumber uint16 = 5400
+)
+
+const (
+	ErrorCodeScriptUnknownOpcode tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeScriptStartNumber)
+	ErrorCodeScriptTruncatedInstruction
+	ErrorCodeScriptInvalidServoIndex
+	ErrorCodeScriptInvalidPoseIndex
+	ErrorCodeScriptUnbalancedBlock
+	ErrorCodeScriptNestingTooDeep
+	ErrorCodeScriptNestedParallel
+	ErrorCodeScriptInvalidPose
+	ErrorCodeScriptNotLoaded
+	ErrorCodeScriptUnknownStatement
+	ErrorCodeScriptInvalidArgument
+	ErrorCodeScriptProgramTooLarge
+	ErrorCodeScriptNilServo
+)
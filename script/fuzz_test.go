@@ -0,0 +1,89 @@
+//go:build !tinygo
+
+package script
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/internal/fuzzservos"
+)
+
+const (
+	// fuzzUpdates is the number of updates a fuzzed program runs for, its loops may never end
+	fuzzUpdates = 256
+
+	// fuzzUpdateIntervalMs is the time between the updates of a fuzzed program
+	fuzzUpdateIntervalMs = 50
+)
+
+// runFuzzProgram loads and runs a program, failing the test if any servo outputs a pulse width beyond its pulse range
+// or is moved outside its limits
+func runFuzzProgram(t *testing.T, program []byte) {
+	servos := fuzzservos.New(t)
+	scriptServos := make([]Servo, len(servos.Handlers))
+	poses := [][]uint16{make([]uint16, len(servos.Handlers)), make([]uint16, len(servos.Handlers))}
+	for index, handler := range servos.Handlers {
+		scriptServos[index] = handler
+		poses[0][index] = handler.LeftLimit()
+		poses[1][index] = handler.RightLimit()
+	}
+	interpreter, err := NewInterpreter(scriptServos, poses)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("NewInterpreter: %d", err)
+	}
+	if err = interpreter.Load(program); err != tinygoerrors.ErrorCodeNil {
+		return
+	}
+	if err = interpreter.Start(); err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("Start: %d", err)
+	}
+
+	for update := uint32(0); update < fuzzUpdates && interpreter.IsRunning(); update++ {
+		nowMs := update * fuzzUpdateIntervalMs
+		_ = interpreter.Update(nowMs)
+		servos.Update(t, nowMs)
+	}
+	servos.CheckPulses(t)
+}
+
+// FuzzCompile compiles arbitrary scripts, checking the compiler never panics nor writes beyond the program buffer,
+// and runs the compiled programs the interpreter accepts
+func FuzzCompile(f *testing.F) {
+	f.Add([]byte("move 0 90\nwait 100\nrel 1 -45 # left\ncenter 2\n"))
+	f.Add([]byte("loop 3\n par\n  move 0 180\n  move 1 0\n  wait 200\n end\nend\npose 1\nhalt\n"))
+	f.Add([]byte("loop 0\nrel 2 32767\nwait 10\nrel 2 -32768\nend\n"))
+	f.Add([]byte("end\nmove 9 9999\nwait -1\npose 255\n"))
+	f.Fuzz(func(t *testing.T, source []byte) {
+		var program [128]byte
+		size, _, err := Compile(source, program[:])
+		if err != tinygoerrors.ErrorCodeNil {
+			return
+		}
+		if size > len(program) {
+			t.Fatalf("program of %d bytes in a %d bytes buffer", size, len(program))
+		}
+		runFuzzProgram(t, program[:size])
+	})
+}
+
+// FuzzInterpreterLoad loads arbitrary bytecode, checking the validation rejects the programs that would index beyond
+// the servos, the poses or the program itself, and that the accepted ones keep the servos within their travel
+func FuzzInterpreterLoad(f *testing.F) {
+	for _, source := range []string{
+		"move 0 90\nwait 100\nrel 1 -45\ncenter 2\n",
+		"loop 2\npar\npose 0\nwait 10\nend\nend\n",
+	} {
+		var program [64]byte
+		size, _, err := Compile([]byte(source), program[:])
+		if err != tinygoerrors.ErrorCodeNil {
+			f.Fatalf("seed %q: %d", source, err)
+		}
+		f.Add(program[:size])
+	}
+	f.Add([]byte{byte(OpcodeMove), 7, 0xFF, 0xFF})
+	f.Add([]byte{byte(OpcodeLoop), 0})
+	f.Fuzz(func(t *testing.T, program []byte) {
+		runFuzzProgram(t, program)
+	})
+}
@@ -0,0 +1,15 @@
+package script
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo Handler interface used by the interpreter, so the scripts can drive
+	// any handler and the bytecode can be fuzzed against fakes
+	Servo interface {
+		SetAngle(angle uint16) tinygoerrors.ErrorCode
+		SetAngleRelativeToCenter(relativeAngle int16) tinygoerrors.ErrorCode
+		SetAngleToCenter() tinygoerrors.ErrorCode
+	}
+)
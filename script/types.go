@@ -0,0 +1,288 @@
+package script
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// loopFrame is the state of a running loop block
+	loopFrame struct {
+		startIndex int
+		remaining  uint8
+		isForever  bool
+	}
+
+	// Interpreter executes servo script bytecode without blocking, driven by Update
+	Interpreter struct {
+		servos          []Servo
+		poses           [][]uint16
+		program         []byte
+		index           int
+		isRunning       bool
+		isWaiting       bool
+		waitTimestampMs uint32
+		waitMs          uint32
+		isInParallel    bool
+		parallelWaitMs  uint32
+		loops           [MaxNestingDepth]loopFrame
+		loopsCount      int
+	}
+)
+
+// NewInterpreter creates a new instance of Interpreter
+//
+// Parameters:
+//
+// servos: The servos the scripts can command, addressed by their index
+// poses: The poses the scripts can call, each one with an absolute angle for every servo
+//
+// Returns:
+//
+// An instance of Interpreter and an error if any servo is nil or any pose doesn't have an angle for every servo
+func NewInterpreter(servos []Servo, poses [][]uint16) (*Interpreter, tinygoerrors.ErrorCode) {
+	// Check if the servos are valid
+	for _, servo := range servos {
+		if servo == nil {
+			return nil, ErrorCodeScriptNilServo
+		}
+	}
+
+	// Check if the poses are valid
+	for _, pose := range poses {
+		if len(pose) != len(servos) {
+			return nil, ErrorCodeScriptInvalidPose
+		}
+	}
+
+	return &Interpreter{
+		servos: servos,
+		poses:  poses,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// Validate checks that a program is well-formed for the servos and poses of the interpreter, so it can't fail while
+// running except for the errors returned by the servos
+//
+// Parameters:
+//
+// program: The bytecode to check
+//
+// Returns:
+//
+// An error if the program is malformed
+func (i *Interpreter) Validate(program []byte) tinygoerrors.ErrorCode {
+	var blocks [MaxNestingDepth]Opcode
+	blocksCount := 0
+
+	for index := 0; index < len(program); {
+		// Check if the instruction is complete
+		opcode := Opcode(program[index])
+		size := opcode.Size()
+		if size == 0 {
+			return ErrorCodeScriptUnknownOpcode
+		}
+		if index+size > len(program) {
+			return ErrorCodeScriptTruncatedInstruction
+		}
+
+		// Check the operands and the blocks nesting
+		switch opcode {
+		case OpcodeMove, OpcodeMoveRelative, OpcodeCenter:
+			if int(program[index+1]) >= len(i.servos) {
+				return ErrorCodeScriptInvalidServoIndex
+			}
+		case OpcodePose:
+			if int(program[index+1]) >= len(i.poses) {
+				return ErrorCodeScriptInvalidPoseIndex
+			}
+		case OpcodeLoop, OpcodeParallel:
+			if blocksCount > 0 && blocks[blocksCount-1] == OpcodeParallel {
+				return ErrorCodeScriptNestedParallel
+			}
+			if blocksCount == MaxNestingDepth {
+				return ErrorCodeScriptNestingTooDeep
+			}
+			blocks[blocksCount] = opcode
+			blocksCount++
+		case OpcodeEndLoop, OpcodeEndParallel:
+			expected := OpcodeLoop
+			if opcode == OpcodeEndParallel {
+				expected = OpcodeParallel
+			}
+			if blocksCount == 0 || blocks[blocksCount-1] != expected {
+				return ErrorCodeScriptUnbalancedBlock
+			}
+			blocksCount--
+		}
+		index += size
+	}
+
+	// Check if every block was closed
+	if blocksCount != 0 {
+		return ErrorCodeScriptUnbalancedBlock
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Load validates a program and prepares it to be run from its first instruction
+//
+// Parameters:
+//
+// program: The bytecode to run, it is not copied so it must not be modified while loaded
+//
+// Returns:
+//
+// An error if the program is malformed
+func (i *Interpreter) Load(program []byte) tinygoerrors.ErrorCode {
+	if err := i.Validate(program); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	i.program = program
+	i.Stop()
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Start runs the loaded program from its first instruction
+//
+// Returns:
+//
+// An error if no program was loaded
+func (i *Interpreter) Start() tinygoerrors.ErrorCode {
+	if i.program == nil {
+		return ErrorCodeScriptNotLoaded
+	}
+	i.Stop()
+	i.isRunning = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Stop stops the running program, leaving the servos at their current angles
+func (i *Interpreter) Stop() {
+	i.index = 0
+	i.isRunning = false
+	i.isWaiting = false
+	i.isInParallel = false
+	i.loopsCount = 0
+}
+
+// IsRunning checks if the program is running
+//
+// Returns:
+//
+// True if the program is running, false otherwise
+func (i *Interpreter) IsRunning() bool {
+	return i.isRunning
+}
+
+// wait pauses the program
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+// durationMs: The duration of the pause in milliseconds
+func (i *Interpreter) wait(nowMs uint32, durationMs uint32) {
+	i.isWaiting = true
+	i.waitTimestampMs = nowMs
+	i.waitMs = durationMs
+}
+
+// Update executes the program until it waits, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+//
+// Returns:
+//
+// The error returned by a servo, which stops the program
+func (i *Interpreter) Update(nowMs uint32) tinygoerrors.ErrorCode {
+	// Check if the program is running
+	if !i.isRunning {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Check if the program is still waiting
+	if i.isWaiting {
+		if nowMs-i.waitTimestampMs < i.waitMs {
+			return tinygoerrors.ErrorCodeNil
+		}
+		i.isWaiting = false
+	}
+
+	for executed := 0; executed < MaxInstructionsPerUpdate; executed++ {
+		// Check if the program has finished
+		if i.index >= len(i.program) {
+			i.isRunning = false
+			return tinygoerrors.ErrorCodeNil
+		}
+
+		// Fetch the instruction
+		opcode := Opcode(i.program[i.index])
+		operands := i.program[i.index+1 : i.index+opcode.Size()]
+		i.index += opcode.Size()
+
+		// Execute the instruction
+		err := tinygoerrors.ErrorCodeNil
+		switch opcode {
+		case OpcodeHalt:
+			i.isRunning = false
+			return tinygoerrors.ErrorCodeNil
+		case OpcodeMove:
+			err = i.servos[operands[0]].SetAngle(decodeUint16(operands[1:]))
+		case OpcodeMoveRelative:
+			err = i.servos[operands[0]].SetAngleRelativeToCenter(int16(decodeUint16(operands[1:])))
+		case OpcodeCenter:
+			err = i.servos[operands[0]].SetAngleToCenter()
+		case OpcodePose:
+			for index, angle := range i.poses[operands[0]] {
+				if err = i.servos[index].SetAngle(angle); err != tinygoerrors.ErrorCodeNil {
+					break
+				}
+			}
+		case OpcodeWait:
+			durationMs := uint32(decodeUint16(operands))
+			if i.isInParallel {
+				// Waits inside a parallel block overlap, so the block lasts as long as its longest wait
+				if durationMs > i.parallelWaitMs {
+					i.parallelWaitMs = durationMs
+				}
+				continue
+			}
+			i.wait(nowMs, durationMs)
+			return tinygoerrors.ErrorCodeNil
+		case OpcodeLoop:
+			i.loops[i.loopsCount] = loopFrame{
+				startIndex: i.index,
+				remaining:  operands[0],
+				isForever:  operands[0] == 0,
+			}
+			i.loopsCount++
+		case OpcodeEndLoop:
+			frame := &i.loops[i.loopsCount-1]
+			if !frame.isForever {
+				frame.remaining--
+			}
+			if frame.isForever || frame.remaining > 0 {
+				i.index = frame.startIndex
+			} else {
+				i.loopsCount--
+			}
+		case OpcodeParallel:
+			i.isInParallel = true
+			i.parallelWaitMs = 0
+		case OpcodeEndParallel:
+			i.isInParallel = false
+			if i.parallelWaitMs > 0 {
+				i.wait(nowMs, i.parallelWaitMs)
+				return tinygoerrors.ErrorCodeNil
+			}
+		}
+
+		// Stop the program if a servo failed
+		if err != tinygoerrors.ErrorCodeNil {
+			i.isRunning = false
+			return err
+		}
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,104 @@
+package script
+
+// decodeUint16 decodes a little-endian uint16 operand
+//
+// Parameters:
+//
+// data: The operand bytes, at least two
+//
+// Returns:
+//
+// The decoded value
+func decodeUint16(data []byte) uint16 {
+	return uint16(data[0]) | uint16(data[1])<<8
+}
+
+// encodeUint16 encodes a little-endian uint16 operand
+//
+// Parameters:
+//
+// value: The value to encode
+// data: The operand bytes, at least two
+func encodeUint16(value uint16, data []byte) {
+	data[0] = byte(value)
+	data[1] = byte(value >> 8)
+}
+
+// isWhitespace checks if a character separates the fields of a statement
+//
+// Parameters:
+//
+// character: The character to check
+//
+// Returns:
+//
+// True if the character is a space, a tab or a carriage return, false otherwise
+func isWhitespace(character byte) bool {
+	return character == ' ' || character == '\t' || character == '\r'
+}
+
+// equals checks if two byte slices hold the same bytes
+//
+// Parameters:
+//
+// a: The first byte slice
+// b: The second byte slice
+//
+// Returns:
+//
+// True if both byte slices are equal, false otherwise
+func equals(a []byte, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseInt parses a signed decimal number within a range
+//
+// Parameters:
+//
+// data: The ASCII digits, optionally preceded by a sign
+// minValue: The minimum allowed value
+// maxValue: The maximum allowed value
+//
+// Returns:
+//
+// The parsed number and false if the input is not a valid number within the range
+func parseInt(data []byte, minValue int32, maxValue int32) (int32, bool) {
+	// Check for the sign
+	isNegative := false
+	if len(data) > 0 && (data[0] == '-' || data[0] == '+') {
+		isNegative = data[0] == '-'
+		data = data[1:]
+	}
+	if len(data) == 0 {
+		return 0, false
+	}
+
+	// Parse the digits, stopping before the value can overflow
+	var value int64
+	for _, digit := range data {
+		if digit < '0' || digit > '9' {
+			return 0, false
+		}
+		value = value*10 + int64(digit-'0')
+		if value > 0xffffffff {
+			return 0, false
+		}
+	}
+	if isNegative {
+		value = -value
+	}
+
+	// Check if the value is within the range
+	if value < int64(minValue) || value > int64(maxValue) {
+		return 0, false
+	}
+	return int32(value), true
+}
@@ -0,0 +1,93 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// selfCheckCaptures is the number of pulses captured by a self-check, only the last one is checked since the
+	// first may still have the width set before the duty cycle update took effect
+	selfCheckCaptures = 2
+)
+
+var (
+	// selfCheckMismatchPrefix is the prefix message logged when the captured pulse width doesn't match
+	selfCheckMismatchPrefix = []byte("Servo self-check captured pulse width:")
+
+	// selfCheckExpectedPrefix is the prefix message of the expected pulse width of a failed self-check
+	selfCheckExpectedPrefix = []byte("expected:")
+)
+
+// SetSelfCheck sets the loopback capture Initialize verifies the output with, so clock or prescaler
+// misconfigurations that stretch the pulses are caught when the handler is initialized. The PWM output must be wired
+// to the capture pin
+//
+// Parameters:
+//
+// capture: The capture of the looped back output, or nil to not check it
+// tolerance: The largest difference in nanoseconds between the captured and the commanded pulse widths
+func (h *DefaultHandler) SetSelfCheck(capture PulseCapture, tolerance Nanoseconds) {
+	h.selfCheckCapture = capture
+	h.selfCheckTolerance = tolerance
+}
+
+// SelfCheck captures the pulses of the looped back output and verifies their width matches the commanded one. The
+// capture sees the high time of the line, so the complement of the pulse width is expected if the polarity is inverted
+//
+// Returns:
+//
+// The captured pulse width in nanoseconds, and an error if no capture is set, the pulses are not being output, the
+// capture timed out or the width is off by more than the tolerance
+func (h *DefaultHandler) SelfCheck() (Nanoseconds, tinygoerrors.ErrorCode) {
+	// Check if the pulses can be captured
+	if h.selfCheckCapture == nil {
+		return 0, h.reportError(ErrorCodeServoNilCapture)
+	}
+	if h.isSleeping || !h.canWritePulse() {
+		return 0, h.reportError(ErrorCodeServoSelfCheckUnavailable)
+	}
+
+	// Capture the pulses, keeping the last one
+	var captured Nanoseconds
+	for index := 0; index < selfCheckCaptures; index++ {
+		width, ok := h.selfCheckCapture.CapturePulseWidth()
+		if !ok {
+			return 0, h.reportError(ErrorCodeServoCaptureTimeout)
+		}
+		captured = Nanoseconds(width)
+	}
+
+	// Compare the high time of the line with the commanded pulse width
+	expected := h.pulse
+	if h.polarity == PolarityInverted {
+		expected = h.period - min(h.pulse, h.period)
+	}
+	difference := captured - expected
+	if captured < expected {
+		difference = expected - captured
+	}
+	if difference <= h.selfCheckTolerance {
+		return captured, tinygoerrors.ErrorCodeNil
+	}
+
+	// Log the mismatch if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.AddMessageWithUint32(
+			selfCheckMismatchPrefix,
+			uint32(captured),
+			true,
+			true,
+			false,
+		)
+		h.logger.AddMessageWithUint32(
+			selfCheckExpectedPrefix,
+			uint32(expected),
+			true,
+			true,
+			false,
+		)
+		h.logger.Error()
+	}
+	return captured, h.reportError(ErrorCodeServoPulseMismatch)
+}
@@ -0,0 +1,16 @@
+package servotest
+
+type (
+	// CommandKind is an enum to represent the commands recorded by a Handler.
+	CommandKind uint8
+)
+
+const (
+	CommandKindAngle CommandKind = iota
+	CommandKindPulse
+	CommandKindTimedMove
+	CommandKindMaxSpeed
+	CommandKindDetach
+	CommandKindAttach
+	CommandKindTrim
+)
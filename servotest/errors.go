@@ -0,0 +1,17 @@
+package servotest
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeServoTestStartNumber is the starting number for fake-related error codes.
+	ErrorCodeServoTestStartNumber uint16 = 5680
+)
+
+const (
+	ErrorCodeServoTestAngleOutOfRange tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeServoTestStartNumber)
+	ErrorCodeServoTestInvalidPulseWidth
+	ErrorCodeServoTestInvalidParameters
+	ErrorCodeServoTestInjected
+)
@@ -0,0 +1,144 @@
+package servotest
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Pulse is a pulse width written to an Output
+	Pulse struct {
+		TimestampMs uint32
+		PulseWidth  uint32
+	}
+
+	// Output is a fake PulseOutput for host tests of the handlers and the backends built on it. It records the period
+	// it is configured with and every pulse width written, with the time set with SetTime, and its configuration can
+	// be made to fail
+	Output struct {
+		period       uint32
+		isConfigured bool
+		configures   uint32
+		configureErr tinygoerrors.ErrorCode
+		nowMs        uint32
+		pulses       []Pulse
+	}
+)
+
+// NewOutput creates a new instance of Output
+//
+// Returns:
+//
+// An instance of Output, not configured
+func NewOutput() *Output {
+	return &Output{}
+}
+
+// Configure records the period of the signal
+//
+// Parameters:
+//
+// period: The period in nanoseconds
+//
+// Returns:
+//
+// The error set with FailConfigure, if any
+func (o *Output) Configure(period uint32) tinygoerrors.ErrorCode {
+	o.configures++
+	if o.configureErr != tinygoerrors.ErrorCodeNil {
+		return o.configureErr
+	}
+	o.period = period
+	o.isConfigured = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetPulse records a pulse width
+//
+// Parameters:
+//
+// pulse: The pulse width in nanoseconds
+func (o *Output) SetPulse(pulse uint32) {
+	o.pulses = append(o.pulses, Pulse{TimestampMs: o.nowMs, PulseWidth: pulse})
+}
+
+// FailConfigure makes the next configurations fail
+//
+// Parameters:
+//
+// errCode: The error returned by Configure, or tinygoerrors.ErrorCodeNil to let it succeed again
+func (o *Output) FailConfigure(errCode tinygoerrors.ErrorCode) {
+	o.configureErr = errCode
+}
+
+// SetTime sets the time the next pulse widths are recorded at
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+func (o *Output) SetTime(nowMs uint32) {
+	o.nowMs = nowMs
+}
+
+// Period returns the period the output was last configured with
+//
+// Returns:
+//
+// The period in nanoseconds, and true if the output was configured
+func (o *Output) Period() (uint32, bool) {
+	return o.period, o.isConfigured
+}
+
+// Configures returns the number of calls to Configure, including the failed ones
+//
+// Returns:
+//
+// The number of configurations
+func (o *Output) Configures() uint32 {
+	return o.configures
+}
+
+// Pulses returns the recorded pulse widths, oldest first
+//
+// Returns:
+//
+// The recorded pulse widths
+func (o *Output) Pulses() []Pulse {
+	return o.pulses
+}
+
+// LastPulse returns the newest pulse width
+//
+// Returns:
+//
+// The pulse width in nanoseconds, and true if any was written
+func (o *Output) LastPulse() (uint32, bool) {
+	if len(o.pulses) == 0 {
+		return 0, false
+	}
+	return o.pulses[len(o.pulses)-1].PulseWidth, true
+}
+
+// ClearPulses forgets the recorded pulse widths
+func (o *Output) ClearPulses() {
+	o.pulses = o.pulses[:0]
+}
+
+// PulseOutside returns the first recorded pulse width outside a pulse range, e.g. to check a parser fed arbitrary
+// input never drives the servo beyond its travel. The zero pulse widths of an idle output are not outside the range
+//
+// Parameters:
+//
+// minPulseWidth: The shortest pulse width allowed in nanoseconds
+// maxPulseWidth: The longest pulse width allowed in nanoseconds
+//
+// Returns:
+//
+// The first recorded pulse width outside the range, and true if any was found
+func (o *Output) PulseOutside(minPulseWidth uint32, maxPulseWidth uint32) (Pulse, bool) {
+	for _, pulse := range o.pulses {
+		if pulse.PulseWidth != 0 && (pulse.PulseWidth < minPulseWidth || pulse.PulseWidth > maxPulseWidth) {
+			return pulse, true
+		}
+	}
+	return Pulse{}, false
+}
@@ -0,0 +1,530 @@
+package servotest
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Command is a command received by a Handler
+	Command struct {
+		TimestampMs       uint32
+		Kind              CommandKind
+		AngleMilliDegrees uint32
+		PulseWidth        uint32
+		DurationMs        uint32
+		MaxSpeed          uint16
+		Trim              int16
+		Err               tinygoerrors.ErrorCode
+	}
+
+	// Handler is a fake servo handler for host tests of the application code. It implements the methods of the
+	// tinygo-servo Handler interface and the Servo interfaces of its sub-packages, records every command with the
+	// time set with SetTime and can be made to fail. The angles are applied immediately, timed moves and speed caps
+	// are only recorded
+	Handler struct {
+		actuationRange     uint16
+		minPulseWidth      uint32
+		maxPulseWidth      uint32
+		centerAngle        uint16
+		leftLimitAngle     uint16
+		rightLimitAngle    uint16
+		trim               int16
+		maxSpeed           uint16
+		angle              uint32
+		pulse              uint32
+		isMovementDisabled bool
+		isSleeping         bool
+		isDetached         bool
+		nowMs              uint32
+		commands           []Command
+		injectedError      tinygoerrors.ErrorCode
+		injectedCount      uint32
+	}
+)
+
+// NewHandler creates a new instance of Handler centered in its actuation range, free to move over all of it
+//
+// Parameters:
+//
+// actuationRange: The actuation range in degrees
+// minPulseWidth: The pulse width of the 0 degrees angle in nanoseconds
+// maxPulseWidth: The pulse width of the actuation range angle in nanoseconds
+//
+// Returns:
+//
+// An instance of Handler and an error if the actuation range is zero or the pulse widths are not increasing
+func NewHandler(actuationRange uint16, minPulseWidth uint32, maxPulseWidth uint32) (
+	*Handler,
+	tinygoerrors.ErrorCode,
+) {
+	if actuationRange == 0 || minPulseWidth >= maxPulseWidth {
+		return nil, ErrorCodeServoTestInvalidParameters
+	}
+	centerAngle := actuationRange / 2
+	handler := &Handler{
+		actuationRange:  actuationRange,
+		minPulseWidth:   minPulseWidth,
+		maxPulseWidth:   maxPulseWidth,
+		centerAngle:     centerAngle,
+		rightLimitAngle: actuationRange,
+		angle:           uint32(centerAngle) * 1000,
+	}
+	handler.pulse = handler.calculatePulse(handler.angle)
+	return handler, tinygoerrors.ErrorCodeNil
+}
+
+// SetLimits sets the left and right limit angles, the commands outside of them fail like they do on the handlers
+//
+// Parameters:
+//
+// leftLimitAngle: The left limit angle in degrees
+// rightLimitAngle: The right limit angle in degrees, not lower than the left one nor higher than the actuation range
+//
+// Returns:
+//
+// An error if the limits are invalid
+func (h *Handler) SetLimits(leftLimitAngle uint16, rightLimitAngle uint16) tinygoerrors.ErrorCode {
+	if leftLimitAngle > rightLimitAngle || rightLimitAngle > h.actuationRange {
+		return ErrorCodeServoTestInvalidParameters
+	}
+	h.leftLimitAngle = leftLimitAngle
+	h.rightLimitAngle = rightLimitAngle
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetTime sets the time the next commands are recorded at
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+func (h *Handler) SetTime(nowMs uint32) {
+	h.nowMs = nowMs
+}
+
+// Update sets the time the next commands are recorded at, like the main loop updates the handlers
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds
+func (h *Handler) Update(nowMs uint32) {
+	h.nowMs = nowMs
+}
+
+// InjectError makes the next commands fail with an error. The failed commands are recorded with it and don't change
+// the state of the handler
+//
+// Parameters:
+//
+// errCode: The error returned by the commands
+// count: The number of commands that fail, or zero to fail every command until ClearError is called
+func (h *Handler) InjectError(errCode tinygoerrors.ErrorCode, count uint32) {
+	h.injectedError = errCode
+	h.injectedCount = count
+}
+
+// ClearError stops the injected failures
+func (h *Handler) ClearError() {
+	h.injectedError = tinygoerrors.ErrorCodeNil
+	h.injectedCount = 0
+}
+
+// Commands returns the recorded commands, oldest first
+//
+// Returns:
+//
+// The recorded commands
+func (h *Handler) Commands() []Command {
+	return h.commands
+}
+
+// LastCommand returns the newest recorded command
+//
+// Returns:
+//
+// The command and true if any was recorded, or an empty command and false otherwise
+func (h *Handler) LastCommand() (Command, bool) {
+	if len(h.commands) == 0 {
+		return Command{}, false
+	}
+	return h.commands[len(h.commands)-1], true
+}
+
+// ClearCommands forgets the recorded commands
+func (h *Handler) ClearCommands() {
+	h.commands = h.commands[:0]
+}
+
+// record records a command, failing it if an error is injected
+//
+// Parameters:
+//
+// command: The command to record, its timestamp is set
+//
+// Returns:
+//
+// The error of the command, the injected one if any
+func (h *Handler) record(command Command) tinygoerrors.ErrorCode {
+	if command.Err == tinygoerrors.ErrorCodeNil && h.injectedError != tinygoerrors.ErrorCodeNil {
+		command.Err = h.injectedError
+		if h.injectedCount != 0 {
+			h.injectedCount--
+			if h.injectedCount == 0 {
+				h.injectedError = tinygoerrors.ErrorCodeNil
+			}
+		}
+	}
+	command.TimestampMs = h.nowMs
+	h.commands = append(h.commands, command)
+	return command.Err
+}
+
+// calculatePulse calculates the pulse width of an angle
+//
+// Parameters:
+//
+// milliDegrees: The angle in millidegrees
+//
+// Returns:
+//
+// The pulse width in nanoseconds
+func (h *Handler) calculatePulse(milliDegrees uint32) uint32 {
+	span := uint64(h.maxPulseWidth - h.minPulseWidth)
+	return h.minPulseWidth + uint32(span*uint64(milliDegrees)/(uint64(h.actuationRange)*1000))
+}
+
+// isWithinLimits checks if an angle is within the limits
+//
+// Parameters:
+//
+// milliDegrees: The angle in millidegrees
+//
+// Returns:
+//
+// True if the angle is within the limits, false otherwise
+func (h *Handler) isWithinLimits(milliDegrees uint32) bool {
+	return milliDegrees >= uint32(h.leftLimitAngle)*1000 && milliDegrees <= uint32(h.rightLimitAngle)*1000
+}
+
+// setAngle records an angle command and applies it unless it fails
+//
+// Parameters:
+//
+// kind: The kind of the command
+// milliDegrees: The angle in millidegrees
+// durationMs: The duration of the move in milliseconds, for the timed moves
+//
+// Returns:
+//
+// An error if the angle is out of the limits or an error is injected
+func (h *Handler) setAngle(kind CommandKind, milliDegrees uint32, durationMs uint32) tinygoerrors.ErrorCode {
+	command := Command{
+		Kind:              kind,
+		AngleMilliDegrees: milliDegrees,
+		PulseWidth:        h.calculatePulse(milliDegrees),
+		DurationMs:        durationMs,
+	}
+	if !h.isWithinLimits(milliDegrees) {
+		command.Err = ErrorCodeServoTestAngleOutOfRange
+	}
+	if err := h.record(command); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.angle = milliDegrees
+	h.pulse = command.PulseWidth
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetAngle sets the angle
+//
+// Parameters:
+//
+// angle: The angle in degrees
+//
+// Returns:
+//
+// An error if the angle is out of the limits or an error is injected
+func (h *Handler) SetAngle(angle uint16) tinygoerrors.ErrorCode {
+	return h.setAngle(CommandKindAngle, uint32(angle)*1000, 0)
+}
+
+// SetAngleMilliDegrees sets the angle with millidegree resolution
+//
+// Parameters:
+//
+// milliDegrees: The angle in millidegrees
+//
+// Returns:
+//
+// An error if the angle is out of the limits or an error is injected
+func (h *Handler) SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	return h.setAngle(CommandKindAngle, milliDegrees, 0)
+}
+
+// MoveToMilliDegrees records a timed move and sets its target angle immediately
+//
+// Parameters:
+//
+// milliDegrees: The target angle in millidegrees
+// durationMs: The duration of the move in milliseconds
+//
+// Returns:
+//
+// An error if the angle is out of the limits or an error is injected
+func (h *Handler) MoveToMilliDegrees(milliDegrees uint32, durationMs uint32) tinygoerrors.ErrorCode {
+	return h.setAngle(CommandKindTimedMove, milliDegrees, durationMs)
+}
+
+// GetAngle returns the angle
+//
+// Returns:
+//
+// The angle in degrees, truncated
+func (h *Handler) GetAngle() uint16 {
+	return uint16(h.angle / 1000)
+}
+
+// GetAngleMilliDegrees returns the angle
+//
+// Returns:
+//
+// The angle in millidegrees
+func (h *Handler) GetAngleMilliDegrees() uint32 {
+	return h.angle
+}
+
+// SetAngleRelativeToCenter sets the angle relative to the center, clamped to the limits
+//
+// Parameters:
+//
+// relativeAngle: The relative angle in degrees, negative to the left and positive to the right
+//
+// Returns:
+//
+// An error if an error is injected
+func (h *Handler) SetAngleRelativeToCenter(relativeAngle int16) tinygoerrors.ErrorCode {
+	absoluteAngle := int32(h.centerAngle) + int32(relativeAngle)
+	absoluteAngle = max(absoluteAngle, int32(h.leftLimitAngle))
+	absoluteAngle = min(absoluteAngle, int32(h.rightLimitAngle))
+	return h.SetAngle(uint16(absoluteAngle))
+}
+
+// IsAngleCentered checks if the angle is the center one
+//
+// Returns:
+//
+// True if the servo is centered, false otherwise
+func (h *Handler) IsAngleCentered() bool {
+	return h.angle == uint32(h.centerAngle)*1000
+}
+
+// SetAngleToCenter sets the center angle
+//
+// Returns:
+//
+// An error if an error is injected
+func (h *Handler) SetAngleToCenter() tinygoerrors.ErrorCode {
+	return h.SetAngle(h.centerAngle)
+}
+
+// SetAngleToRight sets the angle to the right of the center, clamped to the right limit
+//
+// Parameters:
+//
+// angle: The angle from the center in degrees
+//
+// Returns:
+//
+// An error if an error is injected
+func (h *Handler) SetAngleToRight(angle uint16) tinygoerrors.ErrorCode {
+	return h.SetAngleRelativeToCenter(int16(min(angle, h.actuationRange)))
+}
+
+// SetAngleToLeft sets the angle to the left of the center, clamped to the left limit
+//
+// Parameters:
+//
+// angle: The angle from the center in degrees
+//
+// Returns:
+//
+// An error if an error is injected
+func (h *Handler) SetAngleToLeft(angle uint16) tinygoerrors.ErrorCode {
+	return h.SetAngleRelativeToCenter(-int16(min(angle, h.actuationRange)))
+}
+
+// LeftLimit returns the left limit angle
+//
+// Returns:
+//
+// The left limit angle in degrees
+func (h *Handler) LeftLimit() uint16 {
+	return h.leftLimitAngle
+}
+
+// RightLimit returns the right limit angle
+//
+// Returns:
+//
+// The right limit angle in degrees
+func (h *Handler) RightLimit() uint16 {
+	return h.rightLimitAngle
+}
+
+// SetPulseMicroseconds sets the pulse width, and the angle it corresponds to
+//
+// Parameters:
+//
+// us: The pulse width in microseconds
+//
+// Returns:
+//
+// An error if the pulse width is out of range or an error is injected
+func (h *Handler) SetPulseMicroseconds(us uint32) tinygoerrors.ErrorCode {
+	pulse := uint64(us) * 1000
+	command := Command{Kind: CommandKindPulse, PulseWidth: uint32(pulse)}
+	if pulse < uint64(h.minPulseWidth) || pulse > uint64(h.maxPulseWidth) {
+		command.Err = ErrorCodeServoTestInvalidPulseWidth
+	} else {
+		span := uint64(h.maxPulseWidth - h.minPulseWidth)
+		command.AngleMilliDegrees = uint32((pulse - uint64(h.minPulseWidth)) * uint64(h.actuationRange) * 1000 / span)
+	}
+	if err := h.record(command); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.angle = command.AngleMilliDegrees
+	h.pulse = command.PulseWidth
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetPulseMicroseconds returns the pulse width of the angle
+//
+// Returns:
+//
+// The pulse width in microseconds, rounded to the nearest
+func (h *Handler) GetPulseMicroseconds() uint32 {
+	return (h.pulse + 500) / 1000
+}
+
+// SetMaxSpeed records a speed cap
+//
+// Parameters:
+//
+// degreesPerSecond: The speed in degrees per second
+//
+// Returns:
+//
+// An error if an error is injected
+func (h *Handler) SetMaxSpeed(degreesPerSecond uint16) tinygoerrors.ErrorCode {
+	err := h.record(Command{Kind: CommandKindMaxSpeed, MaxSpeed: degreesPerSecond})
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.maxSpeed = degreesPerSecond
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetMaxSpeed returns the last recorded speed cap
+//
+// Returns:
+//
+// The speed in degrees per second
+func (h *Handler) GetMaxSpeed() uint16 {
+	return h.maxSpeed
+}
+
+// SetTrim shifts the center angle by an offset from the untrimmed center, keeping the angle
+//
+// Parameters:
+//
+// offsetDegrees: The offset in degrees, negative to the left and positive to the right
+//
+// Returns:
+//
+// An error if the trimmed center is outside the limits or an error is injected
+func (h *Handler) SetTrim(offsetDegrees int16) tinygoerrors.ErrorCode {
+	command := Command{Kind: CommandKindTrim, Trim: offsetDegrees}
+	center := int32(h.centerAngle) - int32(h.trim) + int32(offsetDegrees)
+	if center < int32(h.leftLimitAngle) || center > int32(h.rightLimitAngle) {
+		command.Err = ErrorCodeServoTestAngleOutOfRange
+	}
+	if err := h.record(command); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.centerAngle = uint16(center)
+	h.trim = offsetDegrees
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetTrim returns the offset set by SetTrim
+//
+// Returns:
+//
+// The offset in degrees
+func (h *Handler) GetTrim() int16 {
+	return h.trim
+}
+
+// EnableMovement enables the movement
+func (h *Handler) EnableMovement() {
+	h.isMovementDisabled = false
+}
+
+// DisableMovement disables the movement, the commands are still recorded and applied
+func (h *Handler) DisableMovement() {
+	h.isMovementDisabled = true
+}
+
+// IsMovementEnabled checks if the movement is enabled
+//
+// Returns:
+//
+// True if the movement is enabled, false otherwise
+func (h *Handler) IsMovementEnabled() bool {
+	return !h.isMovementDisabled
+}
+
+// SetSleeping sets whether the servo is reported as sleeping
+//
+// Parameters:
+//
+// isSleeping: Whether the servo is sleeping
+func (h *Handler) SetSleeping(isSleeping bool) {
+	h.isSleeping = isSleeping
+}
+
+// IsSleeping checks if the servo is sleeping
+//
+// Returns:
+//
+// True if the servo is sleeping, false otherwise
+func (h *Handler) IsSleeping() bool {
+	return h.isSleeping
+}
+
+// Detach records that the signal line stopped being driven
+func (h *Handler) Detach() {
+	h.isDetached = true
+	h.record(Command{Kind: CommandKindDetach, AngleMilliDegrees: h.angle})
+}
+
+// Attach records that the signal line is driven again
+//
+// Returns:
+//
+// An error if an error is injected
+func (h *Handler) Attach() tinygoerrors.ErrorCode {
+	if err := h.record(Command{Kind: CommandKindAttach, AngleMilliDegrees: h.angle}); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.isDetached = false
+	return tinygoerrors.ErrorCodeNil
+}
+
+// IsDetached checks if the signal line is not driven
+//
+// Returns:
+//
+// True if the servo is detached, false otherwise
+func (h *Handler) IsDetached() bool {
+	return h.isDetached
+}
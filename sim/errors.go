@@ -0,0 +1,16 @@
+package sim
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeSimStartNumber is the starting number for simulator-related error codes.
+	ErrorCodeSimStartNumber uint16 = 5600
+)
+
+const (
+	ErrorCodeSimAngleOutOfRange tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeSimStartNumber)
+	ErrorCodeSimInvalidPercent
+	ErrorCodeSimZeroSpeed
+)
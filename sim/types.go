@@ -0,0 +1,252 @@
+package sim
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is a simulated servo for host tests of the application fault handling. It accepts the angle commands of
+	// the tinygo-servo handlers and moves its simulated horn towards them at a fixed speed from Update, reporting the
+	// horn angle through its feedback. Failures can be injected to make it stick at an angle, respond slowly, drop
+	// commands or report noisy feedback, all driven by a seeded generator so the tests are deterministic
+	Servo struct {
+		rightLimitMilliDegrees uint32
+		speed                  uint16
+		commandedMilliDegrees  uint32
+		hornMilliDegrees       uint32
+		hasTimestamp           bool
+		timestampMs            uint32
+		isStuck                bool
+		stuckMilliDegrees      uint32
+		slowPercent            uint8
+		dropPercent            uint8
+		noiseMilliDegrees      uint32
+		random                 uint32
+		commands               uint32
+		droppedCommands        uint32
+	}
+)
+
+// NewServo creates a new instance of Servo without failures, with its horn at an angle
+//
+// Parameters:
+//
+// actuationRange: The actuation range in degrees, the accepted angles are between 0 and it
+// speed: The speed of the horn in degrees per second
+// milliDegrees: The initial angle of the horn in millidegrees
+// seed: The seed of the generator of the dropped commands and the feedback noise
+//
+// Returns:
+//
+// An instance of Servo and an error if the speed is zero or the angle is out of range
+func NewServo(actuationRange uint16, speed uint16, milliDegrees uint32, seed uint32) (*Servo, tinygoerrors.ErrorCode) {
+	if speed == 0 {
+		return nil, ErrorCodeSimZeroSpeed
+	}
+	rightLimit := uint32(actuationRange) * 1000
+	if milliDegrees > rightLimit {
+		return nil, ErrorCodeSimAngleOutOfRange
+	}
+	if seed == 0 {
+		seed = 1
+	}
+	return &Servo{
+		rightLimitMilliDegrees: rightLimit,
+		speed:                  speed,
+		commandedMilliDegrees:  milliDegrees,
+		hornMilliDegrees:       milliDegrees,
+		random:                 seed,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SetAngleMilliDegrees commands the simulated servo, unless the command is dropped by an injected failure. Dropped
+// commands are not reported, like a command lost on a link
+//
+// Parameters:
+//
+// milliDegrees: The commanded angle in millidegrees
+//
+// Returns:
+//
+// An error if the angle is out of range
+func (s *Servo) SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	if milliDegrees > s.rightLimitMilliDegrees {
+		return ErrorCodeSimAngleOutOfRange
+	}
+	s.commands++
+	if s.dropPercent != 0 && s.roll() < uint32(s.dropPercent) {
+		s.droppedCommands++
+		return tinygoerrors.ErrorCodeNil
+	}
+	s.commandedMilliDegrees = milliDegrees
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetAngleMilliDegrees returns the last commanded angle that wasn't dropped
+//
+// Returns:
+//
+// The commanded angle in millidegrees
+func (s *Servo) GetAngleMilliDegrees() uint32 {
+	return s.commandedMilliDegrees
+}
+
+// Feedback returns the measured angle of the simulated horn, with the injected noise. It can be used as the feedback
+// function of the routines and joints
+//
+// Returns:
+//
+// The measured angle in millidegrees
+func (s *Servo) Feedback() uint32 {
+	if s.noiseMilliDegrees == 0 {
+		return s.hornMilliDegrees
+	}
+
+	// Add a noise uniformly distributed within the amplitude, without leaving the actuation range
+	s.random = nextRandom(s.random)
+	noise := int64(s.random%(2*s.noiseMilliDegrees+1)) - int64(s.noiseMilliDegrees)
+	measured := int64(s.hornMilliDegrees) + noise
+	if measured < 0 {
+		return 0
+	}
+	if measured > int64(s.rightLimitMilliDegrees) {
+		return s.rightLimitMilliDegrees
+	}
+	return uint32(measured)
+}
+
+// Update moves the simulated horn towards the commanded angle, it must be called periodically
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (s *Servo) Update(nowMs uint32) {
+	if !s.hasTimestamp {
+		s.hasTimestamp = true
+		s.timestampMs = nowMs
+		return
+	}
+
+	// Degrees per second times milliseconds are millidegrees, slowed down by the injected failure
+	step := uint64(s.speed) * uint64(nowMs-s.timestampMs)
+	if s.slowPercent != 0 {
+		step = step * uint64(100-s.slowPercent) / 100
+	}
+	if step == 0 {
+		return
+	}
+	s.timestampMs = nowMs
+
+	target := s.commandedMilliDegrees
+	if s.isStuck {
+		target = s.stuckMilliDegrees
+	}
+	if s.hornMilliDegrees < target {
+		s.hornMilliDegrees += uint32(min(step, uint64(target-s.hornMilliDegrees)))
+	} else if s.hornMilliDegrees > target {
+		s.hornMilliDegrees -= uint32(min(step, uint64(s.hornMilliDegrees-target)))
+	}
+}
+
+// StickAt makes the horn stop at an angle whatever it is commanded, like a stripped gear or a blocked linkage
+//
+// Parameters:
+//
+// milliDegrees: The angle the horn sticks at in millidegrees, it moves there first if needed
+//
+// Returns:
+//
+// An error if the angle is out of range
+func (s *Servo) StickAt(milliDegrees uint32) tinygoerrors.ErrorCode {
+	if milliDegrees > s.rightLimitMilliDegrees {
+		return ErrorCodeSimAngleOutOfRange
+	}
+	s.isStuck = true
+	s.stuckMilliDegrees = milliDegrees
+	return tinygoerrors.ErrorCodeNil
+}
+
+// StickHere makes the horn stop at its current angle whatever it is commanded
+func (s *Servo) StickHere() {
+	s.isStuck = true
+	s.stuckMilliDegrees = s.hornMilliDegrees
+}
+
+// SetSlowResponse slows down the horn, like a servo with a weak supply or a heavy load
+//
+// Parameters:
+//
+// percent: The percentage of speed lost, from 0 to 99
+//
+// Returns:
+//
+// An error if the percentage is not lower than 100
+func (s *Servo) SetSlowResponse(percent uint8) tinygoerrors.ErrorCode {
+	if percent >= 100 {
+		return ErrorCodeSimInvalidPercent
+	}
+	s.slowPercent = percent
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetDroppedCommands drops a share of the angle commands
+//
+// Parameters:
+//
+// percent: The percentage of commands dropped, from 0 to 100
+//
+// Returns:
+//
+// An error if the percentage is higher than 100
+func (s *Servo) SetDroppedCommands(percent uint8) tinygoerrors.ErrorCode {
+	if percent > 100 {
+		return ErrorCodeSimInvalidPercent
+	}
+	s.dropPercent = percent
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetFeedbackNoise adds a noise to the measured angle
+//
+// Parameters:
+//
+// amplitudeMilliDegrees: The largest deviation of the measured angle in millidegrees, zero to remove the noise
+func (s *Servo) SetFeedbackNoise(amplitudeMilliDegrees uint32) {
+	s.noiseMilliDegrees = amplitudeMilliDegrees
+}
+
+// ClearFailures removes every injected failure, the horn moves towards the commanded angle again
+func (s *Servo) ClearFailures() {
+	s.isStuck = false
+	s.slowPercent = 0
+	s.dropPercent = 0
+	s.noiseMilliDegrees = 0
+}
+
+// Commands returns the number of angle commands received, including the dropped ones
+//
+// Returns:
+//
+// The number of commands
+func (s *Servo) Commands() uint32 {
+	return s.commands
+}
+
+// DroppedCommands returns the number of angle commands dropped by the injected failure
+//
+// Returns:
+//
+// The number of dropped commands
+func (s *Servo) DroppedCommands() uint32 {
+	return s.droppedCommands
+}
+
+// roll draws a pseudo-random percentage
+//
+// Returns:
+//
+// A number from 0 to 99
+func (s *Servo) roll() uint32 {
+	s.random = nextRandom(s.random)
+	return s.random % 100
+}
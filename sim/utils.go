@@ -0,0 +1,17 @@
+package sim
+
+// nextRandom advances a xorshift32 pseudo-random generator
+//
+// Parameters:
+//
+// state: The current generator state, it must not be zero
+//
+// Returns:
+//
+// The next generator state
+func nextRandom(state uint32) uint32 {
+	state ^= state << 13
+	state ^= state >> 17
+	state ^= state << 5
+	return state
+}
@@ -0,0 +1,118 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetLimitSlowZone slows the moves heading towards a limit once they are within a distance of it, whatever the speed
+// cap or the timed, profiled, sweeping or replayed move commanding them, reducing the impact loads of heavy
+// mechanisms approaching their mechanical stops. The moves leaving a limit are not slowed. Pulse width commands and
+// SetAngleImmediate are not slowed either
+//
+// Parameters:
+//
+// widthDegrees: The distance from each limit the zone spans, in degrees
+// degreesPerSecond: The maximum speed within the zone in degrees per second, or zero to remove the zone
+//
+// Returns:
+//
+// An error if the speed is set but the zone width is zero or wider than the actuation range
+func (h *DefaultHandler) SetLimitSlowZone(widthDegrees uint16, degreesPerSecond uint16) tinygoerrors.ErrorCode {
+	if degreesPerSecond != 0 && (widthDegrees == 0 || widthDegrees > h.actuationRange) {
+		return h.reportError(ErrorCodeServoInvalidSlowZone)
+	}
+	h.slowZoneWidth = uint32(widthDegrees) * 1000
+	h.slowZoneSpeed = degreesPerSecond
+	if degreesPerSecond == 0 {
+		h.slowZoneWidth = 0
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetLimitSlowZone returns the limit slow zone set by SetLimitSlowZone
+//
+// Returns:
+//
+// The zone width in degrees and its maximum speed in degrees per second, zero if there is no zone
+func (h *DefaultHandler) GetLimitSlowZone() (uint16, uint16) {
+	return uint16(h.slowZoneWidth / 1000), h.slowZoneSpeed
+}
+
+// slowZoneEdge returns the edge of the slow zone of the limit a move heads towards
+//
+// Parameters:
+//
+// current: The current angle in millidegrees
+// target: The target angle in millidegrees
+//
+// Returns:
+//
+// The angle in millidegrees where the zone starts, and true if the move heads towards a limit with a slow zone
+func (h *DefaultHandler) slowZoneEdge(current uint32, target uint32) (uint32, bool) {
+	if h.slowZoneSpeed == 0 || current == target {
+		return 0, false
+	}
+	if target > current {
+		rightLimit := uint32(h.RightLimit()) * 1000
+		return rightLimit - min(h.slowZoneWidth, rightLimit), true
+	}
+	return uint32(h.LeftLimit())*1000 + h.slowZoneWidth, true
+}
+
+// entersSlowZone checks if a move reaches into the slow zone of the limit it heads towards
+//
+// Parameters:
+//
+// current: The current angle in millidegrees
+// target: The target angle in millidegrees
+//
+// Returns:
+//
+// True if the target is within the slow zone of the limit the move heads towards, false otherwise
+func (h *DefaultHandler) entersSlowZone(current uint32, target uint32) bool {
+	edge, ok := h.slowZoneEdge(current, target)
+	if !ok {
+		return false
+	}
+	if target > current {
+		return target > edge
+	}
+	return target < edge
+}
+
+// limitSlowZoneStep limits a step of a move by the slow zone of the limit it heads towards
+//
+// Parameters:
+//
+// current: The current angle in millidegrees
+// target: The target angle of the step in millidegrees
+// speed: The speed of the move in degrees per second, zero if uncapped
+//
+// Returns:
+//
+// The target of the step, stopped at the edge of the zone if the move is still outside of it, and the speed of the
+// step, capped if the move is within the zone
+func (h *DefaultHandler) limitSlowZoneStep(current uint32, target uint32, speed uint16) (uint32, uint16) {
+	edge, ok := h.slowZoneEdge(current, target)
+	if !ok {
+		return target, speed
+	}
+
+	// Check if the move is within the zone, slowing it down
+	isInside := current >= edge
+	if target < current {
+		isInside = current <= edge
+	}
+	if isInside {
+		if speed == 0 || h.slowZoneSpeed < speed {
+			speed = h.slowZoneSpeed
+		}
+		return target, speed
+	}
+
+	// Stop at the edge of the zone, the next steps are slowed down
+	if (target > current && target > edge) || (target < current && target < edge) {
+		target = edge
+	}
+	return target, speed
+}
@@ -0,0 +1,50 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestLimitSlowZoneSlowsProfiledMoves checks the trapezoidal and S-curve moves heading towards a limit cross its slow
+// zone at the speed of the zone, and still reach their target
+func TestLimitSlowZoneSlowsProfiledMoves(t *testing.T) {
+	for _, jerk := range []float32{0, 2000} {
+		handler, err := NewOutputHandler(servotest.NewOutput())
+		if err != 0 {
+			t.Fatalf("NewOutputHandler: %d", err)
+		}
+		releaseAll(t, handler)
+		if err = handler.SetLimitSlowZone(20, 10); err != 0 {
+			t.Fatalf("SetLimitSlowZone: %d", err)
+		}
+		if err = handler.SetMotionProfile(180, 360); err != 0 {
+			t.Fatalf("SetMotionProfile: %d", err)
+		}
+		if err = handler.SetMotionProfileJerk(jerk); err != 0 {
+			t.Fatalf("SetMotionProfileJerk: %d", err)
+		}
+		if err = handler.MoveToProfiled(180); err != 0 {
+			t.Fatalf("MoveToProfiled: %d", err)
+		}
+
+		// The zone starts at 160 degrees, 10 degrees per second are 10 millidegrees per millisecond
+		previous := handler.GetAngleMilliDegrees()
+		for nowMs := uint32(1); nowMs <= 5000; nowMs++ {
+			handler.Update(nowMs)
+			angle := handler.GetAngleMilliDegrees()
+			if previous >= 160000 && angle > previous+10 {
+				t.Fatalf("jerk %v: stepped from %d to %d millidegrees in 1ms within the zone", jerk, previous, angle)
+			}
+			if previous < 160000 && angle > 160000 {
+				t.Fatalf("jerk %v: stepped from %d to %d millidegrees over the edge of the zone", jerk, previous, angle)
+			}
+			previous = angle
+		}
+		if previous != 180000 {
+			t.Errorf("jerk %v: angle after 5s = %d millidegrees, want 180000", jerk, previous)
+		}
+	}
+}
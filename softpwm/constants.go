@@ -0,0 +1,9 @@
+package softpwm
+
+const (
+	// MaxChannels is the number of pins a PWM can drive
+	MaxChannels = 8
+
+	// MinPeriodUs is the shortest period of the frames, in microseconds
+	MinPeriodUs = 1000
+)
@@ -0,0 +1,15 @@
+package softpwm
+
+import (
+	"errors"
+)
+
+var (
+	// ErrTooManyChannels is returned by Channel once every channel is taken, it is a Go error as required by the
+	// tinygo-pwm PWM interface
+	ErrTooManyChannels = errors.New("softpwm: too many channels")
+
+	// ErrInvalidPeriod is returned by Configure for the periods shorter than MinPeriodUs, or different from the
+	// period the PWM is already running at, since all the channels share the frame
+	ErrInvalidPeriod = errors.New("softpwm: invalid period")
+)
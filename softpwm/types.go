@@ -0,0 +1,204 @@
+//go:build tinygo
+
+package softpwm
+
+import (
+	"machine"
+	"time"
+)
+
+type (
+	// PWM generates servo pulses on GPIO pins without hardware PWM, implementing the tinygo-pwm PWM interface so the
+	// handlers of the package drive it like the PWM peripherals of the MCU. The duty cycles are in microseconds, Top
+	// being the period.
+	//
+	// Every frame raises the pins at once and lowers each of them at the end of its pulse. The frames are driven
+	// either by a timer interrupt, calling StartFrame every period and Service at the returned edge times, or by
+	// RunFrame, which busy-waits through the pulses. The duty cycles set during a frame are latched at the next one,
+	// so Set can be called from the main loop while the interrupt runs the frames.
+	//
+	// The jitter of the pulses is the latency of the timer interrupt, or the duration of a busy loop iteration plus
+	// the interrupts taken during it with RunFrame, typically a few microseconds on a Cortex-M0+. With a 500us to
+	// 2500us servo over 180 degrees, every microsecond of jitter is 0.09 degrees
+	PWM struct {
+		pins      [MaxChannels]machine.Pin
+		duties    [MaxChannels]uint32
+		latched   [MaxChannels]uint32
+		order     [MaxChannels]uint8
+		count     int
+		periodUs  uint32
+		nextEdge  int
+		isRunning bool
+	}
+)
+
+// NewPWM creates a new instance of PWM
+//
+// Returns:
+//
+// An instance of PWM with no channels
+func NewPWM() *PWM {
+	return &PWM{}
+}
+
+// Configure sets the period of the frames. All the channels share the frame, so configuring it again with the same
+// period, as every handler does, is allowed
+//
+// Parameters:
+//
+// config: The PWM configuration, only the period is used
+//
+// Returns:
+//
+// ErrInvalidPeriod if the period is too short or different from the configured one
+func (p *PWM) Configure(config machine.PWMConfig) error {
+	periodUs := config.Period / 1000
+	if periodUs < MinPeriodUs || periodUs > uint64(^uint32(0)) {
+		return ErrInvalidPeriod
+	}
+	if p.periodUs != 0 && uint32(periodUs) != p.periodUs {
+		return ErrInvalidPeriod
+	}
+	p.periodUs = uint32(periodUs)
+	return nil
+}
+
+// Channel returns the channel of a pin, configuring the pin as an output the first time
+//
+// Parameters:
+//
+// pin: The GPIO pin
+//
+// Returns:
+//
+// The channel and ErrTooManyChannels if every channel is taken
+func (p *PWM) Channel(pin machine.Pin) (uint8, error) {
+	for index := 0; index < p.count; index++ {
+		if p.pins[index] == pin {
+			return uint8(index), nil
+		}
+	}
+	if p.count == MaxChannels {
+		return 0, ErrTooManyChannels
+	}
+	pin.Configure(machine.PinConfig{Mode: machine.PinOutput})
+	pin.Low()
+	p.pins[p.count] = pin
+	p.count++
+	return uint8(p.count - 1), nil
+}
+
+// Top returns the value of a full duty cycle
+//
+// Returns:
+//
+// The period in microseconds
+func (p *PWM) Top() uint32 {
+	return p.periodUs
+}
+
+// Set sets the duty cycle of a channel, latched at the next frame
+//
+// Parameters:
+//
+// channel: The channel
+// value: The pulse width in microseconds, from 0 to Top
+func (p *PWM) Set(channel uint8, value uint32) {
+	if int(channel) >= p.count {
+		return
+	}
+	p.duties[channel] = min(value, p.periodUs)
+}
+
+// StartFrame latches the duty cycles and raises the pins of the frame, it must be called every period
+//
+// Returns:
+//
+// The time of the first falling edge since the start of the frame in microseconds, and false if there is none
+func (p *PWM) StartFrame() (uint32, bool) {
+	// Latch the duty cycles and sort the channels by pulse width with an insertion sort, there are few of them
+	for index := 0; index < p.count; index++ {
+		p.latched[index] = p.duties[index]
+		position := index
+		for position > 0 && p.latched[p.order[position-1]] > p.latched[index] {
+			p.order[position] = p.order[position-1]
+			position--
+		}
+		p.order[position] = uint8(index)
+	}
+
+	// Raise the pins with a pulse, the full duty cycles stay high
+	p.nextEdge = 0
+	for p.nextEdge < p.count && p.latched[p.order[p.nextEdge]] == 0 {
+		p.pins[p.order[p.nextEdge]].Low()
+		p.nextEdge++
+	}
+	for index := p.nextEdge; index < p.count; index++ {
+		p.pins[p.order[index]].High()
+	}
+	p.isRunning = true
+	return p.nextEdgeUs()
+}
+
+// Service lowers the pins whose pulse ended, it must be called at the edge times returned by StartFrame and Service
+//
+// Parameters:
+//
+// elapsedUs: The time since the start of the frame in microseconds
+//
+// Returns:
+//
+// The time of the next falling edge since the start of the frame in microseconds, and false if there is none
+func (p *PWM) Service(elapsedUs uint32) (uint32, bool) {
+	for p.nextEdge < p.count {
+		channel := p.order[p.nextEdge]
+		if p.latched[channel] > elapsedUs {
+			break
+		}
+		if p.latched[channel] < p.periodUs {
+			p.pins[channel].Low()
+		}
+		p.nextEdge++
+	}
+	return p.nextEdgeUs()
+}
+
+// nextEdgeUs returns the time of the next falling edge of the frame
+//
+// Returns:
+//
+// The time of the next falling edge since the start of the frame in microseconds, and false if there is none
+func (p *PWM) nextEdgeUs() (uint32, bool) {
+	for p.nextEdge < p.count {
+		duty := p.latched[p.order[p.nextEdge]]
+		if duty < p.periodUs {
+			return duty, true
+		}
+		p.nextEdge++
+	}
+	p.isRunning = false
+	return 0, false
+}
+
+// RunFrame runs a frame busy-waiting through the pulses, it blocks until the longest pulse ends, up to its duty cycle.
+// It must be called every period, e.g. from the main loop
+func (p *PWM) RunFrame() {
+	start := time.Now()
+	edgeUs, ok := p.StartFrame()
+	for ok {
+		elapsedUs := uint32(time.Since(start).Microseconds())
+		if elapsedUs < edgeUs {
+			continue
+		}
+		edgeUs, ok = p.Service(elapsedUs)
+	}
+}
+
+// IsFrameRunning checks if the pulses of the current frame are still being generated
+//
+// Returns:
+//
+// True if a pin still has to be lowered, false otherwise
+func (p *PWM) IsFrameRunning() bool {
+	return p.isRunning
+}
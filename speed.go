@@ -0,0 +1,153 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetMaxSpeed caps the speed of the servo motor, so the angle commands ramp towards their target from Update instead
+// of jumping to it, avoiding the current spikes and mechanical shock of instant jumps on bigger servos. The global
+// speed cap, if lower, takes precedence
+//
+// Parameters:
+//
+// degreesPerSecond: The maximum speed in degrees per second, or zero to jump to the commanded angles
+//
+// Returns:
+//
+// An error code, always nil, so the handler can be commanded through the SpeedServo interfaces of the sub-packages
+func (h *DefaultHandler) SetMaxSpeed(degreesPerSecond uint16) tinygoerrors.ErrorCode {
+	h.maxSpeed = degreesPerSecond
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetMaxSpeed returns the maximum speed of the servo motor set by SetMaxSpeed
+//
+// Returns:
+//
+// The maximum speed in degrees per second, zero if uncapped
+func (h *DefaultHandler) GetMaxSpeed() uint16 {
+	return h.maxSpeed
+}
+
+// effectiveMaxSpeed returns the speed the moves ramp at, the lowest of the handler and global speed caps
+//
+// Returns:
+//
+// The speed in degrees per second, zero if uncapped
+func (h *DefaultHandler) effectiveMaxSpeed() uint16 {
+	if h.maxSpeed == 0 || (globalMaxSpeed != 0 && globalMaxSpeed < h.maxSpeed) {
+		return globalMaxSpeed
+	}
+	return h.maxSpeed
+}
+
+// IsMoving checks if the servo motor is ramping towards a commanded angle
+//
+// Returns:
+//
+// True if a speed-limited, timed, profiled, sweeping or replayed move is in progress, false otherwise
+func (h *DefaultHandler) IsMoving() bool {
+	return h.hasSpeedTarget || h.hasTimedMove || h.hasProfiledMove || h.hasSweep || h.isReplaying
+}
+
+// GetTargetAngleMilliDegrees returns the angle the servo motor is moving towards
+//
+// Returns:
+//
+// The target angle in millidegrees, or the current angle if no move is in progress
+func (h *DefaultHandler) GetTargetAngleMilliDegrees() uint32 {
+	if h.hasTimedMove {
+		return h.moveTo
+	}
+	if h.hasProfiledMove {
+		return h.profileTarget
+	}
+	if h.hasSpeedTarget {
+		return h.speedTarget
+	}
+	return h.GetAngleMilliDegrees()
+}
+
+// StopMove stops the speed-limited, timed, profiled, sweeping or replayed move in progress, holding the current angle
+func (h *DefaultHandler) StopMove() {
+	h.hasSpeedTarget = false
+	h.hasTimedMove = false
+	h.hasProfiledMove = false
+	h.hasSweep = false
+	h.isReplaying = false
+}
+
+// startSpeedLimitedMove validates an angle command and records it as the target of a speed-limited move
+//
+// Parameters:
+//
+// milliDegrees: The target angle in millidegrees, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the angle is out of range, the command is discarded or the servo motor could not be initialized
+func (h *DefaultHandler) startSpeedLimitedMove(milliDegrees uint32) tinygoerrors.ErrorCode {
+	// Check if the angle is within the valid range
+	if milliDegrees < uint32(h.LeftLimit())*1000 || milliDegrees > uint32(h.RightLimit())*1000 {
+		return h.reportError(ErrorCodeServoAngleOutOfRange)
+	}
+
+	// Configure the PWM on the first command if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the command must be discarded because movement is disabled
+	if !h.IsMovementEnabled() && h.disabledCommandPolicy == DisabledCommandPolicyDiscard {
+		return h.reportError(ErrorCodeServoMovementDisabled)
+	}
+
+	// Start ramping from the last update, unless a move is already in progress
+	if milliDegrees == h.GetAngleMilliDegrees() {
+		h.hasSpeedTarget = false
+		h.handleRepeatedCommand()
+		return tinygoerrors.ErrorCodeNil
+	}
+	if !h.hasSpeedTarget {
+		h.speedTimestampMs = h.lastUpdateMs
+	}
+	h.speedTarget = milliDegrees
+	h.hasSpeedTarget = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// updateSpeed advances the speed-limited move in progress
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateSpeed(nowMs uint32) {
+	if !h.hasSpeedTarget {
+		return
+	}
+
+	// Jump to the target if the speed cap was removed during the move, stopping at the slow zone of the limit the move
+	// heads towards if it is not within it yet
+	current := h.GetAngleMilliDegrees()
+	target, speed := h.limitSlowZoneStep(current, h.speedTarget, h.effectiveMaxSpeed())
+	if speed == 0 {
+		h.speedTimestampMs = nowMs
+	} else {
+		// Degrees per second times milliseconds are millidegrees, wait until the servo can move at least one
+		step := uint64(speed) * uint64(nowMs-h.speedTimestampMs)
+		if step == 0 {
+			return
+		}
+		h.speedTimestampMs = nowMs
+
+		if current < target && uint64(target-current) > step {
+			target = current + uint32(step)
+		} else if current > target && uint64(current-target) > step {
+			target = current - uint32(step)
+		}
+	}
+	if target == h.speedTarget {
+		h.hasSpeedTarget = false
+	}
+	_ = h.setAngleWithPulse(target, h.calculatePulseMilliDegrees(target))
+}
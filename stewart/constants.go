@@ -0,0 +1,6 @@
+package stewart
+
+const (
+	// LegsCount is the number of legs of a Stewart platform
+	LegsCount = 6
+)
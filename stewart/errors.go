@@ -0,0 +1,17 @@
+package stewart
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeStewartStartNumber is the starting number for Stewart platform related error codes.
+	ErrorCodeStewartStartNumber uint16 = 5640
+)
+
+const (
+	ErrorCodeStewartNilServo tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeStewartStartNumber)
+	ErrorCodeStewartInvalidGeometry
+	ErrorCodeStewartUnreachable
+	ErrorCodeStewartAngleOutOfRange
+)
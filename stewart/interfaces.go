@@ -0,0 +1,15 @@
+package stewart
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Servo is the subset of the tinygo-servo DefaultHandler methods used by the Stewart platforms, so the pose solver
+	// can be checked against fake actuators
+	Servo interface {
+		SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode
+		LeftLimit() uint16
+		RightLimit() uint16
+	}
+)
@@ -0,0 +1,223 @@
+package stewart
+
+import (
+	"math"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Leg holds the placement of a leg of a Stewart platform, the angles are in degrees around the vertical axis,
+	// measured counterclockwise from the positive X axis seen from above
+	Leg struct {
+		// BaseAngle is the angle of the pivot of the servo horn on the base circle
+		BaseAngle float32
+
+		// PlatformAngle is the angle of the joint of the rod on the platform circle
+		PlatformAngle float32
+
+		// HornAngle is the direction the servo horn points to when it is horizontal
+		HornAngle float32
+
+		// IsInverted is true for the servos mounted mirrored, whose angle decreases as the horn swings up
+		IsInverted bool
+	}
+
+	// Geometry holds the dimensions of a rotary Stewart platform, in any length unit as long as it is the same for
+	// every dimension and the poses. The origin is the center of the base circle, with the Z axis pointing up
+	Geometry struct {
+		// BaseRadius is the radius of the circle of the servo horn pivots
+		BaseRadius float32
+
+		// PlatformRadius is the radius of the circle of the rod joints on the platform
+		PlatformRadius float32
+
+		// HornLength is the distance between the pivot and the rod joint of the servo horns
+		HornLength float32
+
+		// RodLength is the length of the rods linking the servo horns to the platform
+		RodLength float32
+
+		// HorizontalAngle is the servo angle in degrees at which the horns are horizontal
+		HorizontalAngle float32
+
+		// Legs is the placement of every leg, they must be symmetric so they share the same home height
+		Legs [LegsCount]Leg
+	}
+
+	// Pose is the position and orientation of the platform. The position is relative to the home pose, where every
+	// horn is horizontal, and the angles are in degrees, applied as roll around X, then pitch around Y, then yaw
+	// around Z
+	Pose struct {
+		X     float32
+		Y     float32
+		Z     float32
+		Roll  float32
+		Pitch float32
+		Yaw   float32
+	}
+
+	// Platform drives a 6-servo rotary Stewart platform, solving the servo horn angles of platform poses
+	Platform struct {
+		servos     [LegsCount]Servo
+		geometry   Geometry
+		base       [LegsCount][3]float64
+		platform   [LegsCount][3]float64
+		homeHeight float64
+	}
+)
+
+// NewPlatform creates a new instance of Platform
+//
+// Parameters:
+//
+// servos: The servos of the legs, in the same order as the legs of the geometry
+// geometry: The dimensions of the platform
+//
+// Returns:
+//
+// An instance of Platform and an error if any servo is nil, a dimension is not positive or the rods are too short to
+// reach the platform with the horns horizontal
+func NewPlatform(servos [LegsCount]Servo, geometry Geometry) (*Platform, tinygoerrors.ErrorCode) {
+	for _, servo := range servos {
+		if servo == nil {
+			return nil, ErrorCodeStewartNilServo
+		}
+	}
+	if geometry.BaseRadius <= 0 || geometry.PlatformRadius <= 0 || geometry.HornLength <= 0 ||
+		geometry.RodLength <= 0 {
+		return nil, ErrorCodeStewartInvalidGeometry
+	}
+
+	// Place the joints, the home height is the one that makes the first rod fit with its horn horizontal
+	p := &Platform{
+		servos:   servos,
+		geometry: geometry,
+	}
+	for index, leg := range geometry.Legs {
+		baseSin, baseCos := math.Sincos(float64(leg.BaseAngle) * math.Pi / 180)
+		platformSin, platformCos := math.Sincos(float64(leg.PlatformAngle) * math.Pi / 180)
+		p.base[index] = [3]float64{float64(geometry.BaseRadius) * baseCos, float64(geometry.BaseRadius) * baseSin, 0}
+		p.platform[index] = [3]float64{
+			float64(geometry.PlatformRadius) * platformCos,
+			float64(geometry.PlatformRadius) * platformSin,
+			0,
+		}
+	}
+	hornSin, hornCos := math.Sincos(float64(geometry.Legs[0].HornAngle) * math.Pi / 180)
+	hornX := p.base[0][0] + float64(geometry.HornLength)*hornCos
+	hornY := p.base[0][1] + float64(geometry.HornLength)*hornSin
+	dx := p.platform[0][0] - hornX
+	dy := p.platform[0][1] - hornY
+	squaredHeight := float64(geometry.RodLength)*float64(geometry.RodLength) - dx*dx - dy*dy
+	if squaredHeight <= 0 {
+		return nil, ErrorCodeStewartInvalidGeometry
+	}
+	p.homeHeight = math.Sqrt(squaredHeight)
+	return p, tinygoerrors.ErrorCodeNil
+}
+
+// HomeHeight returns the height of the platform over the base at the home pose
+//
+// Returns:
+//
+// The home height, in the length unit of the geometry
+func (p *Platform) HomeHeight() float32 {
+	return float32(p.homeHeight)
+}
+
+// Solve solves the servo angles of a platform pose without moving the servos
+//
+// Parameters:
+//
+// pose: The pose of the platform
+//
+// Returns:
+//
+// The servo angles in millidegrees, and an error if a leg can't reach the pose or a servo angle is out of the limits of
+// its servo
+func (p *Platform) Solve(pose Pose) ([LegsCount]uint32, tinygoerrors.ErrorCode) {
+	var angles [LegsCount]uint32
+
+	// Build the rotation matrix of the platform, roll around X, then pitch around Y, then yaw around Z
+	rollSin, rollCos := math.Sincos(float64(pose.Roll) * math.Pi / 180)
+	pitchSin, pitchCos := math.Sincos(float64(pose.Pitch) * math.Pi / 180)
+	yawSin, yawCos := math.Sincos(float64(pose.Yaw) * math.Pi / 180)
+	rotation := [3][3]float64{
+		{
+			yawCos * pitchCos,
+			yawCos*pitchSin*rollSin - yawSin*rollCos,
+			yawCos*pitchSin*rollCos + yawSin*rollSin,
+		},
+		{
+			yawSin * pitchCos,
+			yawSin*pitchSin*rollSin + yawCos*rollCos,
+			yawSin*pitchSin*rollCos - yawCos*rollSin,
+		},
+		{-pitchSin, pitchCos * rollSin, pitchCos * rollCos},
+	}
+	translation := [3]float64{float64(pose.X), float64(pose.Y), float64(pose.Z) + p.homeHeight}
+
+	hornLength := float64(p.geometry.HornLength)
+	rodLength := float64(p.geometry.RodLength)
+	for index, leg := range p.geometry.Legs {
+		// Find the vector from the horn pivot to the rod joint of the platform
+		var vector [3]float64
+		for axis := range vector {
+			vector[axis] = translation[axis] - p.base[index][axis]
+			for column := range vector {
+				vector[axis] += rotation[axis][column] * p.platform[index][column]
+			}
+		}
+
+		// The horn tip and the platform joint must be a rod length apart, which reduces to
+		// l = m*sin(angle) + n*cos(angle)
+		hornSin, hornCos := math.Sincos(float64(leg.HornAngle) * math.Pi / 180)
+		squaredLength := vector[0]*vector[0] + vector[1]*vector[1] + vector[2]*vector[2]
+		l := squaredLength - (rodLength*rodLength - hornLength*hornLength)
+		m := 2 * hornLength * vector[2]
+		n := 2 * hornLength * (hornCos*vector[0] + hornSin*vector[1])
+		norm := math.Sqrt(m*m + n*n)
+		if norm == 0 || math.Abs(l) > norm {
+			return angles, ErrorCodeStewartUnreachable
+		}
+		degrees := (math.Asin(l/norm) - math.Atan2(n, m)) * 180 / math.Pi
+
+		// Map the horn angle to the servo angle and check it against the servo limits
+		if leg.IsInverted {
+			degrees = -degrees
+		}
+		milliDegrees := math.Round((float64(p.geometry.HorizontalAngle) + degrees) * 1000)
+		servo := p.servos[index]
+		if milliDegrees < float64(servo.LeftLimit())*1000 || milliDegrees > float64(servo.RightLimit())*1000 {
+			return angles, ErrorCodeStewartAngleOutOfRange
+		}
+		angles[index] = uint32(milliDegrees)
+	}
+	return angles, tinygoerrors.ErrorCodeNil
+}
+
+// MoveTo moves the platform to a pose
+//
+// Parameters:
+//
+// pose: The pose of the platform
+//
+// Returns:
+//
+// An error if the pose can't be solved, in which case no servo is moved, or the first error returned by the servos
+func (p *Platform) MoveTo(pose Pose) tinygoerrors.ErrorCode {
+	angles, err := p.Solve(pose)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	firstErr := tinygoerrors.ErrorCodeNil
+	for index, servo := range p.servos {
+		err = servo.SetAngleMilliDegrees(angles[index])
+		if err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"time"
+)
+
+const (
+	// ErasedByte is the value of the bytes of an erased slot
+	ErasedByte byte = 0xFF
+
+	// MaxWriteBlockSize is the largest write block of the flash memories a Flash store can be kept in
+	MaxWriteBlockSize = 256
+
+	// MaxEEPROMPageSize is the largest page of the EEPROM memories, 256 bytes for the biggest AT24Cxx parts
+	MaxEEPROMPageSize = 256
+
+	// EEPROMWriteCycleTime is the time an AT24Cxx EEPROM takes to program a page, during which it ignores the bus
+	EEPROMWriteCycleTime = 5 * time.Millisecond
+
+	// RecordHeaderSize is the size of the header of a record: magic byte, version and payload length
+	RecordHeaderSize = 4
+
+	// RecordOverheadSize is the number of bytes of a record besides its payload: header and CRC-16
+	RecordOverheadSize = RecordHeaderSize + 2
+)
+
+const (
+	// recordMagic is the first byte of every record, so erased and foreign slots are told apart from corrupted ones
+	recordMagic byte = 0x5A
+
+	// eepromSingleAddressSize is the size of the biggest AT24Cxx parts addressed with a single byte, the 24C16, the
+	// upper address bits go in the device address
+	eepromSingleAddressSize = 2048
+
+	// framTwoByteAddressSize is the size of the biggest FRAM parts addressed with two bytes
+	framTwoByteAddressSize = 65536
+
+	// FRAM opcodes
+	framOpcodeWriteEnable = 0x06
+	framOpcodeWrite       = 0x02
+	framOpcodeRead        = 0x03
+
+	// framEraseChunkSize is the number of erased bytes sent per transfer when erasing a FRAM slot
+	framEraseChunkSize = 32
+)
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"time"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// EEPROMDefaultAddress is the I2C address of an AT24Cxx EEPROM with all the address pins low
+	EEPROMDefaultAddress uint16 = 0x50
+
+	// EEPROMMaxAddressOffset is the highest offset set with the three address pins, added to EEPROMDefaultAddress
+	EEPROMMaxAddressOffset = 0x07
+)
+
+type (
+	// EEPROM is a Store kept in an AT24Cxx I2C EEPROM. The writes are split at the page boundaries of the memory and
+	// wait for its write cycle, so the slots can have any size
+	EEPROM struct {
+		bus          I2C
+		address      uint16
+		size         int
+		pageSize     int
+		slotSize     int
+		slotsCount   int
+		isSingleByte bool
+		buffer       [2 + MaxEEPROMPageSize]byte
+	}
+)
+
+// NewEEPROM creates a new instance of EEPROM
+//
+// Parameters:
+//
+// bus: The I2C bus the memory is connected to
+// addressOffset: The offset set with the address pins of the memory, from 0 to EEPROMMaxAddressOffset. The parts up
+// to the 24C16 use the address bits above the first byte as block bits, so the offset must leave room for them
+// size: The size of the memory in bytes, e.g. 4096 for a 24C32
+// pageSize: The size of the write pages in bytes, e.g. 32 for a 24C32
+// slotSize: The size of the slots in bytes, the memory is split in as many slots as fit in it
+//
+// Returns:
+//
+// An instance of EEPROM and an error if the bus is nil or any size or the offset is invalid
+func NewEEPROM(bus I2C, addressOffset uint8, size int, pageSize int, slotSize int) (*EEPROM, tinygoerrors.ErrorCode) {
+	if bus == nil {
+		return nil, ErrorCodeStoreNilDevice
+	}
+	if pageSize <= 0 || pageSize > MaxEEPROMPageSize || size < pageSize || size%pageSize != 0 {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	if slotSize <= 0 || slotSize > size {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	isSingleByte := size <= eepromSingleAddressSize
+	maxAddressOffset := EEPROMMaxAddressOffset
+	if isSingleByte {
+		maxAddressOffset -= (size - 1) >> 8
+	}
+	if int(addressOffset) > maxAddressOffset {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	return &EEPROM{
+		bus:          bus,
+		address:      EEPROMDefaultAddress + uint16(addressOffset),
+		size:         size,
+		pageSize:     pageSize,
+		slotSize:     slotSize,
+		slotsCount:   size / slotSize,
+		isSingleByte: isSingleByte,
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SlotSize returns the size of the slots
+//
+// Returns:
+//
+// The size of the slots in bytes
+func (e *EEPROM) SlotSize() int {
+	return e.slotSize
+}
+
+// SlotsCount returns the number of slots
+//
+// Returns:
+//
+// The number of slots
+func (e *EEPROM) SlotsCount() int {
+	return e.slotsCount
+}
+
+// addressHeader writes the memory address into the buffer and returns the I2C address it is sent to
+//
+// Parameters:
+//
+// offset: The memory address
+//
+// Returns:
+//
+// The I2C address and the number of address bytes written into the buffer
+func (e *EEPROM) addressHeader(offset int) (uint16, int) {
+	if e.isSingleByte {
+		e.buffer[0] = byte(offset)
+		return e.address + uint16(offset>>8), 1
+	}
+	e.buffer[0] = byte(offset >> 8)
+	e.buffer[1] = byte(offset)
+	return e.address, 2
+}
+
+// Read reads the beginning of a slot
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The buffer filled with the first bytes of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range, the buffer is longer than the slot or the memory could not be read
+func (e *EEPROM) Read(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), e.slotSize, e.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	offset := slot * e.slotSize
+	for len(data) > 0 {
+		// The single byte parts wrap around within their 256 bytes blocks
+		length := len(data)
+		if e.isSingleByte {
+			length = min(length, 256-offset%256)
+		}
+		address, headerSize := e.addressHeader(offset)
+		if err := e.bus.Tx(address, e.buffer[:headerSize], data[:length]); err != nil {
+			return ErrorCodeStoreReadFailed
+		}
+		data = data[length:]
+		offset += length
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// write writes a range of the memory page by page, waiting for the write cycle of every page
+//
+// Parameters:
+//
+// offset: The memory address of the range
+// data: The data to write, or nil to write erased bytes
+// length: The length of the range
+//
+// Returns:
+//
+// An error if a page could not be written
+func (e *EEPROM) write(offset int, data []byte, length int) tinygoerrors.ErrorCode {
+	for length > 0 {
+		chunk := min(length, e.pageSize-offset%e.pageSize)
+		address, headerSize := e.addressHeader(offset)
+		payload := e.buffer[headerSize : headerSize+chunk]
+		if data != nil {
+			copy(payload, data[:chunk])
+			data = data[chunk:]
+		} else {
+			for index := range payload {
+				payload[index] = ErasedByte
+			}
+		}
+		if err := e.bus.Tx(address, e.buffer[:headerSize+chunk], nil); err != nil {
+			return ErrorCodeStoreWriteFailed
+		}
+		time.Sleep(EEPROMWriteCycleTime)
+		offset += chunk
+		length -= chunk
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Write writes the data at the beginning of a slot, the bytes after it are kept
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The data written at the beginning of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range, the data is longer than the slot or the memory could not be written
+func (e *EEPROM) Write(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), e.slotSize, e.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	return e.write(slot*e.slotSize, data, len(data))
+}
+
+// Erase writes erased bytes over a whole slot
+//
+// Parameters:
+//
+// slot: The slot index
+//
+// Returns:
+//
+// An error if the slot is out of range or the memory could not be written
+func (e *EEPROM) Erase(slot int) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, 0, e.slotSize, e.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if err := e.write(slot*e.slotSize, nil, e.slotSize); err != tinygoerrors.ErrorCodeNil {
+		return ErrorCodeStoreEraseFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
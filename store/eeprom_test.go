@@ -0,0 +1,96 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// TestEEPROMSplitsWritesAtPageBoundaries writes slots that start and end within the pages of the memory, and checks
+// no write rolled over the end of a page and the neighbouring slots are untouched
+func TestEEPROMSplitsWritesAtPageBoundaries(t *testing.T) {
+	tests := []struct {
+		name          string
+		addressOffset uint8
+		size          int
+		pageSize      int
+		slotSize      int
+	}{
+		{"single byte address", 0, 2048, 16, 40},
+		{"two byte address", 3, 8192, 32, 100},
+	}
+	for _, test := range tests {
+		device := newFakeEEPROM(test.size, test.pageSize, EEPROMDefaultAddress+uint16(test.addressOffset))
+		eeprom, err := NewEEPROM(device, test.addressOffset, test.size, test.pageSize, test.slotSize)
+		if err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("%s: NewEEPROM: %d", test.name, err)
+		}
+
+		// The slots beyond the first 256 bytes take the upper address bits of the single byte parts
+		for _, slot := range []int{1, 7} {
+			data := make([]byte, test.slotSize)
+			for index := range data {
+				data[index] = byte(index + slot)
+			}
+			if err = eeprom.Write(slot, data); err != tinygoerrors.ErrorCodeNil {
+				t.Fatalf("%s: write of slot %d: %d", test.name, slot, err)
+			}
+			read := make([]byte, test.slotSize)
+			if err = eeprom.Read(slot, read); err != tinygoerrors.ErrorCodeNil {
+				t.Fatalf("%s: read of slot %d: %d", test.name, slot, err)
+			}
+			if !bytes.Equal(read, data) {
+				t.Fatalf("%s: slot %d read back %v, expected %v", test.name, slot, read, data)
+			}
+		}
+		if device.rolledOver != 0 {
+			t.Fatalf("%s: %d writes rolled over a page", test.name, device.rolledOver)
+		}
+		for _, slot := range []int{0, 2, 6, 8} {
+			for index, value := range device.data[slot*test.slotSize : (slot+1)*test.slotSize] {
+				if value != ErasedByte {
+					t.Fatalf("%s: byte %d of slot %d is %#x, expected erased", test.name, index, slot, value)
+				}
+			}
+		}
+
+		// Erasing a slot only erases that one
+		if err = eeprom.Erase(1); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("%s: erase: %d", test.name, err)
+		}
+		if _, _, err = ReadRecord(eeprom, 1, make([]byte, test.slotSize)); err != ErrorCodeStoreEmptyRecord {
+			t.Fatalf("%s: record of an erased slot: %d, expected %d", test.name, err, ErrorCodeStoreEmptyRecord)
+		}
+		if device.data[7*test.slotSize] != 7 {
+			t.Fatalf("%s: erasing slot 1 touched slot 7", test.name)
+		}
+	}
+}
+
+// TestEEPROMRejectsInvalidLayouts checks the page and slot sizes and the address pins against the size of the part
+func TestEEPROMRejectsInvalidLayouts(t *testing.T) {
+	tests := []struct {
+		name          string
+		addressOffset uint8
+		size          int
+		pageSize      int
+		slotSize      int
+		expected      tinygoerrors.ErrorCode
+	}{
+		{"24C02", 7, 256, 8, 32, tinygoerrors.ErrorCodeNil},
+		{"24C16 with address pins", 1, 2048, 16, 64, ErrorCodeStoreInvalidLayout},
+		{"24C16", 0, 2048, 16, 64, tinygoerrors.ErrorCodeNil},
+		{"24C256", 7, 32768, 64, 256, tinygoerrors.ErrorCodeNil},
+		{"page too large", 0, 65536, MaxEEPROMPageSize * 2, 256, ErrorCodeStoreInvalidLayout},
+		{"size not a multiple of the page", 0, 1000, 16, 10, ErrorCodeStoreInvalidLayout},
+		{"slot larger than the part", 0, 256, 8, 512, ErrorCodeStoreInvalidLayout},
+	}
+	for _, test := range tests {
+		device := newFakeEEPROM(test.size, test.pageSize, EEPROMDefaultAddress)
+		_, err := NewEEPROM(device, test.addressOffset, test.size, test.pageSize, test.slotSize)
+		if err != test.expected {
+			t.Errorf("%s: %d, expected %d", test.name, err, test.expected)
+		}
+	}
+}
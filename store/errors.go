@@ -0,0 +1,24 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// ErrorCodeStoreStartNumber is the starting number for persistence related error codes.
+	ErrorCodeStoreStartNumber uint16 = 5700
+)
+
+const (
+	ErrorCodeStoreNilDevice tinygoerrors.ErrorCode = tinygoerrors.ErrorCode(iota + ErrorCodeStoreStartNumber)
+	ErrorCodeStoreInvalidLayout
+	ErrorCodeStoreInvalidSlot
+	ErrorCodeStoreDataTooLarge
+	ErrorCodeStoreReadFailed
+	ErrorCodeStoreWriteFailed
+	ErrorCodeStoreEraseFailed
+	ErrorCodeStoreBufferTooSmall
+	ErrorCodeStoreEmptyRecord
+	ErrorCodeStoreCorruptedRecord
+	ErrorCodeStoreVersionMismatch
+)
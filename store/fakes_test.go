@@ -0,0 +1,223 @@
+package store
+
+import (
+	"errors"
+)
+
+var (
+	// errFake is the error returned by the fakes when a failure is injected
+	errFake = errors.New("fake failure")
+)
+
+type (
+	// fakeBlockDevice is a flash memory that, like the real ones, can only clear bits when written, only takes whole
+	// write blocks and fails the writes straddling the erased bytes. A write may be torn after a number of bytes, as
+	// when the power is lost while programming
+	fakeBlockDevice struct {
+		data           []byte
+		writeBlockSize int64
+		eraseBlockSize int64
+		tornAfter      int
+		misaligned     int
+	}
+
+	// fakeEEPROM is an AT24Cxx EEPROM on an I2C bus. A write rolls over to the start of its page when it crosses the
+	// page boundary, like the real parts, so the misaligned writes corrupt the memory and are counted
+	fakeEEPROM struct {
+		data         []byte
+		address      uint16
+		pageSize     int
+		isSingleByte bool
+		offset       int
+		rolledOver   int
+	}
+
+	// fakeFRAM is an SPI FRAM and its chip select line, taking the read, write enable and write commands. The writes
+	// are ignored unless enabled by the previous transaction, like the real parts
+	fakeFRAM struct {
+		data            []byte
+		addressSize     int
+		isSelected      bool
+		opcode          byte
+		offset          int
+		hasCommand      bool
+		isWriteEnabled  bool
+		ignoredCommands int
+	}
+)
+
+// newFakeBlockDevice creates an erased fake flash memory
+func newFakeBlockDevice(size int, writeBlockSize int64, eraseBlockSize int64) *fakeBlockDevice {
+	device := &fakeBlockDevice{
+		data:           make([]byte, size),
+		writeBlockSize: writeBlockSize,
+		eraseBlockSize: eraseBlockSize,
+		tornAfter:      -1,
+	}
+	for index := range device.data {
+		device.data[index] = ErasedByte
+	}
+	return device
+}
+
+func (f *fakeBlockDevice) ReadAt(p []byte, off int64) (int, error) {
+	return copy(p, f.data[off:]), nil
+}
+
+func (f *fakeBlockDevice) WriteAt(p []byte, off int64) (int, error) {
+	if off%f.writeBlockSize != 0 || int64(len(p))%f.writeBlockSize != 0 {
+		f.misaligned++
+		return 0, errFake
+	}
+	for index, value := range p {
+		if f.tornAfter == 0 {
+			return index, errFake
+		}
+		if f.tornAfter > 0 {
+			f.tornAfter--
+		}
+		f.data[off+int64(index)] &= value
+	}
+	return len(p), nil
+}
+
+func (f *fakeBlockDevice) Size() int64 {
+	return int64(len(f.data))
+}
+
+func (f *fakeBlockDevice) WriteBlockSize() int64 {
+	return f.writeBlockSize
+}
+
+func (f *fakeBlockDevice) EraseBlockSize() int64 {
+	return f.eraseBlockSize
+}
+
+func (f *fakeBlockDevice) EraseBlocks(start int64, len int64) error {
+	for index := start * f.eraseBlockSize; index < (start+len)*f.eraseBlockSize; index++ {
+		f.data[index] = ErasedByte
+	}
+	return nil
+}
+
+// newFakeEEPROM creates an erased fake EEPROM answering at the given address
+func newFakeEEPROM(size int, pageSize int, address uint16) *fakeEEPROM {
+	eeprom := &fakeEEPROM{
+		data:         make([]byte, size),
+		address:      address,
+		pageSize:     pageSize,
+		isSingleByte: size <= eepromSingleAddressSize,
+	}
+	for index := range eeprom.data {
+		eeprom.data[index] = ErasedByte
+	}
+	return eeprom
+}
+
+func (f *fakeEEPROM) Tx(address uint16, w []byte, r []byte) error {
+	// The single byte parts take the upper address bits from the device address
+	headerSize := 2
+	offset := 0
+	if f.isSingleByte {
+		if address < f.address || int(address-f.address)<<8 >= len(f.data) || len(w) < 1 {
+			return errFake
+		}
+		headerSize = 1
+		offset = int(address-f.address)<<8 | int(w[0])
+	} else {
+		if address != f.address || len(w) < 2 {
+			return errFake
+		}
+		offset = int(w[0])<<8 | int(w[1])
+	}
+	f.offset = offset % len(f.data)
+
+	// Write the bytes within the page, rolling over to its start
+	page := f.offset - f.offset%f.pageSize
+	for index, value := range w[headerSize:] {
+		if index > 0 && (f.offset+index)%f.pageSize == 0 {
+			f.rolledOver++
+		}
+		f.data[page+(f.offset-page+index)%f.pageSize] = value
+	}
+
+	// Read sequentially, rolling over to the start of the memory
+	for index := range r {
+		r[index] = f.data[(f.offset+index)%len(f.data)]
+	}
+	return nil
+}
+
+// newFakeFRAM creates an erased fake FRAM
+func newFakeFRAM(size int) *fakeFRAM {
+	fram := &fakeFRAM{data: make([]byte, size), addressSize: 2}
+	if size > framTwoByteAddressSize {
+		fram.addressSize = 3
+	}
+	for index := range fram.data {
+		fram.data[index] = ErasedByte
+	}
+	return fram
+}
+
+func (f *fakeFRAM) High() {
+	if f.isSelected && f.hasCommand && f.opcode == framOpcodeWrite {
+		f.isWriteEnabled = false
+	}
+	f.isSelected = false
+	f.hasCommand = false
+}
+
+func (f *fakeFRAM) Low() {
+	f.isSelected = true
+}
+
+func (f *fakeFRAM) Tx(w []byte, r []byte) error {
+	if !f.isSelected {
+		return errFake
+	}
+
+	// Take the command at the start of the transaction
+	if !f.hasCommand {
+		if len(w) == 0 {
+			return errFake
+		}
+		f.opcode = w[0]
+		f.hasCommand = true
+		switch f.opcode {
+		case framOpcodeWriteEnable:
+			f.isWriteEnabled = true
+			return nil
+		case framOpcodeRead, framOpcodeWrite:
+			if len(w) < 1+f.addressSize {
+				return errFake
+			}
+			f.offset = 0
+			for _, value := range w[1 : 1+f.addressSize] {
+				f.offset = f.offset<<8 | int(value)
+			}
+			w = w[1+f.addressSize:]
+		default:
+			return errFake
+		}
+	}
+
+	// Transfer the data, the writes being ignored unless enabled
+	switch f.opcode {
+	case framOpcodeWrite:
+		if !f.isWriteEnabled {
+			f.ignoredCommands++
+			return nil
+		}
+		for _, value := range w {
+			f.data[f.offset%len(f.data)] = value
+			f.offset++
+		}
+	case framOpcodeRead:
+		for index := range r {
+			r[index] = f.data[f.offset%len(f.data)]
+			f.offset++
+		}
+	}
+	return nil
+}
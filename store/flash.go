@@ -0,0 +1,175 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Flash is a Store kept in a region of the flash memory, e.g. the machine.Flash data region left after the
+	// firmware. Every slot spans whole erase blocks, so a slot is erased and rewritten without touching the others
+	Flash struct {
+		device         BlockDevice
+		offset         int64
+		slotSize       int
+		slotsCount     int
+		eraseBlockSize int64
+		writeBlockSize int
+		tail           [MaxWriteBlockSize]byte
+	}
+)
+
+// NewFlash creates a new instance of Flash
+//
+// Parameters:
+//
+// device: The flash memory, usually machine.Flash
+// offset: The offset of the region in the flash memory, a multiple of its erase block size
+// slotSize: The size of the slots in bytes, a multiple of the erase block size
+// slotsCount: The number of slots, the region must fit in the flash memory
+//
+// Returns:
+//
+// An instance of Flash and an error if the device is nil or the region is not aligned to the erase blocks or doesn't
+// fit in the flash memory
+func NewFlash(device BlockDevice, offset int64, slotSize int, slotsCount int) (*Flash, tinygoerrors.ErrorCode) {
+	if device == nil {
+		return nil, ErrorCodeStoreNilDevice
+	}
+	eraseBlockSize := device.EraseBlockSize()
+	writeBlockSize := device.WriteBlockSize()
+	if eraseBlockSize <= 0 || writeBlockSize <= 0 || writeBlockSize > MaxWriteBlockSize {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	if offset < 0 || slotSize <= 0 || slotsCount <= 0 {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	if offset%eraseBlockSize != 0 || int64(slotSize)%eraseBlockSize != 0 {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	if offset+int64(slotSize)*int64(slotsCount) > device.Size() {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	return &Flash{
+		device:         device,
+		offset:         offset,
+		slotSize:       slotSize,
+		slotsCount:     slotsCount,
+		eraseBlockSize: eraseBlockSize,
+		writeBlockSize: int(writeBlockSize),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// SlotSize returns the size of the slots
+//
+// Returns:
+//
+// The size of the slots in bytes
+func (f *Flash) SlotSize() int {
+	return f.slotSize
+}
+
+// SlotsCount returns the number of slots
+//
+// Returns:
+//
+// The number of slots
+func (f *Flash) SlotsCount() int {
+	return f.slotsCount
+}
+
+// slotOffset returns the offset of a slot in the flash memory
+//
+// Parameters:
+//
+// slot: The slot index
+//
+// Returns:
+//
+// The offset of the first byte of the slot
+func (f *Flash) slotOffset(slot int) int64 {
+	return f.offset + int64(slot)*int64(f.slotSize)
+}
+
+// Read reads the beginning of a slot
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The buffer filled with the first bytes of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range, the buffer is longer than the slot or the flash memory could not be read
+func (f *Flash) Read(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), f.slotSize, f.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if _, err := f.device.ReadAt(data, f.slotOffset(slot)); err != nil {
+		return ErrorCodeStoreReadFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Write erases a slot and writes the data at its beginning. The last write block is padded with erased bytes, as the
+// flash memory is only written in whole write blocks
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The data written at the beginning of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range, the data is longer than the slot or the flash memory could not be erased or
+// written
+func (f *Flash) Write(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), f.slotSize, f.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if err := f.Erase(slot); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Write the whole write blocks directly from the data
+	offset := f.slotOffset(slot)
+	aligned := len(data) - len(data)%f.writeBlockSize
+	if aligned > 0 {
+		if _, err := f.device.WriteAt(data[:aligned], offset); err != nil {
+			return ErrorCodeStoreWriteFailed
+		}
+	}
+	if aligned == len(data) {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Write the last partial block padded with erased bytes
+	tail := f.tail[:f.writeBlockSize]
+	copied := copy(tail, data[aligned:])
+	for index := copied; index < len(tail); index++ {
+		tail[index] = ErasedByte
+	}
+	if _, err := f.device.WriteAt(tail, offset+int64(aligned)); err != nil {
+		return ErrorCodeStoreWriteFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Erase erases the blocks of a slot
+//
+// Parameters:
+//
+// slot: The slot index
+//
+// Returns:
+//
+// An error if the slot is out of range or the flash memory could not be erased
+func (f *Flash) Erase(slot int) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, 0, f.slotSize, f.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	start := f.slotOffset(slot) / f.eraseBlockSize
+	if err := f.device.EraseBlocks(start, int64(f.slotSize)/f.eraseBlockSize); err != nil {
+		return ErrorCodeStoreEraseFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// TestFlashPadsThePartialWriteBlock writes data ending in a partial write block, which the flash memory only takes
+// whole, and checks it reads back with the rest of the block erased and the next slot untouched
+func TestFlashPadsThePartialWriteBlock(t *testing.T) {
+	device := newFakeBlockDevice(4096, 256, 1024)
+	flash, err := NewFlash(device, 1024, 1024, 2)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("NewFlash: %d", err)
+	}
+
+	for _, length := range []int{1, 255, 256, 257, 600, 1024} {
+		data := make([]byte, length)
+		for index := range data {
+			data[index] = byte(index*7 + length)
+		}
+		if err = flash.Write(0, data); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("write of %d bytes: %d", length, err)
+		}
+		read := make([]byte, flash.SlotSize())
+		if err = flash.Read(0, read); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("read after the write of %d bytes: %d", length, err)
+		}
+		if !bytes.Equal(read[:length], data) {
+			t.Fatalf("write of %d bytes read back differently", length)
+		}
+		for index := length; index < len(read); index++ {
+			if read[index] != ErasedByte {
+				t.Fatalf("write of %d bytes: byte %d is %#x, expected erased", length, index, read[index])
+			}
+		}
+	}
+	if device.misaligned != 0 {
+		t.Fatalf("%d writes not aligned to the write blocks", device.misaligned)
+	}
+	for index, value := range device.data[:1024] {
+		if value != ErasedByte {
+			t.Fatalf("byte %d before the region is %#x, expected erased", index, value)
+		}
+	}
+	for index, value := range device.data[2048:] {
+		if value != ErasedByte {
+			t.Fatalf("byte %d of the next slot is %#x, expected erased", index, value)
+		}
+	}
+}
+
+// TestFlashRejectsInvalidLayouts checks the region must be aligned to the erase blocks and fit in the flash memory
+func TestFlashRejectsInvalidLayouts(t *testing.T) {
+	tests := []struct {
+		name           string
+		writeBlockSize int64
+		offset         int64
+		slotSize       int
+		slotsCount     int
+		expected       tinygoerrors.ErrorCode
+	}{
+		{"valid", 256, 0, 1024, 4, tinygoerrors.ErrorCodeNil},
+		{"misaligned offset", 256, 512, 1024, 2, ErrorCodeStoreInvalidLayout},
+		{"misaligned slot size", 256, 0, 1536, 2, ErrorCodeStoreInvalidLayout},
+		{"beyond the memory", 256, 1024, 1024, 4, ErrorCodeStoreInvalidLayout},
+		{"no slots", 256, 0, 1024, 0, ErrorCodeStoreInvalidLayout},
+		{"write block too large", MaxWriteBlockSize * 2, 0, 1024, 1, ErrorCodeStoreInvalidLayout},
+	}
+	for _, test := range tests {
+		device := newFakeBlockDevice(4096, test.writeBlockSize, 1024)
+		if _, err := NewFlash(device, test.offset, test.slotSize, test.slotsCount); err != test.expected {
+			t.Errorf("%s: %d, expected %d", test.name, err, test.expected)
+		}
+	}
+	if _, err := NewFlash(nil, 0, 1024, 1); err != ErrorCodeStoreNilDevice {
+		t.Errorf("nil device: %d, expected %d", err, ErrorCodeStoreNilDevice)
+	}
+}
+
+// TestFlashTornRecordIsDetected tears the write of a record at every byte, as a power loss while programming would,
+// and checks the record is never read back as valid
+func TestFlashTornRecordIsDetected(t *testing.T) {
+	payload := []byte("the tuning of servo seven")
+	buffer := make([]byte, 256)
+	for tornAfter := 0; tornAfter < len(payload)+RecordOverheadSize; tornAfter++ {
+		device := newFakeBlockDevice(1024, 8, 256)
+		flash, err := NewFlash(device, 0, 256, 4)
+		if err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("NewFlash: %d", err)
+		}
+		device.tornAfter = tornAfter
+		if err = WriteRecord(flash, 1, 1, payload, buffer); err != ErrorCodeStoreWriteFailed {
+			t.Fatalf("write torn after %d bytes: %d, expected %d", tornAfter, err, ErrorCodeStoreWriteFailed)
+		}
+		_, _, err = ReadRecord(flash, 1, buffer)
+		if err != ErrorCodeStoreEmptyRecord && err != ErrorCodeStoreCorruptedRecord {
+			t.Fatalf("record torn after %d bytes read back: %d", tornAfter, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// FRAM is a Store kept in an SPI FRAM, like the MB85RSxx and FM25Vxx parts. The memory is written byte by byte
+	// with no pages nor write cycles, so it suits the records written on every change, like audit logs
+	FRAM struct {
+		bus         SPI
+		chipSelect  ChipSelect
+		slotSize    int
+		slotsCount  int
+		addressSize int
+		header      [4]byte
+		erased      [framEraseChunkSize]byte
+	}
+)
+
+// NewFRAM creates a new instance of FRAM, the chip select line is driven high
+//
+// Parameters:
+//
+// bus: The SPI bus the memory is connected to, configured in mode 0
+// chipSelect: The chip select line of the memory, configured as an output
+// size: The size of the memory in bytes, e.g. 32768 for a MB85RS256
+// slotSize: The size of the slots in bytes, the memory is split in as many slots as fit in it
+//
+// Returns:
+//
+// An instance of FRAM and an error if the bus or the chip select line is nil or any size is invalid
+func NewFRAM(bus SPI, chipSelect ChipSelect, size int, slotSize int) (*FRAM, tinygoerrors.ErrorCode) {
+	if bus == nil || chipSelect == nil {
+		return nil, ErrorCodeStoreNilDevice
+	}
+	if size <= 0 || size > 1<<24 || slotSize <= 0 || slotSize > size {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	addressSize := 2
+	if size > framTwoByteAddressSize {
+		addressSize = 3
+	}
+	fram := &FRAM{
+		bus:         bus,
+		chipSelect:  chipSelect,
+		slotSize:    slotSize,
+		slotsCount:  size / slotSize,
+		addressSize: addressSize,
+	}
+	for index := range fram.erased {
+		fram.erased[index] = ErasedByte
+	}
+	chipSelect.High()
+	return fram, tinygoerrors.ErrorCodeNil
+}
+
+// SlotSize returns the size of the slots
+//
+// Returns:
+//
+// The size of the slots in bytes
+func (f *FRAM) SlotSize() int {
+	return f.slotSize
+}
+
+// SlotsCount returns the number of slots
+//
+// Returns:
+//
+// The number of slots
+func (f *FRAM) SlotsCount() int {
+	return f.slotsCount
+}
+
+// commandHeader writes an opcode followed by a memory address into the header
+//
+// Parameters:
+//
+// opcode: The opcode of the command
+// offset: The memory address
+//
+// Returns:
+//
+// The header to send
+func (f *FRAM) commandHeader(opcode byte, offset int) []byte {
+	f.header[0] = opcode
+	for index := 0; index < f.addressSize; index++ {
+		f.header[1+index] = byte(offset >> (8 * (f.addressSize - 1 - index)))
+	}
+	return f.header[:1+f.addressSize]
+}
+
+// Read reads the beginning of a slot
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The buffer filled with the first bytes of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range, the buffer is longer than the slot or the memory could not be read
+func (f *FRAM) Read(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), f.slotSize, f.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	f.chipSelect.Low()
+	err := f.bus.Tx(f.commandHeader(framOpcodeRead, slot*f.slotSize), nil)
+	if err == nil {
+		err = f.bus.Tx(nil, data)
+	}
+	f.chipSelect.High()
+	if err != nil {
+		return ErrorCodeStoreReadFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// write writes data at a memory address, after setting the write enable latch, which the memory clears after every
+// write
+//
+// Parameters:
+//
+// offset: The memory address
+// data: The data to write
+//
+// Returns:
+//
+// An error if the memory could not be written
+func (f *FRAM) write(offset int, data []byte) tinygoerrors.ErrorCode {
+	f.header[0] = framOpcodeWriteEnable
+	f.chipSelect.Low()
+	err := f.bus.Tx(f.header[:1], nil)
+	f.chipSelect.High()
+	if err != nil {
+		return ErrorCodeStoreWriteFailed
+	}
+
+	f.chipSelect.Low()
+	err = f.bus.Tx(f.commandHeader(framOpcodeWrite, offset), nil)
+	if err == nil {
+		err = f.bus.Tx(data, nil)
+	}
+	f.chipSelect.High()
+	if err != nil {
+		return ErrorCodeStoreWriteFailed
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Write writes the data at the beginning of a slot, the bytes after it are kept
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The data written at the beginning of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range, the data is longer than the slot or the memory could not be written
+func (f *FRAM) Write(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), f.slotSize, f.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if len(data) == 0 {
+		return tinygoerrors.ErrorCodeNil
+	}
+	return f.write(slot*f.slotSize, data)
+}
+
+// Erase writes erased bytes over a whole slot
+//
+// Parameters:
+//
+// slot: The slot index
+//
+// Returns:
+//
+// An error if the slot is out of range or the memory could not be written
+func (f *FRAM) Erase(slot int) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, 0, f.slotSize, f.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	offset := slot * f.slotSize
+	for remaining := f.slotSize; remaining > 0; {
+		chunk := min(remaining, framEraseChunkSize)
+		if err := f.write(offset, f.erased[:chunk]); err != tinygoerrors.ErrorCodeNil {
+			return ErrorCodeStoreEraseFailed
+		}
+		offset += chunk
+		remaining -= chunk
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// TestFRAMRoundTrip writes, reads back and erases records with the two and three byte addresses of the parts
+func TestFRAMRoundTrip(t *testing.T) {
+	for _, size := range []int{8192, 131072} {
+		device := newFakeFRAM(size)
+		fram, err := NewFRAM(device, device, size, 4096)
+		if err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("%d bytes: NewFRAM: %d", size, err)
+		}
+
+		buffer := make([]byte, 128)
+		slot := fram.SlotsCount() - 1
+		payload := []byte("audit entry")
+		if err = WriteRecord(fram, slot, 1, payload, buffer); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("%d bytes: write: %d", size, err)
+		}
+		if device.ignoredCommands != 0 {
+			t.Fatalf("%d bytes: %d writes without the write enable", size, device.ignoredCommands)
+		}
+		version, read, err := ReadRecord(fram, slot, buffer)
+		if err != tinygoerrors.ErrorCodeNil || version != 1 || !bytes.Equal(read, payload) {
+			t.Fatalf("%d bytes: read: version %d, %q, %d", size, version, read, err)
+		}
+
+		if err = fram.Erase(slot); err != tinygoerrors.ErrorCodeNil {
+			t.Fatalf("%d bytes: erase: %d", size, err)
+		}
+		if _, _, err = ReadRecord(fram, slot, buffer); err != ErrorCodeStoreEmptyRecord {
+			t.Fatalf("%d bytes: record of an erased slot: %d, expected %d", size, err, ErrorCodeStoreEmptyRecord)
+		}
+		for index, value := range device.data[:slot*4096] {
+			if value != ErasedByte {
+				t.Fatalf("%d bytes: byte %d of another slot is %#x", size, index, value)
+			}
+		}
+	}
+}
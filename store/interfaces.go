@@ -0,0 +1,46 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Store is a non-volatile memory split into fixed-size slots, shared by every persistence feature: each one owns
+	// a range of slots and writes its data as records. A slot reads as erased, all its bytes 0xFF, until written, and
+	// the bytes after the data of a write are only defined once the slot is erased
+	Store interface {
+		SlotSize() int
+		SlotsCount() int
+		Read(slot int, data []byte) tinygoerrors.ErrorCode
+		Write(slot int, data []byte) tinygoerrors.ErrorCode
+		Erase(slot int) tinygoerrors.ErrorCode
+	}
+
+	// BlockDevice is the flash memory a Flash store is kept in. It is satisfied by the TinyGo machine.Flash device
+	BlockDevice interface {
+		ReadAt(p []byte, off int64) (n int, err error)
+		WriteAt(p []byte, off int64) (n int, err error)
+		Size() int64
+		WriteBlockSize() int64
+		EraseBlockSize() int64
+		EraseBlocks(start int64, len int64) error
+	}
+
+	// I2C is the bus an EEPROM store is connected to. It is satisfied by the TinyGo machine.I2C peripherals and the
+	// I2C buses of the TinyGo drivers module
+	I2C interface {
+		Tx(address uint16, w []byte, r []byte) error
+	}
+
+	// SPI is the bus a FRAM store is connected to. It is satisfied by the TinyGo machine.SPI peripherals
+	SPI interface {
+		Tx(w []byte, r []byte) error
+	}
+
+	// ChipSelect is the chip select line of a FRAM store. It is satisfied by the TinyGo machine.Pin configured as an
+	// output
+	ChipSelect interface {
+		High()
+		Low()
+	}
+)
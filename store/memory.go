@@ -0,0 +1,115 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Memory is a Store kept in RAM, for the host tests of the persistence features and the boards without a
+	// non-volatile memory, where the data only lasts until the next reset
+	Memory struct {
+		data       []byte
+		slotSize   int
+		slotsCount int
+	}
+)
+
+// NewMemory creates a new instance of Memory with every slot erased
+//
+// Parameters:
+//
+// slotSize: The size of the slots in bytes
+// slotsCount: The number of slots
+//
+// Returns:
+//
+// An instance of Memory and an error if any size is zero
+func NewMemory(slotSize int, slotsCount int) (*Memory, tinygoerrors.ErrorCode) {
+	if slotSize <= 0 || slotsCount <= 0 {
+		return nil, ErrorCodeStoreInvalidLayout
+	}
+	memory := &Memory{
+		data:       make([]byte, slotSize*slotsCount),
+		slotSize:   slotSize,
+		slotsCount: slotsCount,
+	}
+	for index := range memory.data {
+		memory.data[index] = ErasedByte
+	}
+	return memory, tinygoerrors.ErrorCodeNil
+}
+
+// SlotSize returns the size of the slots
+//
+// Returns:
+//
+// The size of the slots in bytes
+func (m *Memory) SlotSize() int {
+	return m.slotSize
+}
+
+// SlotsCount returns the number of slots
+//
+// Returns:
+//
+// The number of slots
+func (m *Memory) SlotsCount() int {
+	return m.slotsCount
+}
+
+// Read reads the beginning of a slot
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The buffer filled with the first bytes of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range or the buffer is longer than the slot
+func (m *Memory) Read(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), m.slotSize, m.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	copy(data, m.data[slot*m.slotSize:])
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Write replaces the content of a slot, the bytes after the data are erased
+//
+// Parameters:
+//
+// slot: The slot index
+// data: The data written at the beginning of the slot
+//
+// Returns:
+//
+// An error if the slot is out of range or the data is longer than the slot
+func (m *Memory) Write(slot int, data []byte) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, len(data), m.slotSize, m.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	m.Erase(slot)
+	copy(m.data[slot*m.slotSize:], data)
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Erase erases a slot
+//
+// Parameters:
+//
+// slot: The slot index
+//
+// Returns:
+//
+// An error if the slot is out of range
+func (m *Memory) Erase(slot int) tinygoerrors.ErrorCode {
+	if err := checkAccess(slot, 0, m.slotSize, m.slotsCount); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	start := slot * m.slotSize
+	for index := start; index < start+m.slotSize; index++ {
+		m.data[index] = ErasedByte
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	"github.com/ralvarezdev/tinygo-servo/v2/internal/crc16"
+)
+
+// EncodeRecord writes a record into a buffer, laid out as the magic byte, the version, the little-endian payload
+// length, the payload and a CRC-16 of all of them, so a torn or foreign write is detected when it is read back
+//
+// Parameters:
+//
+// version: The version of the payload layout, so the features can migrate the records written by older firmware
+// payload: The data to store
+// buffer: The buffer the record is written to, at least RecordOverheadSize bytes longer than the payload
+//
+// Returns:
+//
+// The size of the record and an error if the buffer is too small
+func EncodeRecord(version uint8, payload []byte, buffer []byte) (int, tinygoerrors.ErrorCode) {
+	size := len(payload) + RecordOverheadSize
+	if len(payload) > 0xFFFF {
+		return 0, ErrorCodeStoreDataTooLarge
+	}
+	if len(buffer) < size {
+		return 0, ErrorCodeStoreBufferTooSmall
+	}
+	buffer[0] = recordMagic
+	buffer[1] = version
+	buffer[2] = byte(len(payload))
+	buffer[3] = byte(len(payload) >> 8)
+	copy(buffer[RecordHeaderSize:], payload)
+	checksum := crc16.Checksum(crc16.Initial, buffer[:size-2])
+	buffer[size-2] = byte(checksum)
+	buffer[size-1] = byte(checksum >> 8)
+	return size, tinygoerrors.ErrorCodeNil
+}
+
+// DecodeRecord reads a record from the data read from a slot
+//
+// Parameters:
+//
+// data: The data read from the slot, it may be longer than the record
+//
+// Returns:
+//
+// The version and the payload of the record, a subslice of the data, and an error if the slot is erased or the
+// record is corrupted or truncated
+func DecodeRecord(data []byte) (uint8, []byte, tinygoerrors.ErrorCode) {
+	if len(data) < RecordOverheadSize {
+		return 0, nil, ErrorCodeStoreBufferTooSmall
+	}
+	if data[0] != recordMagic {
+		if data[0] == ErasedByte {
+			return 0, nil, ErrorCodeStoreEmptyRecord
+		}
+		return 0, nil, ErrorCodeStoreCorruptedRecord
+	}
+	size := int(data[2]) | int(data[3])<<8 + RecordOverheadSize
+	if len(data) < size {
+		return 0, nil, ErrorCodeStoreBufferTooSmall
+	}
+	checksum := uint16(data[size-2]) | uint16(data[size-1])<<8
+	if checksum != crc16.Checksum(crc16.Initial, data[:size-2]) {
+		return 0, nil, ErrorCodeStoreCorruptedRecord
+	}
+	return data[1], data[RecordHeaderSize : size-2], tinygoerrors.ErrorCodeNil
+}
+
+// WriteRecord encodes a record into a buffer and writes it into a slot
+//
+// Parameters:
+//
+// store: The store the record is written to
+// slot: The slot index
+// version: The version of the payload layout
+// payload: The data to store
+// buffer: The buffer the record is encoded into, at least RecordOverheadSize bytes longer than the payload
+//
+// Returns:
+//
+// An error if the record doesn't fit in the buffer or the slot, or it could not be written
+func WriteRecord(store Store, slot int, version uint8, payload []byte, buffer []byte) tinygoerrors.ErrorCode {
+	size, err := EncodeRecord(version, payload, buffer)
+	if err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	return store.Write(slot, buffer[:size])
+}
+
+// ReadRecord reads a slot into a buffer and decodes its record
+//
+// Parameters:
+//
+// store: The store the record is read from
+// slot: The slot index
+// buffer: The buffer the slot is read into, up to the slot size
+//
+// Returns:
+//
+// The version and the payload of the record, a subslice of the buffer, and an error if the slot could not be read,
+// is erased or its record is corrupted. A record longer than the slot is corrupted, e.g. torn before its length was
+// written, while one longer than a buffer shorter than the slot may not fit in the buffer
+func ReadRecord(store Store, slot int, buffer []byte) (uint8, []byte, tinygoerrors.ErrorCode) {
+	if len(buffer) > store.SlotSize() {
+		buffer = buffer[:store.SlotSize()]
+	}
+	if err := store.Read(slot, buffer); err != tinygoerrors.ErrorCodeNil {
+		return 0, nil, err
+	}
+	version, payload, err := DecodeRecord(buffer)
+	if err == ErrorCodeStoreBufferTooSmall && len(buffer) == store.SlotSize() {
+		return 0, nil, ErrorCodeStoreCorruptedRecord
+	}
+	return version, payload, err
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// TestRecordRoundTrip encodes records of several lengths and decodes them back
+func TestRecordRoundTrip(t *testing.T) {
+	buffer := make([]byte, 300)
+	for _, length := range []int{0, 1, 42, 255, 256, 294} {
+		payload := make([]byte, length)
+		for index := range payload {
+			payload[index] = byte(index ^ length)
+		}
+		size, err := EncodeRecord(3, payload, buffer)
+		if err != tinygoerrors.ErrorCodeNil || size != length+RecordOverheadSize {
+			t.Fatalf("encode of %d bytes: size %d, %d", length, size, err)
+		}
+		version, decoded, err := DecodeRecord(buffer)
+		if err != tinygoerrors.ErrorCodeNil || version != 3 || !bytes.Equal(decoded, payload) {
+			t.Fatalf("decode of %d bytes: version %d, %d", length, version, err)
+		}
+	}
+	if _, err := EncodeRecord(1, make([]byte, 295), buffer); err != ErrorCodeStoreBufferTooSmall {
+		t.Fatalf("encode into a short buffer: %d, expected %d", err, ErrorCodeStoreBufferTooSmall)
+	}
+}
+
+// TestDecodeRecordDetectsDamage checks the erased slots, foreign data, flipped bits and truncated records are told
+// apart from the valid records
+func TestDecodeRecordDetectsDamage(t *testing.T) {
+	encoded := make([]byte, 32)
+	size, err := EncodeRecord(1, []byte("center 90"), encoded)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("encode: %d", err)
+	}
+
+	erased := bytes.Repeat([]byte{ErasedByte}, 32)
+	tests := []struct {
+		name     string
+		data     []byte
+		expected tinygoerrors.ErrorCode
+	}{
+		{"erased", erased, ErrorCodeStoreEmptyRecord},
+		{"foreign", append([]byte{0x00}, encoded[1:]...), ErrorCodeStoreCorruptedRecord},
+		{"too short", encoded[:RecordOverheadSize-1], ErrorCodeStoreBufferTooSmall},
+		{"truncated", encoded[:size-1], ErrorCodeStoreBufferTooSmall},
+		{"erased tail", append(append([]byte{}, encoded[:size-4]...), erased[:8]...), ErrorCodeStoreCorruptedRecord},
+	}
+	for index := 0; index < size; index++ {
+		// Every flipped byte but the magic one and the length is caught by the checksum
+		if index == 0 || index == 2 || index == 3 {
+			continue
+		}
+		flipped := append([]byte{}, encoded...)
+		flipped[index] ^= 0x10
+		tests = append(tests, struct {
+			name     string
+			data     []byte
+			expected tinygoerrors.ErrorCode
+		}{"flipped byte", flipped, ErrorCodeStoreCorruptedRecord})
+	}
+	for _, test := range tests {
+		if _, _, err = DecodeRecord(test.data); err != test.expected {
+			t.Errorf("%s: %d, expected %d", test.name, err, test.expected)
+		}
+	}
+}
+
+// TestRecordSlotChecksTheVersion checks a record slot only loads the records of its own version
+func TestRecordSlotChecksTheVersion(t *testing.T) {
+	memory, err := NewMemory(64, 2)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("NewMemory: %d", err)
+	}
+	older, err := NewRecordSlot(memory, 0, 1)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("older slot: %d", err)
+	}
+	newer, err := NewRecordSlot(memory, 0, 2)
+	if err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("newer slot: %d", err)
+	}
+
+	buffer := make([]byte, 64)
+	if _, err = newer.Load(buffer); err != ErrorCodeStoreEmptyRecord {
+		t.Fatalf("load of an erased slot: %d, expected %d", err, ErrorCodeStoreEmptyRecord)
+	}
+	if err = older.Store([]byte{1, 2, 3}); err != tinygoerrors.ErrorCodeNil {
+		t.Fatalf("store: %d", err)
+	}
+	if _, err = newer.Load(buffer); err != ErrorCodeStoreVersionMismatch {
+		t.Fatalf("load of another version: %d, expected %d", err, ErrorCodeStoreVersionMismatch)
+	}
+	if data, err := older.Load(buffer); err != tinygoerrors.ErrorCodeNil || !bytes.Equal(data, []byte{1, 2, 3}) {
+		t.Fatalf("load: %v, %d", data, err)
+	}
+	if _, err = older.Load(buffer[:2]); err != ErrorCodeStoreBufferTooSmall {
+		t.Fatalf("load into a short buffer: %d, expected %d", err, ErrorCodeStoreBufferTooSmall)
+	}
+}
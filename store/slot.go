@@ -0,0 +1,85 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// RecordSlot is a single slot of a store holding one record, loaded and stored as plain bytes. It is the storage
+	// the features saving a single blob take, like the tuning of the servo handlers, so they don't depend on the slot
+	// layout nor on the record format
+	RecordSlot struct {
+		store   Store
+		slot    int
+		version uint8
+		buffer  []byte
+	}
+)
+
+// NewRecordSlot creates a new instance of RecordSlot, allocating a buffer of the slot size
+//
+// Parameters:
+//
+// store: The store the slot belongs to
+// slot: The slot index
+// version: The version of the payload layout, the records of other versions are rejected by Load
+//
+// Returns:
+//
+// An instance of RecordSlot and an error if the store is nil, the slot is out of range or too small for a record
+func NewRecordSlot(store Store, slot int, version uint8) (*RecordSlot, tinygoerrors.ErrorCode) {
+	if store == nil {
+		return nil, ErrorCodeStoreNilDevice
+	}
+	if err := checkAccess(
+		slot,
+		RecordOverheadSize,
+		store.SlotSize(),
+		store.SlotsCount(),
+	); err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	return &RecordSlot{
+		store:   store,
+		slot:    slot,
+		version: version,
+		buffer:  make([]byte, store.SlotSize()),
+	}, tinygoerrors.ErrorCodeNil
+}
+
+// Load reads the payload of the record of the slot
+//
+// Parameters:
+//
+// buffer: The buffer the payload is copied into
+//
+// Returns:
+//
+// The payload, a subslice of the buffer, and an error if the slot could not be read, is erased, its record is
+// corrupted or of another version, or the payload doesn't fit in the buffer
+func (r *RecordSlot) Load(buffer []byte) ([]byte, tinygoerrors.ErrorCode) {
+	version, payload, err := ReadRecord(r.store, r.slot, r.buffer)
+	if err != tinygoerrors.ErrorCodeNil {
+		return nil, err
+	}
+	if version != r.version {
+		return nil, ErrorCodeStoreVersionMismatch
+	}
+	if len(payload) > len(buffer) {
+		return nil, ErrorCodeStoreBufferTooSmall
+	}
+	return buffer[:copy(buffer, payload)], tinygoerrors.ErrorCodeNil
+}
+
+// Store replaces the record of the slot
+//
+// Parameters:
+//
+// data: The payload to store
+//
+// Returns:
+//
+// An error if the record doesn't fit in the slot or could not be written
+func (r *RecordSlot) Store(data []byte) tinygoerrors.ErrorCode {
+	return WriteRecord(r.store, r.slot, r.version, data, r.buffer)
+}
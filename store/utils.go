@@ -0,0 +1,27 @@
+package store
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// checkAccess checks a slot access against the layout of a store
+//
+// Parameters:
+//
+// slot: The slot index
+// length: The number of bytes accessed
+// slotSize: The size of the slots of the store
+// slotsCount: The number of slots of the store
+//
+// Returns:
+//
+// An error if the slot is out of range or the data doesn't fit in it
+func checkAccess(slot int, length int, slotSize int, slotsCount int) tinygoerrors.ErrorCode {
+	if slot < 0 || slot >= slotsCount {
+		return ErrorCodeStoreInvalidSlot
+	}
+	if length > slotSize {
+		return ErrorCodeStoreDataTooLarge
+	}
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,54 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SubmitAngleMilliDegrees submits an angle from another goroutine or an interrupt handler, to be applied by the next
+// Update. The submission is a lock-free atomic exchange of the latest value, so neither the submitter nor the Update
+// tick ever block on each other, and a burst of submissions between two ticks only applies the newest one. Every other
+// method of the handler must be called from the goroutine running Update
+//
+// Parameters:
+//
+// milliDegrees: The angle in millidegrees, checked against the limits when it is applied
+//
+// Returns:
+//
+// An error if the angle is beyond the actuation range, the submission is then counted as rejected and the previous
+// one, if any, is kept
+func (h *DefaultHandler) SubmitAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	// The actuation range is set by the constructor, so it is safe to read from any goroutine. It also keeps the angle
+	// plus one from wrapping to the empty exchange
+	if milliDegrees > uint32(h.actuationRange)*1000 {
+		h.submissionsRejected.Add(1)
+		return ErrorCodeServoAngleOutOfRange
+	}
+
+	// Zero marks the empty exchange, so the angle is stored plus one
+	h.submittedAngle.Store(milliDegrees + 1)
+	return tinygoerrors.ErrorCodeNil
+}
+
+// RejectedSubmissions returns the number of submitted angles that were not applied, either beyond the actuation range
+// when submitted or refused when applied, e.g. outside the limits. It can be called from any goroutine
+//
+// Returns:
+//
+// The number of rejected submissions since the handler was created
+func (h *DefaultHandler) RejectedSubmissions() uint32 {
+	return h.submissionsRejected.Load()
+}
+
+// applySubmittedAngle applies the latest submitted angle, if any
+func (h *DefaultHandler) applySubmittedAngle() {
+	submitted := h.submittedAngle.Swap(0)
+	if submitted == 0 {
+		return
+	}
+
+	// The error is reported by SetAngleMilliDegrees, the submitter only sees the count
+	if err := h.SetAngleMilliDegrees(submitted - 1); err != tinygoerrors.ErrorCodeNil {
+		h.submissionsRejected.Add(1)
+	}
+}
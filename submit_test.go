@@ -0,0 +1,98 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSubmitAngleFromAnotherGoroutine submits angles from another goroutine while Update runs, run it with -race. No
+// tick may wait on the submitter, and once the submitter stops the newest angle is the one applied
+func TestSubmitAngleFromAnotherGoroutine(t *testing.T) {
+	handler, err := NewHandler(newFakePWM(), 0)
+	if err != 0 {
+		t.Fatalf("handler: %d", err)
+	}
+	releaseAll(t, handler)
+
+	const submissions = 20000
+	var (
+		group sync.WaitGroup
+		done  = make(chan struct{})
+	)
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		defer close(done)
+		for index := range uint32(submissions) {
+			if err := handler.SubmitAngleMilliDegrees(index % 180000); err != 0 {
+				t.Errorf("submission %d: %d", index, err)
+				return
+			}
+		}
+	}()
+
+	var slowest time.Duration
+	for nowMs := uint32(0); ; nowMs++ {
+		start := time.Now()
+		handler.Update(nowMs)
+		slowest = max(slowest, time.Since(start))
+
+		select {
+		case <-done:
+		default:
+			continue
+		}
+		break
+	}
+	group.Wait()
+	handler.Update(submissions)
+
+	if slowest > 100*time.Millisecond {
+		t.Fatalf("slowest Update took %v", slowest)
+	}
+	if angle := handler.GetAngleMilliDegrees(); angle != (submissions-1)%180000 {
+		t.Fatalf("applied angle %d, expected the newest submission %d", angle, (submissions-1)%180000)
+	}
+	if rejected := handler.RejectedSubmissions(); rejected != 0 {
+		t.Fatalf("%d submissions rejected", rejected)
+	}
+}
+
+// TestSubmitAngleRejectsInvalidAngles checks the angles beyond the actuation range, the largest one included as it
+// would wrap to the empty exchange, and the angles outside the limits are counted as rejected and never applied
+func TestSubmitAngleRejectsInvalidAngles(t *testing.T) {
+	handler, err := NewHandler(newFakePWM(), 0)
+	if err != 0 {
+		t.Fatalf("handler: %d", err)
+	}
+	releaseAll(t, handler)
+	if err = handler.SetLimits(30, 150); err != 0 {
+		t.Fatalf("SetLimits: %d", err)
+	}
+	angle := handler.GetAngleMilliDegrees()
+
+	for _, milliDegrees := range []uint32{0xFFFFFFFF, 180001} {
+		if err = handler.SubmitAngleMilliDegrees(milliDegrees); err != ErrorCodeServoAngleOutOfRange {
+			t.Fatalf("submission %d: %d, expected %d", milliDegrees, err, ErrorCodeServoAngleOutOfRange)
+		}
+	}
+	handler.Update(0)
+	if rejected := handler.RejectedSubmissions(); rejected != 2 {
+		t.Fatalf("%d submissions rejected, expected 2", rejected)
+	}
+
+	// Within the actuation range but outside the limits, it is rejected when applied
+	if err = handler.SubmitAngleMilliDegrees(170000); err != 0 {
+		t.Fatalf("submission within the actuation range: %d", err)
+	}
+	handler.Update(1)
+	if rejected := handler.RejectedSubmissions(); rejected != 3 {
+		t.Fatalf("%d submissions rejected, expected 3", rejected)
+	}
+	if current := handler.GetAngleMilliDegrees(); current != angle {
+		t.Fatalf("angle %d, expected %d", current, angle)
+	}
+}
@@ -0,0 +1,54 @@
+package tinygo_servo
+
+// SuspendOutput parks the pulse generation of the servo around an operation that disables the interrupts for a long
+// time, like a flash page write or the WiFi initialization, because the pulses truncated during those windows
+// command wrong angles and kick the servo. The signal line is held idle instead, which servos ignore by holding their
+// position. The PWM peripherals with buffered compare registers finish the pulse in flight, so the operation should
+// start one PWM period after this call. Angles set while suspended are stored and applied by ResumeOutput
+func (h *DefaultHandler) SuspendOutput() {
+	// Check if the output is already suspended
+	if h.isOutputSuspended {
+		return
+	}
+	h.stopPulses()
+	h.isOutputSuspended = true
+}
+
+// ResumeOutput resumes the pulse generation suspended by SuspendOutput at the current angle. Sleeping servos resume
+// their refresh bursts from Update
+func (h *DefaultHandler) ResumeOutput() {
+	// Check if the output is suspended
+	if !h.isOutputSuspended {
+		return
+	}
+	h.isOutputSuspended = false
+
+	// Restore the pulses, unless the servo is sleeping between two refresh bursts
+	if (!h.isSleeping || h.isRefreshing) && h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+}
+
+// IsOutputSuspended checks if the pulse generation is suspended
+//
+// Returns:
+//
+// True if the output is suspended, false otherwise
+func (h *DefaultHandler) IsOutputSuspended() bool {
+	return h.isOutputSuspended
+}
+
+// SuspendOutput suspends the pulse generation of every initialized handler, see DefaultHandler.SuspendOutput. The
+// handlers writing through a custom output, like a smart servo bus, are suspended too
+func SuspendOutput() {
+	for handler := firstRegisteredHandler; handler != nil; handler = handler.nextRegistered {
+		handler.SuspendOutput()
+	}
+}
+
+// ResumeOutput resumes the pulse generation of every initialized handler, see DefaultHandler.ResumeOutput
+func ResumeOutput() {
+	for handler := firstRegisteredHandler; handler != nil; handler = handler.nextRegistered {
+		handler.ResumeOutput()
+	}
+}
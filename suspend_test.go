@@ -0,0 +1,51 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestSuspendOutputCoversCustomOutputs checks the package-level suspension holds the signal of the handlers writing
+// through a custom output idle too, and that the angles set meanwhile are applied on resume
+func TestSuspendOutputCoversCustomOutputs(t *testing.T) {
+	pwm := newFakePWM()
+	pwmHandler, err := NewHandler(pwm, 0)
+	if err != 0 {
+		t.Fatalf("PWM handler: %d", err)
+	}
+	output := servotest.NewOutput()
+	outputHandler, err := NewOutputHandler(output)
+	if err != 0 {
+		t.Fatalf("output handler: %d", err)
+	}
+	releaseAll(t, pwmHandler, outputHandler)
+
+	SuspendOutput()
+	if !pwmHandler.IsOutputSuspended() || !outputHandler.IsOutputSuspended() {
+		t.Fatalf("suspended: PWM %v, output %v", pwmHandler.IsOutputSuspended(), outputHandler.IsOutputSuspended())
+	}
+	if pulse, _ := output.LastPulse(); pulse != 0 {
+		t.Fatalf("output pulse while suspended: %d", pulse)
+	}
+
+	// The angles set while suspended are stored, not written
+	output.ClearPulses()
+	if err = outputHandler.SetAngle(120); err != 0 {
+		t.Fatalf("SetAngle while suspended: %d", err)
+	}
+	if pulses := output.Pulses(); len(pulses) != 0 {
+		t.Fatalf("%d pulses written while suspended", len(pulses))
+	}
+
+	ResumeOutput()
+	if pwmHandler.IsOutputSuspended() || outputHandler.IsOutputSuspended() {
+		t.Fatalf("still suspended after ResumeOutput")
+	}
+	expected := outputHandler.calculatePulse(120)
+	if pulse, _ := output.LastPulse(); Nanoseconds(pulse) != expected {
+		t.Fatalf("output pulse after resume: %d, expected %d", pulse, expected)
+	}
+}
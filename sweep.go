@@ -0,0 +1,84 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// StartSweep continuously oscillates the servo motor between two angles at a constant speed, driven by Update, e.g.
+// for radar-style sensor scanning. The sweep starts at the first angle and runs until StopSweep, StopMove or another
+// angle command
+//
+// Parameters:
+//
+// from: The absolute angle the sweep starts at, must be between the left and right limits
+// to: The absolute angle the sweep turns around at, must be between the left and right limits
+// periodMs: The duration of a full oscillation, from the first angle to the second one and back, in milliseconds
+//
+// Returns:
+//
+// An error if the period is too short, an angle is out of range, the command is discarded or the servo motor could
+// not be initialized
+func (h *DefaultHandler) StartSweep(from uint16, to uint16, periodMs uint32) tinygoerrors.ErrorCode {
+	if periodMs < 2 {
+		return h.reportError(ErrorCodeServoInvalidSweepPeriod)
+	}
+
+	// Check if the angles are within the valid range
+	if from < h.LeftLimit() || from > h.RightLimit() || to < h.LeftLimit() || to > h.RightLimit() {
+		return h.reportError(ErrorCodeServoAngleOutOfRange)
+	}
+
+	// Configure the PWM on the first command if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the command must be discarded because movement is disabled
+	if !h.IsMovementEnabled() && h.disabledCommandPolicy == DisabledCommandPolicyDiscard {
+		return h.reportError(ErrorCodeServoMovementDisabled)
+	}
+
+	// Start the sweep at the last update, replacing any other move
+	h.StopMove()
+	h.sweepFrom = uint32(from) * 1000
+	h.sweepTo = uint32(to) * 1000
+	h.sweepPeriodMs = periodMs
+	h.sweepStartMs = h.lastUpdateMs
+	h.hasSweep = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// StopSweep stops the sweep in progress, holding the current angle
+func (h *DefaultHandler) StopSweep() {
+	h.hasSweep = false
+}
+
+// IsSweeping checks if a sweep is in progress
+//
+// Returns:
+//
+// True if a sweep is in progress, false otherwise
+func (h *DefaultHandler) IsSweeping() bool {
+	return h.hasSweep
+}
+
+// updateSweep advances the sweep in progress
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateSweep(nowMs uint32) {
+	if !h.hasSweep {
+		return
+	}
+
+	// Fold the phase of the oscillation into the progress from the first angle to the second one
+	halfPeriodMs := h.sweepPeriodMs / 2
+	phaseMs := (nowMs - h.sweepStartMs) % (2 * halfPeriodMs)
+	if phaseMs > halfPeriodMs {
+		phaseMs = 2*halfPeriodMs - phaseMs
+	}
+	delta := int64(h.sweepTo) - int64(h.sweepFrom)
+	offset := delta * int64(phaseMs) / int64(halfPeriodMs)
+	_ = h.commandAngle(uint32(int64(h.sweepFrom) + offset))
+}
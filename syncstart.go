@@ -0,0 +1,172 @@
+package tinygo_servo
+
+import (
+	"sync/atomic"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+const (
+	// MaxSyncStartTargets is the maximum number of servos started together by a SyncStart
+	MaxSyncStartTargets = 16
+)
+
+type (
+	// syncStartTarget is a servo angle waiting for the synchronized start
+	syncStartTarget struct {
+		handler *DefaultHandler
+		angle   uint16
+		pulse   Nanoseconds
+		output  Nanoseconds
+	}
+
+	// SyncStart moves several servos within microseconds of each other when triggered by an external GPIO edge or by
+	// software, so multiple boards driving parts of one mechanism can start moving at the same time. The pulses are
+	// precomputed when the targets are added, firing only writes them to the outputs, and the next call to Update
+	// commits the new angles to the handlers
+	SyncStart struct {
+		targets      [MaxSyncStartTargets]syncStartTarget
+		targetsCount int
+		isArmed      atomic.Bool
+		isFired      atomic.Bool
+		hasFired     bool
+		pin          Pin
+		isPinArmed   bool
+	}
+)
+
+// NewSyncStart creates a new instance of SyncStart
+//
+// Returns:
+//
+// An instance of SyncStart with no targets
+func NewSyncStart() *SyncStart {
+	return &SyncStart{}
+}
+
+// Add adds a servo angle to the synchronized start. The armed handlers must not be commanded until the start fires
+//
+// Parameters:
+//
+// handler: The handler of the servo
+// angle: The absolute angle the servo moves to when the start fires
+//
+// Returns:
+//
+// An error if the start is armed, it is full, the handler isn't initialized or the angle is out of its limits
+func (s *SyncStart) Add(handler *DefaultHandler, angle uint16) tinygoerrors.ErrorCode {
+	// Check if the start can be modified
+	if s.isArmed.Load() {
+		return ErrorCodeServoSyncStartArmed
+	}
+	if s.targetsCount == MaxSyncStartTargets {
+		return ErrorCodeServoTooManySyncTargets
+	}
+
+	// Check if the handler can be commanded
+	if handler == nil {
+		return ErrorCodeServoNilHandler
+	}
+	if err := handler.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	if !IsAngleWithinLimits(handler, angle) {
+		return ErrorCodeServoAngleOutOfRange
+	}
+
+	// Precompute the output so firing only writes it
+	pulse := handler.calculatePulse(angle)
+	s.targets[s.targetsCount] = syncStartTarget{
+		handler: handler,
+		angle:   angle,
+		pulse:   pulse,
+		output:  handler.calculateOutputPulse(pulse),
+	}
+	s.targetsCount++
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Clear removes every target from the synchronized start and disarms it, a fired start not committed yet by Update is
+// discarded
+func (s *SyncStart) Clear() {
+	s.Disarm()
+	s.targetsCount = 0
+	s.isFired.Store(false)
+	s.hasFired = false
+}
+
+// Arm arms the synchronized start, so the next call to Fire moves the servos
+func (s *SyncStart) Arm() {
+	s.hasFired = false
+	s.isArmed.Store(true)
+}
+
+// Disarm disarms the synchronized start and removes the sync pin interrupt, if any
+func (s *SyncStart) Disarm() {
+	s.isArmed.Store(false)
+	if s.isPinArmed {
+		s.disarmPin()
+		s.isPinArmed = false
+	}
+}
+
+// IsArmed checks if the synchronized start is waiting for its trigger
+//
+// Returns:
+//
+// True if the start is armed, false otherwise
+func (s *SyncStart) IsArmed() bool {
+	return s.isArmed.Load()
+}
+
+// HasFired checks if the synchronized start has moved the servos since it was armed, and Update committed their angles
+//
+// Returns:
+//
+// True if the start has fired and was committed, false otherwise
+func (s *SyncStart) HasFired() bool {
+	return s.hasFired
+}
+
+// Fire writes the target pulse of every servo if the start is armed. It is safe to call from an interrupt: it only
+// writes the precomputed pulses to the outputs and flags the start as fired, the handlers are left untouched until
+// Update commits the angles
+func (s *SyncStart) Fire() {
+	// Check if the start is armed, so repeated edges are ignored
+	if !s.isArmed.CompareAndSwap(true, false) {
+		return
+	}
+
+	// Write the precomputed outputs, keeping the skew between servos as low as possible
+	for index := 0; index < s.targetsCount; index++ {
+		target := &s.targets[index]
+		if target.handler.canWritePulse() {
+			target.handler.output.SetPulse(uint32(target.output))
+		}
+	}
+	s.isFired.Store(true)
+}
+
+// Update commits the angles of a fired start to the handlers through their usual angle path, replacing their moves
+// in progress, so the alarm zones, the recording and the after set angle functions see them. It must be called
+// periodically from the goroutine running the Update of the handlers
+//
+// Returns:
+//
+// An error if any handler rejected its angle, e.g. because its limits changed since the target was added
+func (s *SyncStart) Update() tinygoerrors.ErrorCode {
+	if !s.isFired.Swap(false) {
+		return tinygoerrors.ErrorCodeNil
+	}
+	firstErr := tinygoerrors.ErrorCodeNil
+	for index := 0; index < s.targetsCount; index++ {
+		target := &s.targets[index]
+		target.handler.StopMove()
+		err := target.handler.setAngleWithPulse(uint32(target.angle)*1000, target.pulse)
+		if err != tinygoerrors.ErrorCodeNil && firstErr == tinygoerrors.ErrorCodeNil {
+			firstErr = err
+		}
+	}
+	s.hasFired = true
+	return firstErr
+}
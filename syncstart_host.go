@@ -0,0 +1,6 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+// disarmPin does nothing on host builds, which have no GPIO interrupts to arm the synchronized start on
+func (s *SyncStart) disarmPin() {}
@@ -0,0 +1,59 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"testing"
+
+	"github.com/ralvarezdev/tinygo-servo/v2/servotest"
+)
+
+// TestSyncStartCommitsOnUpdate checks firing only writes the pulses, leaving the handlers untouched until Update
+// commits the angles through their usual angle path
+func TestSyncStartCommitsOnUpdate(t *testing.T) {
+	output := servotest.NewOutput()
+	var angles []uint16
+	handler, err := NewOutputHandler(output, WithAfterSetAngleFunc(func(angle uint16) { angles = append(angles, angle) }))
+	if err != 0 {
+		t.Fatalf("NewOutputHandler: %d", err)
+	}
+	releaseAll(t, handler)
+
+	start := NewSyncStart()
+	if err = start.Add(handler, 150); err != 0 {
+		t.Fatalf("Add: %d", err)
+	}
+	start.Arm()
+	start.Fire()
+	start.Fire()
+	pulse, _ := output.LastPulse()
+	if handler.GetAngle() != 90 || len(angles) != 0 || start.HasFired() || start.IsArmed() {
+		t.Fatalf(
+			"after firing: angle %d, %d callbacks, fired %v, armed %v, want 90, none, not fired and disarmed",
+			handler.GetAngle(),
+			len(angles),
+			start.HasFired(),
+			start.IsArmed(),
+		)
+	}
+	if pulse != uint32(handler.calculatePulse(150)) {
+		t.Fatalf("pulse after firing = %dns, want %dns", pulse, handler.calculatePulse(150))
+	}
+
+	if err = start.Update(); err != 0 {
+		t.Fatalf("Update: %d", err)
+	}
+	if handler.GetAngle() != 150 || len(angles) != 1 || !start.HasFired() {
+		t.Errorf(
+			"after Update: angle %d, %d callbacks, fired %v, want 150, one and fired",
+			handler.GetAngle(),
+			len(angles),
+			start.HasFired(),
+		)
+	}
+
+	// The start is committed once
+	if err = start.Update(); err != 0 || len(angles) != 1 {
+		t.Errorf("second Update: %d with %d callbacks, want none and one callback", err, len(angles))
+	}
+}
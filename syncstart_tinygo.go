@@ -0,0 +1,40 @@
+//go:build tinygo
+
+package tinygo_servo
+
+import (
+	"machine"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// ArmOnPin arms the synchronized start and fires it from the interrupt of a GPIO edge, Update commits it afterwards.
+// The pin must be configured as an input beforehand
+//
+// Parameters:
+//
+// pin: The sync pin
+// change: The edge of the sync pin that fires the start
+//
+// Returns:
+//
+// An error if the pin interrupt could not be set
+func (s *SyncStart) ArmOnPin(pin Pin, change machine.PinChange) tinygoerrors.ErrorCode {
+	s.Arm()
+	if err := pin.SetInterrupt(
+		change, func(Pin) {
+			s.Fire()
+		},
+	); err != nil {
+		s.isArmed.Store(false)
+		return ErrorCodeServoFailedToSetInterrupt
+	}
+	s.pin = pin
+	s.isPinArmed = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// disarmPin removes the sync pin interrupt
+func (s *SyncStart) disarmPin() {
+	_ = s.pin.SetInterrupt(0, nil)
+}
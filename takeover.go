@@ -0,0 +1,123 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// takeOverPrefix is the prefix for the log message when a control source takes over the servo
+	takeOverPrefix = []byte("Servo taken over by control source:")
+)
+
+// SetSourceAngleMilliDegrees sets the angle a control source commands. Only the active source moves the servo motor,
+// the others are tracked so a take over can blend towards their setpoint
+//
+// Parameters:
+//
+// source: The control source
+// milliDegrees: The angle commanded by the source in millidegrees
+//
+// Returns:
+//
+// An error if the source is unknown, or the error of setting the angle if the source is active
+func (h *DefaultHandler) SetSourceAngleMilliDegrees(source ControlSource, milliDegrees uint32) tinygoerrors.ErrorCode {
+	if source >= controlSourcesCount {
+		return ErrorCodeServoUnknownControlSource
+	}
+	h.sourceAngles[source] = milliDegrees
+	h.hasSourceAngles[source] = true
+
+	// The blend applies the setpoint while the active source is taking over
+	if source != h.activeSource || h.isTakingOver {
+		return tinygoerrors.ErrorCodeNil
+	}
+	return h.SetAngleMilliDegrees(milliDegrees)
+}
+
+// TakeOver hands the control of the servo motor to another source, ramping the authority from the current angle to
+// the setpoint of the new source instead of snapping to it. The blend is driven by Update and follows the setpoint
+// while it changes
+//
+// Parameters:
+//
+// source: The control source taking over
+// rampMs: The duration of the blend in milliseconds, zero switches immediately
+//
+// Returns:
+//
+// An error if the source is unknown
+func (h *DefaultHandler) TakeOver(source ControlSource, rampMs uint32) tinygoerrors.ErrorCode {
+	if source >= controlSourcesCount {
+		return ErrorCodeServoUnknownControlSource
+	}
+	h.activeSource = source
+	h.takeOverFrom = h.GetAngleMilliDegrees()
+	h.takeOverRampMs = rampMs
+	h.hasTakeOverTimestamp = false
+	h.isTakingOver = rampMs > 0
+
+	// Log the take over if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.AddMessageWithUint8(takeOverPrefix, uint8(source), true, true, false)
+		h.logger.Debug()
+	}
+
+	// Switch immediately without a ramp
+	if !h.isTakingOver && h.hasSourceAngles[source] {
+		return h.SetAngleMilliDegrees(h.sourceAngles[source])
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ActiveSource returns the control source commanding the servo motor
+//
+// Returns:
+//
+// The active control source
+func (h *DefaultHandler) ActiveSource() ControlSource {
+	return h.activeSource
+}
+
+// IsTakingOver returns whether a take over blend is in progress
+//
+// Returns:
+//
+// True if the authority is being ramped to the active source, false otherwise
+func (h *DefaultHandler) IsTakingOver() bool {
+	return h.isTakingOver
+}
+
+// updateTakeOver advances the take over blend
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateTakeOver(nowMs uint32) {
+	if !h.isTakingOver {
+		return
+	}
+
+	// Start the blend on the first update
+	if !h.hasTakeOverTimestamp {
+		h.hasTakeOverTimestamp = true
+		h.takeOverTimestampMs = nowMs
+	}
+
+	// Keep the current angle until the new source commands one
+	if !h.hasSourceAngles[h.activeSource] {
+		return
+	}
+	target := h.sourceAngles[h.activeSource]
+
+	// Blend from the angle at the take over to the setpoint of the active source
+	elapsedMs := nowMs - h.takeOverTimestampMs
+	if elapsedMs >= h.takeOverRampMs {
+		h.isTakingOver = false
+		_ = h.setAngleMilliDegrees(target)
+		return
+	}
+	blended := int64(h.takeOverFrom) +
+		(int64(target)-int64(h.takeOverFrom))*int64(elapsedMs)/int64(h.takeOverRampMs)
+	_ = h.setAngleMilliDegrees(uint32(blended))
+}
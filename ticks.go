@@ -0,0 +1,222 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// GetTop returns the PWM counter value of a full period, the resolution of the pulse widths in timer ticks
+//
+// Returns:
+//
+// The PWM Top value, and an error if the handler has no PWM peripheral, it could not be initialized or its Top value
+// is zero
+func (h *DefaultHandler) GetTop() (uint32, tinygoerrors.ErrorCode) {
+	// Check if the handler writes to a PWM channel, custom outputs have no timer ticks
+	if h.pwm == nil {
+		return 0, ErrorCodeServoNoPWM
+	}
+
+	// Configure the PWM first if the initialization was deferred, the Top value depends on it
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return 0, err
+	}
+	top := h.pwm.Top()
+	if top == 0 {
+		return 0, ErrorCodeServoZeroTop
+	}
+	return top, tinygoerrors.ErrorCodeNil
+}
+
+// PulseToTicks converts a pulse width to PWM timer ticks, with the rounding behavior of the handler
+//
+// Parameters:
+//
+// pulse: The pulse width to convert
+//
+// Returns:
+//
+// The timer ticks of the pulse width, and an error if the PWM Top value could not be read
+func (h *DefaultHandler) PulseToTicks(pulse Nanoseconds) (uint32, tinygoerrors.ErrorCode) {
+	if _, err := h.GetTop(); err != tinygoerrors.ErrorCodeNil {
+		return 0, err
+	}
+	return h.calculateDuty(pulse), tinygoerrors.ErrorCodeNil
+}
+
+// TicksToPulse converts PWM timer ticks to the nearest pulse width
+//
+// Parameters:
+//
+// ticks: The timer ticks to convert
+//
+// Returns:
+//
+// The pulse width of the timer ticks, and an error if the PWM Top value could not be read
+func (h *DefaultHandler) TicksToPulse(ticks uint32) (Nanoseconds, tinygoerrors.ErrorCode) {
+	top, err := h.GetTop()
+	if err != tinygoerrors.ErrorCodeNil {
+		return 0, err
+	}
+	return Nanoseconds(divideRounded(uint64(ticks)*uint64(h.period), uint64(top), RoundingNearest)),
+		tinygoerrors.ErrorCodeNil
+}
+
+// GetPulseTicks returns the pulse width of the current angle in PWM timer ticks, before applying the polarity
+//
+// Returns:
+//
+// The timer ticks of the current pulse width, and an error if the PWM Top value could not be read
+func (h *DefaultHandler) GetPulseTicks() (uint32, tinygoerrors.ErrorCode) {
+	return h.PulseToTicks(h.pulse)
+}
+
+// GetPulseTicksRange returns the min and max pulse widths in PWM timer ticks, the valid range of SetPulseTicks
+//
+// Returns:
+//
+// The timer ticks of the min and max pulse widths, and an error if the PWM Top value could not be read
+func (h *DefaultHandler) GetPulseTicksRange() (uint32, uint32, tinygoerrors.ErrorCode) {
+	minTicks, err := h.PulseToTicks(h.minPulseWidth)
+	if err != tinygoerrors.ErrorCodeNil {
+		return 0, 0, err
+	}
+	return minTicks, h.calculateDuty(h.maxPulseWidth), tinygoerrors.ErrorCodeNil
+}
+
+// SetPulseTicks outputs an exact number of PWM timer ticks, for applications that store the pulse widths in timer
+// ticks to reproduce them exactly without float or microseconds conversions. The angle of the handler is set to the
+// angle closest to the pulse width
+//
+// Parameters:
+//
+// ticks: The timer ticks to output, between the ticks of the min and max pulse widths
+//
+// Returns:
+//
+// An error if the ticks are out of the pulse range, can't be produced by any pulse width of nanosecond resolution,
+// its angle is out of the limits or the servo motor could not be initialized
+func (h *DefaultHandler) SetPulseTicks(ticks uint32) tinygoerrors.ErrorCode {
+	// Check if the ticks are within the pulse range
+	minTicks, maxTicks, err := h.GetPulseTicksRange()
+	if err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+	if ticks < minTicks || ticks > maxTicks {
+		return h.reportError(ErrorCodeServoInvalidPulseTicks)
+	}
+
+	// Find a pulse width that converts back to exactly the same ticks with the rounding of the handler
+	pulse, err := h.TicksToPulse(ticks)
+	if err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+	switch {
+	case h.calculateDuty(pulse) == ticks:
+	case pulse > 0 && h.calculateDuty(pulse-1) == ticks:
+		pulse--
+	case h.calculateDuty(pulse+1) == ticks:
+		pulse++
+	default:
+		return h.reportError(ErrorCodeServoUnreachablePulseTicks)
+	}
+	h.StopMove()
+	return h.commandPulse(pulse)
+}
+
+// calculateMilliDegrees calculates the angle in millidegrees closest to the given pulse width, the inverse of
+// calculatePulseMilliDegrees
+//
+// Parameters:
+//
+// pulse: The pulse width to convert
+//
+// Returns:
+//
+// The angle in millidegrees, clamped to the actuation range
+func (h *DefaultHandler) calculateMilliDegrees(pulse Nanoseconds) uint32 {
+	// Clamp the pulse width to the pulse range
+	if pulse <= h.minPulseWidth {
+		return 0
+	}
+	if pulse >= h.maxPulseWidth {
+		return uint32(h.actuationRange) * 1000
+	}
+
+	// Use the segment of the pulse width if a calibration table or a neutral pulse width is set
+	if h.calibration != nil {
+		return h.calculateCalibratedMilliDegrees(pulse)
+	}
+	if h.neutralPulseWidth == 0 {
+		return uint32(
+			divideRounded(
+				uint64(pulse-h.minPulseWidth)*uint64(h.actuationRange)*1000,
+				uint64(h.maxPulseWidth-h.minPulseWidth),
+				RoundingNearest,
+			),
+		)
+	}
+	centerMilliDegrees := uint32(h.centerAngle) * 1000
+	if pulse <= h.neutralPulseWidth {
+		return uint32(
+			divideRounded(
+				uint64(pulse-h.minPulseWidth)*uint64(centerMilliDegrees),
+				uint64(h.neutralPulseWidth-h.minPulseWidth),
+				RoundingNearest,
+			),
+		)
+	}
+	return centerMilliDegrees + uint32(
+		divideRounded(
+			uint64(pulse-h.neutralPulseWidth)*(uint64(h.actuationRange)*1000-uint64(centerMilliDegrees)),
+			uint64(h.maxPulseWidth-h.neutralPulseWidth),
+			RoundingNearest,
+		),
+	)
+}
+
+// SetPulseMicroseconds outputs a raw pulse width, bypassing the angle mapping, e.g. for calibration, ESCs or servos
+// without a linear response. The angle of the handler is set to the angle closest to the pulse width
+//
+// Parameters:
+//
+// us: The pulse width in microseconds, between the min and max pulse widths
+//
+// Returns:
+//
+// An error if the pulse width is out of the pulse range, its angle is out of the limits or the servo motor could not
+// be initialized
+func (h *DefaultHandler) SetPulseMicroseconds(us uint32) tinygoerrors.ErrorCode {
+	pulse, ok := microsecondsToNanoseconds(Microseconds(us))
+	if !ok || pulse < h.minPulseWidth || pulse > h.maxPulseWidth {
+		return h.reportError(ErrorCodeServoInvalidPulseWidth)
+	}
+	h.StopMove()
+	return h.commandPulse(pulse)
+}
+
+// GetPulseMicroseconds returns the pulse width of the current angle
+//
+// Returns:
+//
+// The pulse width in microseconds, rounded to the nearest
+func (h *DefaultHandler) GetPulseMicroseconds() uint32 {
+	return uint32(nanosecondsToMicroseconds(h.pulse))
+}
+
+// commandPulse outputs a pulse width commanded by the application, recording the angle closest to it
+//
+// Parameters:
+//
+// pulse: The pulse width, within the pulse range
+//
+// Returns:
+//
+// An error if the angle of the pulse width is out of the limits or the servo motor could not be initialized
+func (h *DefaultHandler) commandPulse(pulse Nanoseconds) tinygoerrors.ErrorCode {
+	milliDegrees := h.calculateMilliDegrees(pulse)
+	if err := h.setAngleWithPulse(milliDegrees, pulse); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.recordAngle(milliDegrees)
+	return tinygoerrors.ErrorCodeNil
+}
@@ -0,0 +1,48 @@
+//go:build tinygo
+
+package main
+
+import (
+	"machine"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+	tinygoservo "github.com/ralvarezdev/tinygo-servo/v2"
+)
+
+type (
+	// nopPWM is a PWM implementation that discards every operation, so the size report only measures this package
+	nopPWM struct{}
+)
+
+// Configure does nothing
+func (nopPWM) Configure(config machine.PWMConfig) error {
+	return nil
+}
+
+// Channel returns the first channel for every pin
+func (nopPWM) Channel(pin machine.Pin) (uint8, error) {
+	return 0, nil
+}
+
+// Top returns a typical 16-bit counter top
+func (nopPWM) Top() uint32 {
+	return 0xffff
+}
+
+// Set does nothing
+func (nopPWM) Set(channel uint8, value uint32) {}
+
+func main() {
+	// Create a handler for a standard 180 degrees hobby servo
+	handler, err := tinygoservo.NewHandler(nopPWM{}, machine.Pin(0))
+	if err != tinygoerrors.ErrorCodeNil {
+		return
+	}
+
+	// Sweep the whole range so the pulse math is linked in
+	for {
+		for angle := uint16(0); angle <= 180; angle++ {
+			_ = handler.SetAngle(angle)
+		}
+	}
+}
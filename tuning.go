@@ -0,0 +1,200 @@
+package tinygo_servo
+
+import (
+	"encoding/binary"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Tuning is the per-unit adjustment of a handler found during commissioning: the center angle and its trim, the
+	// limits, the neutral pulse width and the calibration table. It is saved with SaveTuning so the adjustment
+	// survives power cycles, and only applies to handlers created with the same configuration, direction included
+	Tuning struct {
+		CenterAngle       uint16
+		LeftLimitAngle    uint16
+		RightLimitAngle   uint16
+		Trim              int16
+		NeutralPulseWidth Nanoseconds
+		Calibration       *CalibrationTable
+	}
+)
+
+// GetTuning returns the current per-unit adjustment of the handler
+//
+// Returns:
+//
+// The tuning, the angles being absolute angles as stored by the handler
+func (h *DefaultHandler) GetTuning() Tuning {
+	return Tuning{
+		CenterAngle:       h.centerAngle,
+		LeftLimitAngle:    h.leftLimitAngle,
+		RightLimitAngle:   h.rightLimitAngle,
+		Trim:              h.trim,
+		NeutralPulseWidth: h.neutralPulseWidth,
+		Calibration:       h.calibration,
+	}
+}
+
+// ApplyTuning replaces the per-unit adjustment of the handler, the whole tuning is checked before any of it is
+// applied. The pulse of the current angle is recalculated, and the servo motor is moved within the new limits if it is
+// outside of them
+//
+// Parameters:
+//
+// tuning: The tuning, usually returned by GetTuning on the same handler before a power cycle
+//
+// Returns:
+//
+// An error if the center angle is not between the limits, the limits exceed the actuation range, the pulse widths
+// are out of the pulse range or the servo motor could not be moved
+func (h *DefaultHandler) ApplyTuning(tuning Tuning) tinygoerrors.ErrorCode {
+	// Check the tuning, so it is not applied in part
+	if tuning.LeftLimitAngle > tuning.CenterAngle || tuning.RightLimitAngle < tuning.CenterAngle ||
+		tuning.RightLimitAngle > h.actuationRange {
+		return h.reportError(ErrorCodeServoInvalidTuning)
+	}
+	if tuning.NeutralPulseWidth != 0 &&
+		(tuning.NeutralPulseWidth < h.minPulseWidth || tuning.NeutralPulseWidth > h.maxPulseWidth) {
+		return h.reportError(ErrorCodeServoInvalidNeutralPulseWidth)
+	}
+
+	// Apply the mapping, the neutral pulse width is restored if the calibration table is rejected
+	neutralPulseWidth := h.neutralPulseWidth
+	h.neutralPulseWidth = tuning.NeutralPulseWidth
+	if err := h.SetCalibrationTable(tuning.Calibration); err != tinygoerrors.ErrorCodeNil {
+		h.neutralPulseWidth = neutralPulseWidth
+		return err
+	}
+
+	// Apply the center and the limits
+	h.setCenterAngle(tuning.CenterAngle)
+	h.trim = tuning.Trim
+	return h.SetLimits(tuning.LeftLimitAngle, tuning.RightLimitAngle)
+}
+
+// Encode writes the tuning into a buffer, little-endian, preceded by TuningVersion
+//
+// Parameters:
+//
+// buffer: The buffer to write into, MaxTuningSize bytes fit any tuning
+//
+// Returns:
+//
+// The number of bytes written and an error if the buffer is too small
+func (t Tuning) Encode(buffer []byte) (int, tinygoerrors.ErrorCode) {
+	var points []CalibrationPoint
+	if t.Calibration != nil {
+		points = t.Calibration.Points()
+	}
+	size := TuningHeaderSize + len(points)*TuningPointSize
+	if len(buffer) < size {
+		return 0, ErrorCodeServoInvalidTuning
+	}
+	buffer[0] = TuningVersion
+	binary.LittleEndian.PutUint16(buffer[1:], t.CenterAngle)
+	binary.LittleEndian.PutUint16(buffer[3:], t.LeftLimitAngle)
+	binary.LittleEndian.PutUint16(buffer[5:], t.RightLimitAngle)
+	binary.LittleEndian.PutUint16(buffer[7:], uint16(t.Trim))
+	binary.LittleEndian.PutUint32(buffer[9:], uint32(t.NeutralPulseWidth))
+	buffer[13] = byte(len(points))
+	for index, point := range points {
+		offset := TuningHeaderSize + index*TuningPointSize
+		binary.LittleEndian.PutUint32(buffer[offset:], point.AngleMilliDegrees)
+		binary.LittleEndian.PutUint32(buffer[offset+4:], uint32(point.PulseWidth))
+	}
+	return size, tinygoerrors.ErrorCodeNil
+}
+
+// DecodeTuning reads a tuning written by Encode
+//
+// Parameters:
+//
+// data: The encoded tuning
+//
+// Returns:
+//
+// The tuning and an error if the data is truncated, of another version or its calibration table is invalid
+func DecodeTuning(data []byte) (Tuning, tinygoerrors.ErrorCode) {
+	if len(data) < TuningHeaderSize || data[0] != TuningVersion {
+		return Tuning{}, ErrorCodeServoInvalidTuning
+	}
+	tuning := Tuning{
+		CenterAngle:       binary.LittleEndian.Uint16(data[1:]),
+		LeftLimitAngle:    binary.LittleEndian.Uint16(data[3:]),
+		RightLimitAngle:   binary.LittleEndian.Uint16(data[5:]),
+		Trim:              int16(binary.LittleEndian.Uint16(data[7:])),
+		NeutralPulseWidth: Nanoseconds(binary.LittleEndian.Uint32(data[9:])),
+	}
+	count := int(data[13])
+	if count == 0 {
+		return tuning, tinygoerrors.ErrorCodeNil
+	}
+	if count > MaxCalibrationPoints || len(data) < TuningHeaderSize+count*TuningPointSize {
+		return Tuning{}, ErrorCodeServoInvalidTuning
+	}
+	var points [MaxCalibrationPoints]CalibrationPoint
+	for index := range count {
+		offset := TuningHeaderSize + index*TuningPointSize
+		points[index] = CalibrationPoint{
+			AngleMilliDegrees: binary.LittleEndian.Uint32(data[offset:]),
+			PulseWidth:        Nanoseconds(binary.LittleEndian.Uint32(data[offset+4:])),
+		}
+	}
+	table, err := NewCalibrationTable(points[:count])
+	if err != tinygoerrors.ErrorCodeNil {
+		return Tuning{}, ErrorCodeServoInvalidTuning
+	}
+	tuning.Calibration = table
+	return tuning, tinygoerrors.ErrorCodeNil
+}
+
+// SaveTuning saves the current per-unit adjustment of the handler, see GetTuning
+//
+// Parameters:
+//
+// storage: The storage to save the tuning to
+//
+// Returns:
+//
+// An error if the storage is nil or the tuning could not be stored
+func (h *DefaultHandler) SaveTuning(storage TuningStorage) tinygoerrors.ErrorCode {
+	if storage == nil {
+		return h.reportError(ErrorCodeServoNilTuningStorage)
+	}
+	var buffer [MaxTuningSize]byte
+	size, err := h.GetTuning().Encode(buffer[:])
+	if err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+	if err = storage.Store(buffer[:size]); err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// LoadTuning loads a tuning saved by SaveTuning and applies it, see ApplyTuning. The handler keeps its configured
+// adjustment if nothing valid was saved, e.g. on the first boot of a unit
+//
+// Parameters:
+//
+// storage: The storage to load the tuning from
+//
+// Returns:
+//
+// An error if the storage is nil, the tuning could not be loaded, is invalid or could not be applied
+func (h *DefaultHandler) LoadTuning(storage TuningStorage) tinygoerrors.ErrorCode {
+	if storage == nil {
+		return h.reportError(ErrorCodeServoNilTuningStorage)
+	}
+	var buffer [MaxTuningSize]byte
+	data, err := storage.Load(buffer[:])
+	if err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+	tuning, err := DecodeTuning(data)
+	if err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+	return h.ApplyTuning(tuning)
+}
@@ -0,0 +1,109 @@
+//go:build !tinygo
+
+package tinygo_servo
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// newTestTuning creates a tuning with every field set, a negative trim and a full calibration table
+func newTestTuning(t *testing.T) Tuning {
+	t.Helper()
+	return Tuning{
+		CenterAngle:       95,
+		LeftLimitAngle:    20,
+		RightLimitAngle:   170,
+		Trim:              -1250,
+		NeutralPulseWidth: 1480000,
+		Calibration:       newFullCalibrationTable(t),
+	}
+}
+
+// encodeTestTuning encodes a tuning into a buffer that fits any tuning, failing on an error
+func encodeTestTuning(t *testing.T, tuning Tuning) []byte {
+	t.Helper()
+	buffer := make([]byte, MaxTuningSize)
+	size, err := tuning.Encode(buffer)
+	if err != 0 {
+		t.Fatalf("Encode: %d", err)
+	}
+	return buffer[:size]
+}
+
+// TestTuningRoundTrip checks a decoded tuning matches the encoded one, with and without a calibration table
+func TestTuningRoundTrip(t *testing.T) {
+	withTable := newTestTuning(t)
+	withoutTable := withTable
+	withoutTable.Calibration = nil
+	for _, tuning := range []Tuning{withTable, withoutTable} {
+		data := encodeTestTuning(t, tuning)
+		decoded, err := DecodeTuning(data)
+		if err != 0 {
+			t.Fatalf("DecodeTuning: %d", err)
+		}
+		if decoded.CenterAngle != tuning.CenterAngle || decoded.LeftLimitAngle != tuning.LeftLimitAngle ||
+			decoded.RightLimitAngle != tuning.RightLimitAngle || decoded.Trim != tuning.Trim ||
+			decoded.NeutralPulseWidth != tuning.NeutralPulseWidth {
+			t.Errorf("decoded %+v, want %+v", decoded, tuning)
+		}
+		if tuning.Calibration == nil {
+			if decoded.Calibration != nil || len(data) != TuningHeaderSize {
+				t.Errorf("%d bytes with calibration %v, want %d bytes without one", len(data), decoded.Calibration, TuningHeaderSize)
+			}
+			continue
+		}
+		points, decodedPoints := tuning.Calibration.Points(), decoded.Calibration.Points()
+		if len(data) != MaxTuningSize || len(decodedPoints) != len(points) {
+			t.Fatalf("%d bytes with %d points, want %d bytes with %d", len(data), len(decodedPoints), MaxTuningSize, len(points))
+		}
+		for index, point := range points {
+			if decodedPoints[index] != point {
+				t.Errorf("point %d = %+v, want %+v", index, decodedPoints[index], point)
+			}
+		}
+	}
+}
+
+// TestTuningRejectsTruncatedData checks every prefix of an encoded tuning is rejected, and a buffer too small for the
+// tuning is not written
+func TestTuningRejectsTruncatedData(t *testing.T) {
+	tuning := newTestTuning(t)
+	data := encodeTestTuning(t, tuning)
+	for size := range len(data) {
+		if _, err := DecodeTuning(data[:size]); err != ErrorCodeServoInvalidTuning {
+			t.Errorf("DecodeTuning of %d bytes = %d, want %d", size, err, ErrorCodeServoInvalidTuning)
+		}
+	}
+	if size, err := tuning.Encode(make([]byte, len(data)-1)); err != ErrorCodeServoInvalidTuning || size != 0 {
+		t.Errorf("Encode into %d bytes = %d bytes and %d, want %d", len(data)-1, size, err, ErrorCodeServoInvalidTuning)
+	}
+}
+
+// TestTuningRejectsWrongVersion checks a tuning of another layout version is rejected
+func TestTuningRejectsWrongVersion(t *testing.T) {
+	for _, version := range []byte{0, TuningVersion + 1, 0xFF} {
+		data := encodeTestTuning(t, newTestTuning(t))
+		data[0] = version
+		if _, err := DecodeTuning(data); err != ErrorCodeServoInvalidTuning {
+			t.Errorf("DecodeTuning of version %d = %d, want %d", version, err, ErrorCodeServoInvalidTuning)
+		}
+	}
+}
+
+// TestTuningRejectsTooManyPoints checks a tuning holding more calibration points than a table fits is rejected, even
+// if the points themselves are valid
+func TestTuningRejectsTooManyPoints(t *testing.T) {
+	const count = MaxCalibrationPoints + 1
+	data := encodeTestTuning(t, Tuning{CenterAngle: 90, RightLimitAngle: 180})
+	data[13] = count
+	for index := range count {
+		var point [TuningPointSize]byte
+		binary.LittleEndian.PutUint32(point[:], uint32(index)*10000)
+		binary.LittleEndian.PutUint32(point[4:], uint32(DefaultMinPulseWidth)+uint32(index)*100000)
+		data = append(data, point[:]...)
+	}
+	if _, err := DecodeTuning(data); err != ErrorCodeServoInvalidTuning {
+		t.Errorf("DecodeTuning of %d points = %d, want %d", count, err, ErrorCodeServoInvalidTuning)
+	}
+}
@@ -1,31 +1,146 @@
 package tinygo_servo
 
 import (
-	"machine"
+	"sync/atomic"
 
 	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
 	tinygologger "github.com/ralvarezdev/tinygo-logger"
-	tinygopwm "github.com/ralvarezdev/tinygo-pwm"
 )
 
 type (
 	// DefaultHandler is the default implementation of the Servo interface
 	DefaultHandler struct {
-		afterSetAngleFunc   func(angle uint16)
-		isMovementEnabled   func() bool
-		isDirectionInverted bool
-		frequency           uint16
-		minPulseWidth       uint32
-		maxPulseWidth       uint32
-		centerAngle         uint16
-		actuationRange    uint16
-		leftLimitAngle    uint16
-		rightLimitAngle   uint16
-		angle               uint16
-		logger              tinygologger.Logger
-		pwm 			  tinygopwm.PWM
-		channel 		  uint8
-		period 				  uint32
+		afterSetAngleFunc     func(angle uint16)
+		isMovementEnabledFunc func() bool
+		isMovementDisabled    bool
+		isDirectionInverted   bool
+		frequency             uint16
+		minPulseWidth         Nanoseconds
+		maxPulseWidth         Nanoseconds
+		centerAngle           uint16
+		actuationRange        uint16
+		leftLimitAngle        uint16
+		rightLimitAngle       uint16
+		angle                 uint16
+		logger                tinygologger.Logger
+		pwm                   PWM
+		output                PulseOutput
+		pin                   Pin
+		channel               uint8
+		period                Nanoseconds
+		pulse                 Nanoseconds
+		isSleeping            bool
+		isRefreshing          bool
+		hasRefreshTimestamp   bool
+		refreshIntervalMs     uint32
+		refreshBurstMs        uint32
+		refreshTimestampMs    uint32
+		isPreparedForSleep    bool
+		rounding              Rounding
+		polarity              Polarity
+		isHardwareInverted    bool
+		warmUpFrames          uint16
+		isWarmingUp           bool
+		hasWarmUpTimestamp    bool
+		warmUpTimestampMs     uint32
+		disabledCommandPolicy DisabledCommandPolicy
+		hasPendingPulse       bool
+		name                  []byte
+		isInitialized         bool
+		isLearningLimits      bool
+		learnedLimitMarks     uint8
+		learnedLimitAngles    [2]uint16
+		limitsWarning         tinygoerrors.ErrorCode
+		angleFraction         uint16
+		isDamped              bool
+		dampedStiffness       float32
+		dampedFriction        float32
+		dampedTarget          float32
+		dampedPosition        float32
+		dampedVelocity        float32
+		hasDampedTimestamp    bool
+		dampedTimestampMs     uint32
+		errorReporter         ErrorReporter
+		componentID           uint16
+		responseLagMs         uint32
+		responseMaxSpeed      uint16
+		activeSource          ControlSource
+		sourceAngles          [controlSourcesCount]uint32
+		hasSourceAngles       [controlSourcesCount]bool
+		isTakingOver          bool
+		hasTakeOverTimestamp  bool
+		takeOverTimestampMs   uint32
+		takeOverRampMs        uint32
+		takeOverFrom          uint32
+		hasParkAngle          bool
+		parkAngle             uint16
+		nextRegistered        *DefaultHandler
+		submittedAngle        atomic.Uint32
+		submissionsRejected   atomic.Uint32
+		lastUpdateMs          uint32
+		estimateFrom          uint32
+		estimateStartMs       uint32
+		alarmZones            [MaxAlarmZones]alarmZone
+		alarmZonesCount       int
+		neutralPulseWidth     Nanoseconds
+		isDetached            bool
+		isOutputSuspended     bool
+		maxSpeed              uint16
+		hasSpeedTarget        bool
+		speedTarget           uint32
+		speedTimestampMs      uint32
+		hasUserScale          bool
+		userUnitsPerDegree    float32
+		userZeroOffset        float32
+		hasTimedMove          bool
+		moveFrom              uint32
+		moveTo                uint32
+		moveStartMs           uint32
+		moveDurationMs        uint32
+		moveEasing            Easing
+		latencyBudgetMs       uint32
+		staleCommands         uint32
+		profileVelocityCap    float32
+		profileAcceleration   float32
+		hasProfiledMove       bool
+		profileTarget         uint32
+		profilePosition       float32
+		profileVelocity       float32
+		profileTimestampMs    uint32
+		profileJerk           float32
+		isJerkLimitedMove     bool
+		profileFrom           float32
+		profileDistance       float32
+		profilePeakVelocity   float32
+		profileJerkTime       float32
+		profileAccelTime      float32
+		profileCruiseTime     float32
+		profileElapsed        float32
+		hasSweep              bool
+		sweepFrom             uint32
+		sweepTo               uint32
+		sweepPeriodMs         uint32
+		sweepStartMs          uint32
+		recording             []RecordedAngle
+		recordingCount        int
+		recordingStartMs      uint32
+		isRecording           bool
+		isReplaying           bool
+		replayIndex           int
+		replayStartMs         uint32
+		isReplayLooped        bool
+		rewriteEveryCommands  uint32
+		rewriteEveryMs        uint32
+		repeatedCommands      uint32
+		rewriteTimestampMs    uint32
+		keepAliveIntervalMs   uint32
+		keepAliveTimestampMs  uint32
+		selfCheckCapture      PulseCapture
+		selfCheckTolerance    Nanoseconds
+		slowZoneWidth         uint32
+		slowZoneSpeed         uint16
+		trim                  int16
+		calibration           *CalibrationTable
 	}
 )
 
@@ -35,7 +150,7 @@ var (
 
 	// setPulseWidthPrefix is the prefix message for new pulse width setting
 	setPulseWidthPrefix = []byte("Set servo pulse width to:")
-	
+
 	// setPeriodPrefix is the prefix for the log message when setting the PWM period
 	setPeriodPrefix = []byte("Set Servo PWM period to:")
 
@@ -47,102 +162,102 @@ var (
 
 	// setRightLimitAnglePrefix is the prefix message for right limit angle
 	setRightLimitAnglePrefix = []byte("\tServo right limit angle set to:")
+
+	// sleepMessage is the message logged when the servo enters the low-power mode
+	sleepMessage = []byte("Servo sleeping")
+
+	// wakeMessage is the message logged when the servo leaves the low-power mode
+	wakeMessage = []byte("Servo awake")
+
+	// prepareForSleepMessage is the message logged when the servo is prepared for a deep sleep of the MCU
+	prepareForSleepMessage = []byte("Servo prepared for deep sleep")
+
+	// limitsClampedMessage is the message logged when the requested limits had to be clamped to the actuation range
+	limitsClampedMessage = []byte("Servo limits clamped to the actuation range")
+
+	// resumeFromSleepMessage is the message logged when the servo is resumed after a deep sleep of the MCU
+	resumeFromSleepMessage = []byte("Servo resumed from deep sleep")
 )
 
-// NewDefaultHandler creates a new instance of DefaultHandler
+// newDefaultHandler creates a new instance of DefaultHandler, the options of NewHandler are collected into its
+// parameters
 //
 // Parameters:
 //
 // pwm: The PWM interface to control the servo
 // pin: The pin connected to the servo
 // afterSetAngleFunc: A callback function to be called after setting the angle
-// isMovementEnabled: A function to check if movement is enabled
+// isMovementEnabled: An optional function to check if movement is enabled, checked in addition to EnableMovement and
+// DisableMovement
 // frequency: The frequency of the PWM signal
-// minPulseWidth: The minimum pulse width for the servo motor
-// maxPulseWidth: The maximum pulse width for the servo motor
-// centerAngle: The center angle of the servo motor
-// maxLeftAngle: The maximum left angle from the center
-// maxRightAngle: The maximum right angle from the center
+// minPulseWidth: The minimum pulse width for the servo motor in nanoseconds
+// maxPulseWidth: The maximum pulse width for the servo motor in nanoseconds
+// actuationRange: The actuation range of the servo motor in degrees, up to MaxActuationRange
+// centerAngle: The center angle of the servo motor, between 0 and the actuation range
+// maxLeftAngle: The maximum left angle from the center, clamped so the left limit is not lower than 0
+// maxRightAngle: The maximum right angle from the center, clamped so the right limit is not higher than the actuation
+// range
 // isDirectionInverted: Whether the direction of the servo motor is inverted
 // logger: The logger instance for logging messages
+// output: The output to write the pulses through, or nil to write them to the channel of the pin in the PWM
+// peripheral
+// isDeferred: Whether to defer the PWM configuration and the channel acquisition until the first command or an
+// explicit call to Initialize
 //
 // Returns:
 //
 // An instance of DefaultHandler and an error if any occurred during initialization
-func NewDefaultHandler(
-	pwm tinygopwm.PWM,
-	pin machine.Pin,
+func newDefaultHandler(
+	pwm PWM,
+	pin Pin,
 	afterSetAngleFunc func(angle uint16),
 	isMovementEnabled func() bool,
 	frequency uint16,
-	minPulseWidth uint32,
-	maxPulseWidth uint32,
+	minPulseWidth Nanoseconds,
+	maxPulseWidth Nanoseconds,
 	actuationRange uint16,
 	centerAngle uint16,
 	maxLeftAngle uint16,
 	maxRightAngle uint16,
 	isDirectionInverted bool,
 	logger tinygologger.Logger,
+	output PulseOutput,
+	isDeferred bool,
 ) (*DefaultHandler, tinygoerrors.ErrorCode) {
-	// Check if the frequency is zero
-	if frequency == 0 {
-		return nil, ErrorCodeServoZeroFrequency
-	}
-
-	// Configure the PWM
-	period := 1e9 / float64(frequency)
-	if err := pwm.Configure(
-		machine.PWMConfig{
-			Period: uint64(period),
-		},
-	); err != nil {
-		return nil, ErrorCodeServoFailedToConfigurePWM
-	}
-
-	// Get the channel from the pin
-	channel, err := pwm.Channel(pin)
-	if err != nil {
-		return nil, ErrorCodeServoFailedToGetPWMChannel
+	// Check if the PWM is nil, it is only required when no output is provided
+	if pwm == nil && output == nil {
+		return nil, ErrorCodeServoNilPWM
 	}
 
-	// Check if the min pulse width is valid
-	if minPulseWidth == 0 || minPulseWidth >= uint32(period) {
-		return nil, ErrorCodeServoInvalidMinPulseWidth
+	// Check if the parameters are valid
+	config := Config{
+		Frequency:      frequency,
+		MinPulseWidth:  minPulseWidth,
+		MaxPulseWidth:  maxPulseWidth,
+		ActuationRange: actuationRange,
+		CenterAngle:    centerAngle,
 	}
-
-	// Check if the max pulse width is valid
-	if maxPulseWidth == 0 || maxPulseWidth >= uint32(period) {
-		return nil, ErrorCodeServoInvalidMaxPulseWidth
-	}
-
-	// Check if the actuation range is valid
-	if actuationRange == 0 || actuationRange > 360 {
-		return nil, ErrorCodeServoInvalidActuationRange
-	}
-
-	// Check if the center angle is valid
-	if centerAngle < 0 || centerAngle > actuationRange {
-		return nil, ErrorCodeServoInvalidCenterAngle
+	if err := config.Validate(); err != tinygoerrors.ErrorCodeNil {
+		return nil, err
 	}
+	period, _ := periodFromFrequency(frequency)
 
 	// Calculate the left and right limit angles
-	leftLimitAngle := centerAngle - maxLeftAngle
-	rightLimitAngle := centerAngle + maxRightAngle
-
-	// Check if the left limit angle is valid
-	if leftLimitAngle < 0 {
-		leftLimitAngle = 0
-	}
-
-	// Check if the right limit angle is valid
-	if rightLimitAngle > actuationRange {
-		rightLimitAngle = actuationRange
+	leftLimitAngle, rightLimitAngle, areLimitsClamped := deriveLimitAngles(
+		centerAngle,
+		maxLeftAngle,
+		maxRightAngle,
+		actuationRange,
+	)
+	limitsWarning := tinygoerrors.ErrorCodeNil
+	if areLimitsClamped {
+		limitsWarning = ErrorCodeServoLimitsClamped
 	}
 
 	// If the direction is inverted, swap the left and right limit angles and recalculate the center angle
 	if isDirectionInverted {
 		centerAngle = actuationRange - centerAngle
-		leftLimitAngle, rightLimitAngle = actuationRange - rightLimitAngle, actuationRange - leftLimitAngle
+		leftLimitAngle, rightLimitAngle = actuationRange-rightLimitAngle, actuationRange-leftLimitAngle
 	}
 
 	// Log the left and right limit angles if logger is provided
@@ -176,33 +291,105 @@ func NewDefaultHandler(
 			false,
 		)
 		logger.Debug()
+
+		// Warn that the requested limits exceed the actuation range
+		if areLimitsClamped {
+			logger.WarningMessageWithErrorCode(limitsClampedMessage, limitsWarning, true)
+		}
 	}
 
 	// Initialize the servo with the provided parameters
 	handler := &DefaultHandler{
-		afterSetAngleFunc:   afterSetAngleFunc,
-		isMovementEnabled:   isMovementEnabled,
-		isDirectionInverted: isDirectionInverted,
-		frequency:           frequency,
-		minPulseWidth:       minPulseWidth,
-		maxPulseWidth:       maxPulseWidth,
-		angle:               centerAngle,
-		centerAngle:         centerAngle,
-		actuationRange:    actuationRange,
-		logger:              logger,
-		pwm: 			  pwm,
-		channel: 		  channel,
-		leftLimitAngle:    leftLimitAngle,
-		rightLimitAngle:   rightLimitAngle,
-		period: 				  uint32(period),
-
-	}
-
-	// Center the servo on initialization
-	_ = handler.SetAngleToCenter()
+		afterSetAngleFunc:     afterSetAngleFunc,
+		isMovementEnabledFunc: isMovementEnabled,
+		isDirectionInverted:   isDirectionInverted,
+		frequency:             frequency,
+		minPulseWidth:         minPulseWidth,
+		maxPulseWidth:         maxPulseWidth,
+		angle:                 centerAngle,
+		centerAngle:           centerAngle,
+		actuationRange:        actuationRange,
+		logger:                logger,
+		pwm:                   pwm,
+		output:                output,
+		pin:                   pin,
+		leftLimitAngle:        leftLimitAngle,
+		rightLimitAngle:       rightLimitAngle,
+		period:                period,
+		limitsWarning:         limitsWarning,
+	}
+	if output == nil {
+		handler.output = &pwmOutput{handler: handler}
+	}
+	handler.pulse = handler.calculatePulse(centerAngle)
+	handler.estimateFrom = uint32(centerAngle) * 1000
+
+	// Configure the PWM and center the servo, unless the initialization is deferred
+	if !isDeferred {
+		if err := handler.Initialize(); err != tinygoerrors.ErrorCodeNil {
+			return nil, err
+		}
+	}
 	return handler, tinygoerrors.ErrorCodeNil
 }
 
+// Initialize configures the output, acquiring the channel of the PWM peripheral, and outputs the pulse of the current
+// angle. It is called by the constructor unless the handler was created with NewDeferredDefaultHandler, in which case
+// the first command calls it
+//
+// Returns:
+//
+// An error if the PWM peripheral could not be configured
+func (h *DefaultHandler) Initialize() tinygoerrors.ErrorCode {
+	// Check if the handler is already initialized
+	if h.isInitialized {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Check if another handler is using the PWM peripheral with a different period
+	if err := registerHandler(h); err != tinygoerrors.ErrorCodeNil {
+		return h.reportError(err)
+	}
+
+	// Configure the output with the period of the signal
+	if err := h.output.Configure(uint32(h.period)); err != tinygoerrors.ErrorCodeNil {
+		unregisterHandler(h)
+		return h.reportError(err)
+	}
+	h.isInitialized = true
+	h.applyPolarity()
+
+	// Output the pulse of the current angle, or hold the line idle if the servo is sleeping
+	if h.isSleeping {
+		h.stopPulses()
+		return tinygoerrors.ErrorCodeNil
+	}
+	if !h.canWritePulse() {
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.writePulse(h.pulse)
+
+	// Verify the output through the loopback capture if it is set, releasing the PWM peripheral if it is wrong
+	if h.selfCheckCapture != nil {
+		if _, err := h.SelfCheck(); err != tinygoerrors.ErrorCodeNil {
+			h.stopPulses()
+			h.isInitialized = false
+			unregisterHandler(h)
+			return err
+		}
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// IsInitialized checks if the PWM peripheral has been configured
+//
+// Returns:
+//
+// True if the handler is initialized, false otherwise
+func (h *DefaultHandler) IsInitialized() bool {
+	return h.isInitialized
+}
+
 // GetAngle returns the current angle of the servo motor
 //
 // Returns:
@@ -212,45 +399,168 @@ func (h *DefaultHandler) GetAngle() uint16 {
 	return h.angle
 }
 
+// LeftLimit returns the left limit angle of the servo motor, derived from its center angle, maximum left angle,
+// direction inversion and the global range cap. While the limits are being learned it is the whole actuation range
+//
+// Returns:
+//
+// The lowest absolute angle the servo motor can be set to
+func (h *DefaultHandler) LeftLimit() uint16 {
+	if h.isLearningLimits {
+		return 0
+	}
+	return h.centerAngle - capRangeFromCenter(h.centerAngle-h.leftLimitAngle)
+}
+
+// RightLimit returns the right limit angle of the servo motor, derived from its center angle, maximum right angle,
+// direction inversion and the global range cap. While the limits are being learned it is the whole actuation range
+//
+// Returns:
+//
+// The highest absolute angle the servo motor can be set to
+func (h *DefaultHandler) RightLimit() uint16 {
+	if h.isLearningLimits {
+		return h.actuationRange
+	}
+	return h.centerAngle + capRangeFromCenter(h.rightLimitAngle-h.centerAngle)
+}
+
 // SetAngle sets the angle of the servo motor
 //
 // Parameters:
 //
 // angle: The angle to set the servo motor to, must be between 0 and the actuation range
 func (h *DefaultHandler) SetAngle(angle uint16) tinygoerrors.ErrorCode {
+	return h.SetAngleMilliDegrees(uint32(angle) * 1000)
+}
+
+// SetAngleMilliDegrees sets the angle of the servo motor with a resolution of a thousandth of a degree, for motions
+// too slow to be smooth in whole degree steps. If the speed is capped, the servo motor ramps towards the angle from
+// Update
+//
+// Parameters:
+//
+// milliDegrees: The angle to set the servo motor to in millidegrees, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the angle is out of range or the servo motor could not be initialized
+func (h *DefaultHandler) SetAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	if err := h.setAngleMilliDegrees(milliDegrees); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.recordAngle(milliDegrees)
+	return tinygoerrors.ErrorCodeNil
+}
+
+// setAngleMilliDegrees sets the angle of the servo motor like SetAngleMilliDegrees without recording it, for the
+// steps of the moves driven by Update
+//
+// Parameters:
+//
+// milliDegrees: The angle to set the servo motor to in millidegrees, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the angle is out of range or the servo motor could not be initialized
+func (h *DefaultHandler) setAngleMilliDegrees(milliDegrees uint32) tinygoerrors.ErrorCode {
+	// A new angle replaces the timed, profiled, sweeping or replayed move in progress
+	h.hasTimedMove = false
+	h.hasProfiledMove = false
+	h.hasSweep = false
+	h.isReplaying = false
+	return h.commandAngle(milliDegrees)
+}
+
+// commandAngle sets the angle of the servo motor, ramping towards it if the speed is capped or it is within the slow
+// zone of a limit
+//
+// Parameters:
+//
+// milliDegrees: The angle to set the servo motor to in millidegrees, must be between the left and right limits
+//
+// Returns:
+//
+// An error if the angle is out of range or the servo motor could not be initialized
+func (h *DefaultHandler) commandAngle(milliDegrees uint32) tinygoerrors.ErrorCode {
+	// Ramp towards the angle if the speed is capped or the move reaches into a slow zone
+	if h.effectiveMaxSpeed() != 0 || h.entersSlowZone(h.GetAngleMilliDegrees(), milliDegrees) {
+		return h.startSpeedLimitedMove(milliDegrees)
+	}
+	h.hasSpeedTarget = false
+	return h.setAngleWithPulse(milliDegrees, h.calculatePulseMilliDegrees(milliDegrees))
+}
+
+// setAngleWithPulse sets the angle of the servo motor, outputting the given pulse width for it
+//
+// Parameters:
+//
+// milliDegrees: The angle to set the servo motor to in millidegrees, must be between the left and right limits
+// pulse: The pulse width to output for the angle
+//
+// Returns:
+//
+// An error if the angle is out of range or the servo motor could not be initialized
+func (h *DefaultHandler) setAngleWithPulse(milliDegrees uint32, pulse Nanoseconds) tinygoerrors.ErrorCode {
 	// Check if the angle is within the valid range
-	if angle < h.leftLimitAngle || angle > h.rightLimitAngle {
-		return ErrorCodeServoAngleOutOfRange
+	if milliDegrees < uint32(h.LeftLimit())*1000 || milliDegrees > uint32(h.RightLimit())*1000 {
+		return h.reportError(ErrorCodeServoAngleOutOfRange)
 	}
 
-	// Check if the angle is the same as the current angle
-	if angle == h.angle {
+	// Configure the PWM on the first command if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Check if the command must be discarded because movement is disabled
+	isMovementEnabled := h.IsMovementEnabled()
+	if !isMovementEnabled && h.disabledCommandPolicy == DisabledCommandPolicyDiscard {
+		return h.reportError(ErrorCodeServoMovementDisabled)
+	}
+
+	// Check if the angle and its pulse are the same as the current ones
+	if milliDegrees == h.GetAngleMilliDegrees() && pulse == h.pulse {
+		h.handleRepeatedCommand()
 		return tinygoerrors.ErrorCodeNil
 	}
 
+	// Dead-reckon the horn from where it most likely is now
+	h.startEstimate()
+
 	// Update the current angle
+	angle := uint16(milliDegrees / 1000)
 	h.angle = angle
+	h.angleFraction = uint16(milliDegrees % 1000)
 
-	// Calculate the pulse
-	pulse := uint32(h.minPulseWidth) + uint32(float64(h.maxPulseWidth-h.minPulseWidth) * float64(angle) / float64(h.actuationRange))
+	// Update the pulse, restarting the count of the repeated commands
+	h.pulse = pulse
+	h.repeatedCommands = 0
+	h.rewriteTimestampMs = h.lastUpdateMs
 
-	// Set the servo angle
-	if h.isMovementEnabled == nil || h.isMovementEnabled() {
-		tinygopwm.SetDuty(
-			h.pwm,
-			h.channel,
-			pulse,
-			h.period,
-		)
+	// Set the servo angle, commanding a new angle ends the low-power mode
+	if h.isSleeping {
+		h.Wake()
+	} else if h.canWritePulse() {
+		h.writePulse(pulse)
+	}
+
+	// Queue the pulse to be applied once movement is enabled again
+	if !isMovementEnabled && h.disabledCommandPolicy == DisabledCommandPolicyQueue {
+		h.hasPendingPulse = true
 	}
 
 	// Log the new angle if logger is provided
 	if h.logger != nil {
+		h.addLogName()
 		h.logger.AddMessageWithUint16(setAnglePrefix, angle, true, true, false)
-		h.logger.AddMessageWithUint32(setPulseWidthPrefix, pulse, true, true, false)
+		h.addLogName()
+		h.logger.AddMessageWithUint32(setPulseWidthPrefix, uint32(pulse), true, true, false)
 		h.logger.Debug()
 	}
 
+	// Notify the alarm zones entered or exited
+	h.updateAlarmZones(milliDegrees)
+
 	// Call the after set angle function if provided
 	if h.afterSetAngleFunc != nil {
 		h.afterSetAngleFunc(angle)
@@ -259,13 +569,22 @@ func (h *DefaultHandler) SetAngle(angle uint16) tinygoerrors.ErrorCode {
 	return tinygoerrors.ErrorCodeNil
 }
 
+// GetAngleMilliDegrees returns the current angle of the servo motor in millidegrees
+//
+// Returns:
+//
+// The current angle of the servo motor in millidegrees
+func (h *DefaultHandler) GetAngleMilliDegrees() uint32 {
+	return uint32(h.angle)*1000 + uint32(h.angleFraction)
+}
+
 // IsAngleCentered checks if the servo motor angle is centered
 //
 // Returns:
 //
 // True if the servo motor is centered, false otherwise
 func (h *DefaultHandler) IsAngleCentered() bool {
-	return h.angle == h.centerAngle
+	return h.angle == h.centerAngle && h.angleFraction == 0
 }
 
 // SetAngleToCenter centers the servo motor to the middle position
@@ -281,23 +600,27 @@ func (h *DefaultHandler) SetAngleToCenter() tinygoerrors.ErrorCode {
 //
 // Parameters:
 //
-// relativeAngle: The relative angle value between -90 and 90 degrees
+// relativeAngle: The relative angle value, negative to the left and positive to the right. It is clamped to the left and
+// right limits, so for a 270 degrees servo centered at 135 degrees the useful values are between -135 and 135 degrees
 //
 // Returns:
 //
-// An error if the relative angle is not within the left and right limits
+// An error if the servo angle could not be set
 func (h *DefaultHandler) SetAngleRelativeToCenter(relativeAngle int16) tinygoerrors.ErrorCode {
-	// Calculate the absolute angle based on the center angle and relative angle
+	// Calculate the absolute angle based on the center angle and relative angle, without overflowing
+	relative := int32(relativeAngle)
 	if h.isDirectionInverted {
-		relativeAngle = -relativeAngle
+		relative = -relative
 	}
-	absoluteAngle := int16(h.centerAngle) + relativeAngle
+	absoluteAngle := int32(h.centerAngle) + relative
 
 	// Check if the absolute angle is within the left and right limits
-	if absoluteAngle < int16(h.leftLimitAngle) {
-		absoluteAngle = int16(h.leftLimitAngle)
-	} else if absoluteAngle > int16(h.rightLimitAngle) {
-		absoluteAngle = int16(h.rightLimitAngle)
+	leftLimitAngle := int32(h.LeftLimit())
+	rightLimitAngle := int32(h.RightLimit())
+	if absoluteAngle < leftLimitAngle {
+		absoluteAngle = leftLimitAngle
+	} else if absoluteAngle > rightLimitAngle {
+		absoluteAngle = rightLimitAngle
 	}
 
 	// Set the servo angle
@@ -308,12 +631,15 @@ func (h *DefaultHandler) SetAngleRelativeToCenter(relativeAngle int16) tinygoerr
 //
 // Parameters:
 //
-// angle: The angle value to move the servo to the right, must be between 0 and the right limit
+// angle: The angle value to move the servo to the right, clamped to the right limit
 //
 // Returns:
 //
-// An error if the angle is not within the right limit
+// An error if the servo angle could not be set
 func (h *DefaultHandler) SetAngleToRight(angle uint16) tinygoerrors.ErrorCode {
+	if angle > MaxActuationRange {
+		angle = MaxActuationRange
+	}
 	return h.SetAngleRelativeToCenter(int16(angle))
 }
 
@@ -321,11 +647,472 @@ func (h *DefaultHandler) SetAngleToRight(angle uint16) tinygoerrors.ErrorCode {
 //
 // Parameters:
 //
-// angle: The angle value to move the servo to the left, must be between 0 and the left limit
+// angle: The angle value to move the servo to the left, clamped to the left limit
 //
 // Returns:
 //
-// An error if the angle is not within the left limit
+// An error if the servo angle could not be set
 func (h *DefaultHandler) SetAngleToLeft(angle uint16) tinygoerrors.ErrorCode {
+	if angle > MaxActuationRange {
+		angle = MaxActuationRange
+	}
 	return h.SetAngleRelativeToCenter(-int16(angle))
-}
\ No newline at end of file
+}
+
+// calculatePulse calculates the pulse width for the given angle
+//
+// Parameters:
+//
+// angle: The angle to convert, must be between 0 and the actuation range
+//
+// Returns:
+//
+// The pulse width for the given angle
+func (h *DefaultHandler) calculatePulse(angle uint16) Nanoseconds {
+	return h.calculatePulseMilliDegrees(uint32(angle) * 1000)
+}
+
+// calculatePulseMilliDegrees calculates the pulse width for the given angle in millidegrees
+//
+// Parameters:
+//
+// milliDegrees: The angle to convert in millidegrees, must be between 0 and the actuation range
+//
+// Returns:
+//
+// The pulse width for the given angle
+func (h *DefaultHandler) calculatePulseMilliDegrees(milliDegrees uint32) Nanoseconds {
+	// Interpolate the calibration table if set, otherwise map each side of the center to its own segment if the
+	// neutral pulse width is not the geometric center
+	if h.calibration != nil {
+		return h.calculateCalibratedPulseMilliDegrees(milliDegrees)
+	}
+	if h.neutralPulseWidth != 0 {
+		return h.calculateNeutralPulseMilliDegrees(milliDegrees)
+	}
+	return h.minPulseWidth + Nanoseconds(
+		divideRounded(
+			uint64(h.maxPulseWidth-h.minPulseWidth)*uint64(milliDegrees),
+			uint64(h.actuationRange)*1000,
+			h.rounding,
+		),
+	)
+}
+
+// calculateDuty calculates the PWM counter value for the given pulse width
+//
+// Parameters:
+//
+// pulse: The pulse width to convert
+//
+// Returns:
+//
+// The PWM counter value for the given pulse width
+func (h *DefaultHandler) calculateDuty(pulse Nanoseconds) uint32 {
+	// Avoid division by zero
+	if h.period == 0 {
+		return 0
+	}
+	return uint32(divideRounded(uint64(h.pwm.Top())*uint64(pulse), uint64(h.period), h.rounding))
+}
+
+// canWritePulse checks if the pulses can be written to the PWM channel
+//
+// Returns:
+//
+// True if movement is enabled, the servo is not warming up, detached nor suspended, and the PWM peripheral is
+// initialized and not prepared for a deep sleep, false otherwise
+func (h *DefaultHandler) canWritePulse() bool {
+	if !h.isInitialized || h.isPreparedForSleep || h.isWarmingUp || h.isDetached || h.isOutputSuspended {
+		return false
+	}
+	return h.IsMovementEnabled()
+}
+
+// writePulse writes the given pulse width to the output
+//
+// Parameters:
+//
+// pulse: The pulse width to output
+func (h *DefaultHandler) writePulse(pulse Nanoseconds) {
+	h.output.SetPulse(uint32(h.calculateOutputPulse(pulse)))
+	h.keepAliveTimestampMs = h.lastUpdateMs
+}
+
+// calculateOutputPulse calculates the pulse width written to the output for the given pulse width
+//
+// Parameters:
+//
+// pulse: The pulse width to output
+//
+// Returns:
+//
+// The pulse width, complemented to the period if the polarity is inverted and the PWM backend can't invert it in
+// hardware
+func (h *DefaultHandler) calculateOutputPulse(pulse Nanoseconds) Nanoseconds {
+	if h.polarity == PolarityInverted && !h.isHardwareInverted {
+		return h.period - min(pulse, h.period)
+	}
+	return pulse
+}
+
+// stopPulses holds the signal line at its idle level, so the servo stops receiving pulses
+func (h *DefaultHandler) stopPulses() {
+	if !h.isInitialized {
+		return
+	}
+
+	// The idle level of an inverted signal is high, so hold the output at full duty if it can't be inverted in hardware
+	h.output.SetPulse(uint32(h.calculateOutputPulse(0)))
+}
+
+// applyPolarity configures the output polarity in the PWM backend if it supports inverting the channel in hardware.
+// Handlers writing through a custom output always invert the pulses in software
+func (h *DefaultHandler) applyPolarity() {
+	if !h.isInitialized {
+		return
+	}
+	inverter, ok := h.pwm.(InvertingPWM)
+	if !ok {
+		h.isHardwareInverted = false
+		return
+	}
+	h.isHardwareInverted = h.polarity == PolarityInverted
+	inverter.SetInverting(h.channel, h.isHardwareInverted)
+}
+
+// Sleep stops the continuous pulses to save power. If the refresh interval is not zero, Update emits a short burst of
+// pulses at the current angle every refresh interval to re-assert the position of servos that drift when unpowered
+//
+// Parameters:
+//
+// refreshIntervalMs: The time between refresh bursts in milliseconds, or zero to disable the refresh
+// refreshBurstMs: The duration of each refresh burst in milliseconds, must be lower than the refresh interval
+//
+// Returns:
+//
+// An error if the refresh burst is not shorter than the refresh interval
+func (h *DefaultHandler) Sleep(refreshIntervalMs uint32, refreshBurstMs uint32) tinygoerrors.ErrorCode {
+	// Check if the refresh burst is valid
+	if refreshIntervalMs != 0 && (refreshBurstMs == 0 || refreshBurstMs >= refreshIntervalMs) {
+		return ErrorCodeServoInvalidSleepRefresh
+	}
+
+	// Stop the pulses
+	h.stopPulses()
+	h.isSleeping = true
+	h.isRefreshing = false
+	h.hasRefreshTimestamp = false
+	h.refreshIntervalMs = refreshIntervalMs
+	h.refreshBurstMs = refreshBurstMs
+
+	// Log the low-power mode if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(sleepMessage)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// Wake leaves the low-power mode and resumes the continuous pulses at the current angle
+func (h *DefaultHandler) Wake() {
+	// Check if the servo is sleeping
+	if !h.isSleeping {
+		return
+	}
+	h.isSleeping = false
+	h.isRefreshing = false
+
+	// Resume the pulses
+	if h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+
+	// Log the wake up if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(wakeMessage)
+	}
+}
+
+// IsSleeping checks if the servo is in the low-power mode
+//
+// Returns:
+//
+// True if the servo is sleeping, false otherwise
+func (h *DefaultHandler) IsSleeping() bool {
+	return h.isSleeping
+}
+
+// Update advances the time-based behaviors of the servo, it must be called periodically from the main loop
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) Update(nowMs uint32) {
+	h.lastUpdateMs = nowMs
+
+	// Check if the PWM peripheral is prepared for a deep sleep
+	if h.isPreparedForSleep {
+		return
+	}
+
+	h.applySubmittedAngle()
+	h.updateWarmUp(nowMs)
+	h.updateDamped(nowMs)
+	h.updateTakeOver(nowMs)
+	h.updateTimedMove(nowMs)
+	h.updateProfiledMove(nowMs)
+	h.updateSweep(nowMs)
+	h.updateReplay(nowMs)
+	h.updateSpeed(nowMs)
+	h.updateRefresh(nowMs)
+	h.updateKeepAlive(nowMs)
+	h.applyPendingPulse()
+}
+
+// updateRefresh emits the refresh bursts of the low-power mode
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateRefresh(nowMs uint32) {
+	// Check if the servo is sleeping with the refresh enabled
+	if !h.isSleeping || h.refreshIntervalMs == 0 {
+		return
+	}
+
+	// Start counting the refresh interval from the first update
+	if !h.hasRefreshTimestamp {
+		h.refreshTimestampMs = nowMs
+		h.hasRefreshTimestamp = true
+		return
+	}
+	elapsedMs := nowMs - h.refreshTimestampMs
+
+	// Stop the refresh burst once it has lasted long enough
+	if h.isRefreshing {
+		if elapsedMs >= h.refreshBurstMs {
+			h.stopPulses()
+			h.isRefreshing = false
+			h.refreshTimestampMs = nowMs
+		}
+		return
+	}
+
+	// Start a refresh burst once the refresh interval has elapsed
+	if elapsedMs >= h.refreshIntervalMs-h.refreshBurstMs {
+		if h.canWritePulse() {
+			h.writePulse(h.pulse)
+		}
+		h.isRefreshing = true
+		h.refreshTimestampMs = nowMs
+	}
+}
+
+// PrepareForSleep detaches the servo before the MCU enters a deep sleep, stopping the pulses while keeping the current
+// angle and low-power mode state. Angles set while prepared for sleep are stored and applied by ResumeFromSleep
+func (h *DefaultHandler) PrepareForSleep() {
+	// Check if the servo is already prepared for sleep
+	if h.isPreparedForSleep {
+		return
+	}
+
+	// Stop the pulses
+	h.stopPulses()
+	h.isPreparedForSleep = true
+
+	// Log the deep sleep preparation if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(prepareForSleepMessage)
+	}
+}
+
+// ResumeFromSleep reconfigures the PWM peripheral after the MCU leaves a deep sleep, since many chips lose the
+// peripheral state while sleeping, and restores the pulses at the current angle
+//
+// Returns:
+//
+// An error if the PWM peripheral could not be reconfigured
+func (h *DefaultHandler) ResumeFromSleep() tinygoerrors.ErrorCode {
+	// Check if the servo was prepared for sleep
+	if !h.isPreparedForSleep {
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.isPreparedForSleep = false
+
+	// Check if the initialization was deferred and hasn't happened yet, the first command will configure the PWM
+	if !h.isInitialized {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Reconfigure the output, acquiring the channel again
+	if err := h.output.Configure(uint32(h.period)); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+	h.applyPolarity()
+
+	// Restore the pulses unless the servo is in the low-power mode, whose refresh bursts resume from Update. Servos
+	// that lost power while sleeping are warmed up again before restoring the angle
+	if h.isSleeping {
+		h.isRefreshing = false
+		h.hasRefreshTimestamp = false
+	} else if h.warmUpFrames != 0 {
+		return h.StartWarmUp()
+	} else if h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+
+	// Log the resume if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(resumeFromSleepMessage)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// SetRounding sets the rounding behavior of the angle to pulse width and pulse width to timer ticks conversions. On
+// low-resolution timers, rounding to the nearest value makes both extremes of the range equally reachable
+//
+// Parameters:
+//
+// rounding: The rounding behavior to use
+//
+// Returns:
+//
+// An error if the rounding behavior is unknown
+func (h *DefaultHandler) SetRounding(rounding Rounding) tinygoerrors.ErrorCode {
+	// Check if the rounding behavior is known
+	if rounding != RoundingFloor && rounding != RoundingNearest && rounding != RoundingCeiling {
+		return ErrorCodeServoUnknownRounding
+	}
+	h.rounding = rounding
+
+	// Recalculate the pulse of the current angle
+	h.recalculatePulse()
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetRounding returns the rounding behavior of the angle to pulse width and pulse width to timer ticks conversions
+//
+// Returns:
+//
+// The rounding behavior
+func (h *DefaultHandler) GetRounding() Rounding {
+	return h.rounding
+}
+
+// SetPolarity sets the logic level of the pulses on the signal line, for opto-isolated or inverting driver boards that
+// need the signal inverted. The polarity is inverted in hardware if the PWM backend implements InvertingPWM, otherwise
+// the complement duty is output
+//
+// Parameters:
+//
+// polarity: The polarity of the pulses
+//
+// Returns:
+//
+// An error if the polarity is unknown
+func (h *DefaultHandler) SetPolarity(polarity Polarity) tinygoerrors.ErrorCode {
+	// Check if the polarity is known
+	if polarity != PolarityNormal && polarity != PolarityInverted {
+		return ErrorCodeServoUnknownPolarity
+	}
+	h.polarity = polarity
+
+	// Check if the PWM peripheral is prepared for a deep sleep, the polarity is applied on resume
+	if h.isPreparedForSleep {
+		return tinygoerrors.ErrorCodeNil
+	}
+	h.applyPolarity()
+
+	// Rewrite the output with the new polarity
+	if h.isSleeping && !h.isRefreshing {
+		h.stopPulses()
+	} else if h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetPolarity returns the logic level of the pulses on the signal line
+//
+// Returns:
+//
+// The polarity of the pulses
+func (h *DefaultHandler) GetPolarity() Polarity {
+	return h.polarity
+}
+
+// EnableMovement allows the servo motor to move, subject to the isMovementEnabled function if one was provided. With
+// the DisabledCommandPolicyQueue policy, the last angle commanded while movement was disabled is applied
+func (h *DefaultHandler) EnableMovement() {
+	h.isMovementDisabled = false
+	h.applyPendingPulse()
+}
+
+// DisableMovement stops the servo motor from moving, it holds the last pulse it was sent
+func (h *DefaultHandler) DisableMovement() {
+	h.isMovementDisabled = true
+}
+
+// IsMovementEnabled checks if the servo motor is allowed to move
+//
+// Returns:
+//
+// True if movement was not disabled and the isMovementEnabled function, if provided, allows it, false otherwise
+func (h *DefaultHandler) IsMovementEnabled() bool {
+	if h.isMovementDisabled {
+		return false
+	}
+	return h.isMovementEnabledFunc == nil || h.isMovementEnabledFunc()
+}
+
+// SetDisabledCommandPolicy sets how angle commands are handled while movement is disabled:
+//
+// DisabledCommandPolicyStore updates the stored angle without moving, so the servo only moves on the next command
+// after movement is enabled again.
+//
+// DisabledCommandPolicyQueue updates the stored angle and applies it as soon as movement is enabled again.
+//
+// DisabledCommandPolicyDiscard rejects the commands, keeping the stored angle unchanged.
+//
+// Parameters:
+//
+// policy: The policy to apply to the angle commands while movement is disabled
+//
+// Returns:
+//
+// An error if the policy is unknown
+func (h *DefaultHandler) SetDisabledCommandPolicy(policy DisabledCommandPolicy) tinygoerrors.ErrorCode {
+	// Check if the policy is known
+	if policy != DisabledCommandPolicyStore && policy != DisabledCommandPolicyQueue && policy != DisabledCommandPolicyDiscard {
+		return ErrorCodeServoUnknownDisabledCommandPolicy
+	}
+	h.disabledCommandPolicy = policy
+
+	// Only the queue policy keeps a pending pulse
+	if policy != DisabledCommandPolicyQueue {
+		h.hasPendingPulse = false
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// GetDisabledCommandPolicy returns how angle commands are handled while movement is disabled
+//
+// Returns:
+//
+// The policy applied to the angle commands while movement is disabled
+func (h *DefaultHandler) GetDisabledCommandPolicy() DisabledCommandPolicy {
+	return h.disabledCommandPolicy
+}
+
+// applyPendingPulse writes the pulse queued while movement was disabled, once it can be written
+func (h *DefaultHandler) applyPendingPulse() {
+	if !h.hasPendingPulse || h.isSleeping || !h.canWritePulse() {
+		return
+	}
+	h.hasPendingPulse = false
+	h.writePulse(h.pulse)
+}
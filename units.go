@@ -0,0 +1,76 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+type (
+	// Nanoseconds is a duration in nanoseconds, the unit of the periods and the pulse widths of the handlers. It is a
+	// defined type, so mixing it up with microseconds or raw counts doesn't compile, the interfaces implemented by
+	// other packages taking plain uint32 converted at the boundary
+	Nanoseconds uint32
+
+	// Microseconds is a duration in microseconds, the unit of the pulse width commands of servo testers and RC links,
+	// converted with microsecondsToNanoseconds and nanosecondsToMicroseconds
+	Microseconds uint32
+)
+
+const (
+	// nanosecondsPerSecond is the number of nanoseconds in a second, the period of a 1Hz signal
+	nanosecondsPerSecond Nanoseconds = 1000000000
+
+	// nanosecondsPerMicrosecond is the number of nanoseconds in a microsecond
+	nanosecondsPerMicrosecond Nanoseconds = 1000
+
+	// maxMicroseconds is the longest duration in microseconds that can be converted to nanoseconds without overflowing
+	maxMicroseconds Microseconds = Microseconds(^Nanoseconds(0) / nanosecondsPerMicrosecond)
+
+	// minPlausiblePulseWidth is the shortest max pulse width accepted, no servo uses pulses shorter than 10us, so a
+	// shorter one is a pulse width given in microseconds instead of nanoseconds
+	minPlausiblePulseWidth Nanoseconds = 10000
+)
+
+// periodFromFrequency calculates the period of a signal with integer math, truncated to the nanosecond
+//
+// Parameters:
+//
+// frequency: The frequency of the signal in Hz
+//
+// Returns:
+//
+// The period in nanoseconds, and an error if the frequency is zero
+func periodFromFrequency(frequency uint16) (Nanoseconds, tinygoerrors.ErrorCode) {
+	if frequency == 0 {
+		return 0, ErrorCodeServoZeroFrequency
+	}
+	return nanosecondsPerSecond / Nanoseconds(frequency), tinygoerrors.ErrorCodeNil
+}
+
+// microsecondsToNanoseconds converts a duration in microseconds to nanoseconds
+//
+// Parameters:
+//
+// us: The duration in microseconds
+//
+// Returns:
+//
+// The duration in nanoseconds, and false if it doesn't fit in Nanoseconds
+func microsecondsToNanoseconds(us Microseconds) (Nanoseconds, bool) {
+	if us > maxMicroseconds {
+		return 0, false
+	}
+	return Nanoseconds(us) * nanosecondsPerMicrosecond, true
+}
+
+// nanosecondsToMicroseconds converts a duration in nanoseconds to microseconds, rounded to the nearest
+//
+// Parameters:
+//
+// ns: The duration in nanoseconds
+//
+// Returns:
+//
+// The duration in microseconds
+func nanosecondsToMicroseconds(ns Nanoseconds) Microseconds {
+	return Microseconds(divideRounded(uint64(ns), uint64(nanosecondsPerMicrosecond), RoundingNearest))
+}
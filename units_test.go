@@ -0,0 +1,168 @@
+package tinygo_servo
+
+import (
+	"testing"
+
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// TestPeriodFromFrequency checks the period of every frequency from 50Hz to 400Hz is truncated to the nanosecond,
+// and that it converts to microseconds and back within half a microsecond
+func TestPeriodFromFrequency(t *testing.T) {
+	if _, err := periodFromFrequency(0); err != ErrorCodeServoZeroFrequency {
+		t.Fatalf("zero frequency: %d, expected %d", err, ErrorCodeServoZeroFrequency)
+	}
+	for frequency := uint16(50); frequency <= 400; frequency++ {
+		period, err := periodFromFrequency(frequency)
+		if err != 0 {
+			t.Fatalf("%dHz: %d", frequency, err)
+		}
+		if uint64(period)*uint64(frequency) > uint64(nanosecondsPerSecond) ||
+			uint64(period+1)*uint64(frequency) <= uint64(nanosecondsPerSecond) {
+			t.Fatalf("%dHz: period %dns is not truncated to the nanosecond", frequency, period)
+		}
+
+		us := nanosecondsToMicroseconds(period)
+		ns, ok := microsecondsToNanoseconds(us)
+		if !ok {
+			t.Fatalf("%dHz: %dus doesn't fit in nanoseconds", frequency, us)
+		}
+		if difference := max(ns, period) - min(ns, period); difference > nanosecondsPerMicrosecond/2 {
+			t.Fatalf("%dHz: %dns converted to %dus and back to %dns", frequency, period, us, ns)
+		}
+	}
+}
+
+// TestConfigValidatePulseWidthsAgainstPeriod checks the max pulse width must be shorter than the period over the
+// frequencies the servos are driven at, the default 2.5ms max pulse width not fitting at 400Hz
+func TestConfigValidatePulseWidthsAgainstPeriod(t *testing.T) {
+	tests := []struct {
+		frequency     uint16
+		minPulseWidth Nanoseconds
+		maxPulseWidth Nanoseconds
+		expected      tinygoerrors.ErrorCode
+	}{
+		{50, DefaultMinPulseWidth, DefaultMaxPulseWidth, 0},
+		{50, DefaultMinPulseWidth, 19999999, 0},
+		{50, DefaultMinPulseWidth, 20000000, ErrorCodeServoInvalidMaxPulseWidth},
+		{50, 20000000, 20000001, ErrorCodeServoInvalidMinPulseWidth},
+		{50, 0, DefaultMaxPulseWidth, ErrorCodeServoInvalidMinPulseWidth},
+		{50, DefaultMinPulseWidth, DefaultMinPulseWidth, ErrorCodeServoInvalidMaxPulseWidth},
+		{50, 500, 2500, ErrorCodeServoPulseWidthNotInNanoseconds},
+		{50, 1, minPlausiblePulseWidth, 0},
+		{60, DefaultMinPulseWidth, 16666665, 0},
+		{60, DefaultMinPulseWidth, 16666666, ErrorCodeServoInvalidMaxPulseWidth},
+		{300, DefaultMinPulseWidth, DefaultMaxPulseWidth, 0},
+		{333, DefaultMinPulseWidth, DefaultMaxPulseWidth, 0},
+		{333, DefaultMinPulseWidth, 3003002, 0},
+		{333, DefaultMinPulseWidth, 3003003, ErrorCodeServoInvalidMaxPulseWidth},
+		{400, DefaultMinPulseWidth, 2499999, 0},
+		{400, DefaultMinPulseWidth, DefaultMaxPulseWidth, ErrorCodeServoInvalidMaxPulseWidth},
+		{0, DefaultMinPulseWidth, DefaultMaxPulseWidth, ErrorCodeServoZeroFrequency},
+	}
+	for _, test := range tests {
+		config := DefaultConfig()
+		config.Frequency = test.frequency
+		config.MinPulseWidth = test.minPulseWidth
+		config.MaxPulseWidth = test.maxPulseWidth
+		if err := config.Validate(); err != test.expected {
+			t.Errorf(
+				"%dHz from %dns to %dns: %d, expected %d",
+				test.frequency,
+				test.minPulseWidth,
+				test.maxPulseWidth,
+				err,
+				test.expected,
+			)
+		}
+	}
+}
+
+// TestConfigValidateAngles checks the actuation range, the center angle and the neutral pulse width boundaries
+func TestConfigValidateAngles(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(config *Config)
+		expected tinygoerrors.ErrorCode
+	}{
+		{"default", func(config *Config) {}, 0},
+		{"zero actuation range", func(config *Config) { config.ActuationRange = 0 }, ErrorCodeServoInvalidActuationRange},
+		{"max actuation range", func(config *Config) { config.ActuationRange = MaxActuationRange }, 0},
+		{
+			"actuation range above the max",
+			func(config *Config) { config.ActuationRange = MaxActuationRange + 1 },
+			ErrorCodeServoInvalidActuationRange,
+		},
+		{"center at the end", func(config *Config) { config.CenterAngle = config.ActuationRange }, 0},
+		{
+			"center beyond the end",
+			func(config *Config) { config.CenterAngle = config.ActuationRange + 1 },
+			ErrorCodeServoInvalidCenterAngle,
+		},
+		{"neutral at the min", func(config *Config) { config.NeutralPulseWidth = config.MinPulseWidth }, 0},
+		{"neutral at the max", func(config *Config) { config.NeutralPulseWidth = config.MaxPulseWidth }, 0},
+		{
+			"neutral below the min",
+			func(config *Config) { config.NeutralPulseWidth = config.MinPulseWidth - 1 },
+			ErrorCodeServoInvalidNeutralPulseWidth,
+		},
+		{
+			"neutral above the max",
+			func(config *Config) { config.NeutralPulseWidth = config.MaxPulseWidth + 1 },
+			ErrorCodeServoInvalidNeutralPulseWidth,
+		},
+	}
+	for _, test := range tests {
+		config := DefaultConfig()
+		test.modify(&config)
+		if err := config.Validate(); err != test.expected {
+			t.Errorf("%s: %d, expected %d", test.name, err, test.expected)
+		}
+	}
+}
+
+// TestMicrosecondsToNanoseconds checks the conversion boundaries, the longest convertible duration included
+func TestMicrosecondsToNanoseconds(t *testing.T) {
+	tests := []struct {
+		us       Microseconds
+		expected Nanoseconds
+		ok       bool
+	}{
+		{0, 0, true},
+		{1, 1000, true},
+		{500, 500000, true},
+		{2500, 2500000, true},
+		{20000, 20000000, true},
+		{maxMicroseconds, 4294967000, true},
+		{maxMicroseconds + 1, 0, false},
+		{Microseconds(^uint32(0)), 0, false},
+	}
+	for _, test := range tests {
+		ns, ok := microsecondsToNanoseconds(test.us)
+		if ns != test.expected || ok != test.ok {
+			t.Errorf("%dus: %dns %v, expected %dns %v", test.us, ns, ok, test.expected, test.ok)
+		}
+	}
+}
+
+// TestNanosecondsToMicroseconds checks the conversion rounds to the nearest, the longest duration included
+func TestNanosecondsToMicroseconds(t *testing.T) {
+	tests := []struct {
+		ns       Nanoseconds
+		expected Microseconds
+	}{
+		{0, 0},
+		{499, 0},
+		{500, 1},
+		{1499, 1},
+		{1500, 2},
+		{2500000, 2500},
+		{3003003, 3003},
+		{Nanoseconds(^uint32(0)), 4294967},
+	}
+	for _, test := range tests {
+		if us := nanosecondsToMicroseconds(test.ns); us != test.expected {
+			t.Errorf("%dns: %dus, expected %dus", test.ns, us, test.expected)
+		}
+	}
+}
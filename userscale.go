@@ -0,0 +1,109 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+// SetUserScale sets the unit layer of the servo motor, so the application can command it in domain units, e.g. flap
+// notches or rudder degrees to starboard, and the conversion lives in the handler. The user values are measured from
+// the center in the direction of the handler, like SetAngleRelativeToCenter
+//
+// Parameters:
+//
+// unitsPerDegree: The user units per degree of the servo motor, negative to reverse the sense of the units
+// zeroOffset: The angle in degrees from the center where the user value is zero
+//
+// Returns:
+//
+// An error if the units per degree are zero
+func (h *DefaultHandler) SetUserScale(unitsPerDegree float32, zeroOffset float32) tinygoerrors.ErrorCode {
+	if unitsPerDegree == 0 {
+		return ErrorCodeServoInvalidUserScale
+	}
+	h.userUnitsPerDegree = unitsPerDegree
+	h.userZeroOffset = zeroOffset
+	h.hasUserScale = true
+	return tinygoerrors.ErrorCodeNil
+}
+
+// ClearUserScale removes the unit layer of the servo motor
+func (h *DefaultHandler) ClearUserScale() {
+	h.hasUserScale = false
+}
+
+// SetUserValue moves the servo motor to a value in user units
+//
+// Parameters:
+//
+// value: The value in user units, must be within the range returned by GetUserRange
+//
+// Returns:
+//
+// An error if the user scale is not set, the value is out of the limits or the angle could not be set
+func (h *DefaultHandler) SetUserValue(value float32) tinygoerrors.ErrorCode {
+	if !h.hasUserScale {
+		return h.reportError(ErrorCodeServoUserScaleNotSet)
+	}
+
+	// Convert the value to an absolute angle in millidegrees
+	relative := (h.userZeroOffset + value/h.userUnitsPerDegree) * 1000
+	if h.isDirectionInverted {
+		relative = -relative
+	}
+	milliDegrees := float32(uint32(h.centerAngle)*1000) + relative
+
+	// Check if the angle is within the limits, tolerating the rounding errors of the conversion
+	leftLimit := float32(uint32(h.LeftLimit()) * 1000)
+	rightLimit := float32(uint32(h.RightLimit()) * 1000)
+	if milliDegrees < leftLimit-0.5 || milliDegrees > rightLimit+0.5 {
+		return h.reportError(ErrorCodeServoAngleOutOfRange)
+	}
+	milliDegrees = max(leftLimit, min(milliDegrees, rightLimit))
+	return h.SetAngleMilliDegrees(uint32(milliDegrees + 0.5))
+}
+
+// GetUserValue returns the current angle of the servo motor in user units
+//
+// Returns:
+//
+// The value in user units, and an error if the user scale is not set
+func (h *DefaultHandler) GetUserValue() (float32, tinygoerrors.ErrorCode) {
+	if !h.hasUserScale {
+		return 0, ErrorCodeServoUserScaleNotSet
+	}
+	return h.milliDegreesToUserValue(h.GetAngleMilliDegrees()), tinygoerrors.ErrorCodeNil
+}
+
+// GetUserRange returns the values in user units at the limits of the servo motor
+//
+// Returns:
+//
+// The lowest and highest values in user units, and an error if the user scale is not set
+func (h *DefaultHandler) GetUserRange() (float32, float32, tinygoerrors.ErrorCode) {
+	if !h.hasUserScale {
+		return 0, 0, ErrorCodeServoUserScaleNotSet
+	}
+	left := h.milliDegreesToUserValue(uint32(h.LeftLimit()) * 1000)
+	right := h.milliDegreesToUserValue(uint32(h.RightLimit()) * 1000)
+	if left > right {
+		left, right = right, left
+	}
+	return left, right, tinygoerrors.ErrorCodeNil
+}
+
+// milliDegreesToUserValue converts an absolute angle to user units
+//
+// Parameters:
+//
+// milliDegrees: The absolute angle in millidegrees
+//
+// Returns:
+//
+// The value in user units
+func (h *DefaultHandler) milliDegreesToUserValue(milliDegrees uint32) float32 {
+	relative := (float32(milliDegrees) - float32(uint32(h.centerAngle)*1000)) / 1000
+	if h.isDirectionInverted {
+		relative = -relative
+	}
+	return (relative - h.userZeroOffset) * h.userUnitsPerDegree
+}
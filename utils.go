@@ -0,0 +1,110 @@
+package tinygo_servo
+
+// divideRounded divides two numbers using the given rounding behavior
+//
+// Parameters:
+//
+// numerator: The dividend
+// denominator: The divisor, must not be zero
+// rounding: The rounding behavior to apply to the quotient
+//
+// Returns:
+//
+// The rounded quotient
+func divideRounded(numerator uint64, denominator uint64, rounding Rounding) uint64 {
+	switch rounding {
+	case RoundingNearest:
+		return (numerator + denominator/2) / denominator
+	case RoundingCeiling:
+		return (numerator + denominator - 1) / denominator
+	default:
+		return numerator / denominator
+	}
+}
+
+// IsAngleWithinLimits checks if an absolute angle is within the limits of a servo handler
+//
+// Parameters:
+//
+// handler: The servo handler whose limits are used
+// angle: The absolute angle to check
+//
+// Returns:
+//
+// True if the angle is between the left and right limits of the handler, false otherwise
+func IsAngleWithinLimits(handler Handler, angle uint16) bool {
+	if handler == nil {
+		return false
+	}
+	return angle >= handler.LeftLimit() && angle <= handler.RightLimit()
+}
+
+// ClampAngle clamps an absolute angle to the limits of a servo handler
+//
+// Parameters:
+//
+// handler: The servo handler whose limits are used
+// angle: The absolute angle to clamp
+//
+// Returns:
+//
+// The angle clamped between the left and right limits of the handler
+func ClampAngle(handler Handler, angle uint16) uint16 {
+	if handler == nil {
+		return angle
+	}
+	if angle < handler.LeftLimit() {
+		return handler.LeftLimit()
+	}
+	if angle > handler.RightLimit() {
+		return handler.RightLimit()
+	}
+	return angle
+}
+
+// LimitsSpan returns the number of degrees between the limits of a servo handler
+//
+// Parameters:
+//
+// handler: The servo handler whose limits are used
+//
+// Returns:
+//
+// The difference between the right and left limits of the handler
+func LimitsSpan(handler Handler) uint16 {
+	if handler == nil {
+		return 0
+	}
+	return handler.RightLimit() - handler.LeftLimit()
+}
+
+// deriveLimitAngles calculates the limit angles of a servo motor from its center angle and the maximum angles it can
+// move to each side, clamping them to the actuation range without underflowing or overflowing
+//
+// Parameters:
+//
+// centerAngle: The center angle, between 0 and the actuation range
+// maxLowerAngle: The maximum angle to move towards 0 from the center
+// maxUpperAngle: The maximum angle to move towards the actuation range from the center
+// actuationRange: The actuation range
+//
+// Returns:
+//
+// The lowest and the highest absolute angles, and whether any of them was clamped to the actuation range
+func deriveLimitAngles(
+	centerAngle uint16,
+	maxLowerAngle uint16,
+	maxUpperAngle uint16,
+	actuationRange uint16,
+) (uint16, uint16, bool) {
+	lowerLimitAngle := uint16(0)
+	if maxLowerAngle < centerAngle {
+		lowerLimitAngle = centerAngle - maxLowerAngle
+	}
+	upperLimitAngle := actuationRange
+	if maxUpperAngle < actuationRange-centerAngle {
+		upperLimitAngle = centerAngle + maxUpperAngle
+	}
+	isClamped := maxLowerAngle > centerAngle || maxUpperAngle > actuationRange-centerAngle
+	return lowerLimitAngle, upperLimitAngle, isClamped
+}
@@ -0,0 +1,122 @@
+package tinygo_servo
+
+import (
+	tinygoerrors "github.com/ralvarezdev/tinygo-errors"
+)
+
+var (
+	// warmUpStartMessage is the message logged when the servo starts warming up
+	warmUpStartMessage = []byte("Servo warming up")
+
+	// warmUpEndMessage is the message logged when the servo finishes warming up
+	warmUpEndMessage = []byte("Servo warmed up")
+)
+
+// SetWarmUpFrames sets the number of center pulse frames sent by StartWarmUp before accepting commands, for digital
+// servos that ignore the first pulses after power-up. Resuming from a deep sleep also warms up the servo
+//
+// Parameters:
+//
+// frames: The number of PWM periods to send the center pulse for, or zero to disable the warm-up
+func (h *DefaultHandler) SetWarmUpFrames(frames uint16) {
+	h.warmUpFrames = frames
+}
+
+// GetWarmUpFrames returns the number of center pulse frames sent by StartWarmUp before accepting commands
+//
+// Returns:
+//
+// The number of PWM periods the center pulse is sent for
+func (h *DefaultHandler) GetWarmUpFrames() uint16 {
+	return h.warmUpFrames
+}
+
+// StartWarmUp sends the center pulse for the configured number of frames, driven by Update. Angles set while warming
+// up are not lost, the last one is applied once the warm-up finishes
+//
+// Returns:
+//
+// An error if the PWM peripheral could not be configured
+func (h *DefaultHandler) StartWarmUp() tinygoerrors.ErrorCode {
+	// Check if the warm-up is enabled
+	if h.warmUpFrames == 0 {
+		return tinygoerrors.ErrorCodeNil
+	}
+
+	// Configure the PWM if the initialization was deferred
+	if err := h.Initialize(); err != tinygoerrors.ErrorCodeNil {
+		return err
+	}
+
+	// Commanding the warm-up ends the low-power mode
+	h.isSleeping = false
+	h.isRefreshing = false
+
+	// Send the center pulse
+	if h.canWritePulse() {
+		h.writePulse(h.calculatePulse(h.centerAngle))
+	}
+	h.isWarmingUp = true
+	h.hasWarmUpTimestamp = false
+
+	// Log the warm-up if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(warmUpStartMessage)
+	}
+	return tinygoerrors.ErrorCodeNil
+}
+
+// IsWarmingUp checks if the servo is sending the warm-up center pulse
+//
+// Returns:
+//
+// True if the servo is warming up, false otherwise
+func (h *DefaultHandler) IsWarmingUp() bool {
+	return h.isWarmingUp
+}
+
+// warmUpDurationMs returns the duration of the warm-up
+//
+// Returns:
+//
+// The time taken by the configured number of frames in milliseconds, rounded up
+func (h *DefaultHandler) warmUpDurationMs() uint32 {
+	return uint32((uint64(h.warmUpFrames)*uint64(h.period) + 999999) / 1000000)
+}
+
+// updateWarmUp finishes the warm-up once the configured number of frames have been sent
+//
+// Parameters:
+//
+// nowMs: The current time in milliseconds, it may wrap around
+func (h *DefaultHandler) updateWarmUp(nowMs uint32) {
+	// Check if the servo is warming up
+	if !h.isWarmingUp {
+		return
+	}
+
+	// Start counting the frames from the first update
+	if !h.hasWarmUpTimestamp {
+		h.warmUpTimestampMs = nowMs
+		h.hasWarmUpTimestamp = true
+		return
+	}
+
+	// Check if the frames have been sent
+	if nowMs-h.warmUpTimestampMs < h.warmUpDurationMs() {
+		return
+	}
+	h.isWarmingUp = false
+
+	// Apply the last commanded angle
+	if h.canWritePulse() {
+		h.writePulse(h.pulse)
+	}
+
+	// Log the end of the warm-up if logger is provided
+	if h.logger != nil {
+		h.addLogName()
+		h.logger.DebugMessage(warmUpEndMessage)
+	}
+}